@@ -1,15 +1,29 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"ASMO-site-backend/internal/antispam"
+	"ASMO-site-backend/internal/auth"
 	"ASMO-site-backend/internal/cache"
 	"ASMO-site-backend/internal/config"
 	"ASMO-site-backend/internal/database"
 	"ASMO-site-backend/internal/handlers"
+	"ASMO-site-backend/internal/jobs"
 	"ASMO-site-backend/internal/middleware"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/notify"
+	"ASMO-site-backend/internal/repository"
+	"ASMO-site-backend/internal/storage"
 	"ASMO-site-backend/internal/validation"
 	"ASMO-site-backend/pkg/logger"
 
@@ -86,6 +100,74 @@ func prometheusMiddleware() gin.HandlerFunc {
 	}
 }
 
+// bootstrapAdmin создает первого администратора из ADMIN_USERNAME/ADMIN_PASSWORD, если таблица пуста
+func bootstrapAdmin(users *repository.AdminUsersRepository, username, password string) error {
+	if username == "" || password == "" {
+		return nil
+	}
+
+	ctx := context.Background()
+	count, err := users.Count(ctx)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	if _, err := users.Create(ctx, username, hash, []string{auth.RoleSuperadmin}); err != nil {
+		return err
+	}
+
+	log.Printf("Initial admin user %q created", username)
+	return nil
+}
+
+// shutdownTimeout сколько при остановке ждать текущие запросы и отправку поставленных писем
+const shutdownTimeout = 30 * time.Second
+
+// newMediaStorage выбирает хранилище медиатеки по MEDIA_STORAGE
+func newMediaStorage(cfg *config.Config) storage.Storage {
+	if cfg.MediaStorage == "s3" {
+		return storage.NewS3(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PublicURL: cfg.S3PublicURL,
+		})
+	}
+	return storage.NewLocal(cfg.MediaDir, cfg.MediaBaseURL)
+}
+
+// newFormGuard антиспам публичных форм; капча подключается, только если задан CAPTCHA_SECRET
+func newFormGuard(cfg *config.Config, redisCache cache.Cache, tokens *antispam.FormTokens, form string) *antispam.Guard {
+	checks := []antispam.Check{
+		antispam.Honeypot{},
+		tokens,
+		antispam.NewContent(cfg.FormMaxLinks, cfg.FormBlocklist),
+		antispam.NewThrottle(redisCache, form, cfg.FormRateLimitIP, cfg.FormRateLimitEmail, cfg.FormRateWindow),
+	}
+	if cfg.CaptchaSecret != "" {
+		checks = append(checks, antispam.NewCaptcha(antispam.NewSiteVerify(cfg.CaptchaVerifyURL, cfg.CaptchaSecret)))
+	}
+	// Последней: токен гасится, только когда отправка прошла все остальные проверки
+	checks = append(checks, tokens.SingleUse())
+	return antispam.New(checks...)
+}
+
+// newNotifier email-уведомления команды; без SMTP_HOST или NOTIFY_RECIPIENTS отключены (nil)
+func newNotifier(cfg *config.Config, appLogger *logger.Logger) *notify.Notifier {
+	if cfg.SMTPHost == "" || len(cfg.NotifyRecipients) == 0 {
+		return nil
+	}
+	sender := notify.NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+	return notify.New(sender, cfg.NotifyRecipients, cfg.NotifyRetries, cfg.NotifyBackoff, appLogger)
+}
+
 func main() {
 	// Load configuration
 	cfg := config.Load()
@@ -95,6 +177,12 @@ func main() {
 		if strings.Contains(cfg.DatabaseURL, "password@") && strings.Contains(cfg.DatabaseURL, "password") {
 			log.Fatal("Default database password detected in production - use DB_PASSWORD environment variable")
 		}
+		if len(cfg.JWTSecret) < 32 {
+			log.Fatal("JWT_SECRET must be set to at least 32 characters in production")
+		}
+		if len(cfg.FormSecret) < 32 || cfg.FormSecret == cfg.JWTSecret {
+			log.Fatal("FORM_SECRET must be set to at least 32 characters in production and differ from JWT_SECRET")
+		}
 	}
 
 	// Initialize logger
@@ -140,12 +228,84 @@ func main() {
 		gin.SetMode(gin.DebugMode)
 	}
 
+	// Initialize admin authentication
+	tokenManager := auth.NewTokenManager(cfg.JWTSecret, cfg.AccessTokenTTL)
+	rolesRepository := repository.NewRolesRepository(db, redisCache)
+	apiKeysRepository := repository.NewAPIKeysRepository(db, redisCache)
+	if err := bootstrapAdmin(repository.NewAdminUsersRepository(db, redisCache), cfg.AdminUsername, cfg.AdminPassword); err != nil {
+		appLogger.Error("Failed to create initial admin user", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	// Project repositories notify the team on publication, both manual and scheduled,
+	// and pull external img URLs into the media library so they get resized variants
+	notifier := newNotifier(cfg, appLogger)
+	mediaHandler := handlers.NewMediaHandler(db, redisCache, newMediaStorage(cfg), cfg.MediaMaxSize)
+	webRepository := repository.NewWebProjectsRepository(db, redisCache).
+		ImportExternalImages(mediaHandler.ImportImage).
+		OnPublish(func(ctx context.Context, p models.WebProjects) {
+			notifier.ProjectPublished(notify.Project{Type: "web", ID: p.ID, Name: p.Name, Slug: p.Slug, Description: p.Description, Price: p.Price})
+		})
+	mobileRepository := repository.NewMobileProjectsRepository(db, redisCache).
+		ImportExternalImages(mediaHandler.ImportImage).
+		OnPublish(func(ctx context.Context, p models.MobileProjects) {
+			notifier.ProjectPublished(notify.Project{Type: "mobile", ID: p.ID, Name: p.Name, Slug: p.Slug, Description: p.Description, Price: p.Price})
+		})
+	botRepository := repository.NewBotProjectsRepository(db, redisCache).
+		ImportExternalImages(mediaHandler.ImportImage).
+		OnPublish(func(ctx context.Context, p models.BotsProjects) {
+			notifier.ProjectPublished(notify.Project{Type: "bot", ID: p.ID, Name: p.Name, Slug: p.Slug, Description: p.Description, Price: p.Price})
+		})
+	staffRepository := repository.NewStaffRepository(db, redisCache).
+		ImportExternalImages(mediaHandler.ImportImage)
+
 	// Initialize handlers with Redis cache
 	healthHandler := handlers.NewHealthHandlerWithLogger(db, appLogger)
-	webHandler := handlers.NewWebProjectsHandler(db, redisCache)
-	mobileHandler := handlers.NewMobileProjectsHandler(db, redisCache)
-	botHandler := handlers.NewBotProjectsHandler(db, redisCache)
-	staffHandler := handlers.NewStaffHandler(db, redisCache)
+	webHandler := handlers.NewWebProjectsHandlerWithRepository(webRepository)
+	mobileHandler := handlers.NewMobileProjectsHandlerWithRepository(mobileRepository)
+	botHandler := handlers.NewBotProjectsHandlerWithRepository(botRepository)
+	staffHandler := handlers.NewStaffHandlerWithRepository(staffRepository)
+	searchHandler := handlers.NewSearchHandler(db, redisCache)
+	projectsHandler := handlers.NewProjectsHandler(db, redisCache)
+	authHandler := handlers.NewAuthHandler(db, redisCache, tokenManager, cfg.RefreshTokenTTL)
+	adminUsersHandler := handlers.NewAdminUsersHandler(db, redisCache)
+	apiKeysHandler := handlers.NewAPIKeysHandler(db, redisCache)
+	auditHandler := handlers.NewAuditHandler(db)
+	trashHandler := handlers.NewTrashHandler(db)
+	translationsHandler := handlers.NewTranslationsHandler(db)
+	tagsHandler := handlers.NewTagsHandler(db, redisCache)
+	formTokens := antispam.NewFormTokens(cfg.FormSecret, redisCache, cfg.FormMinFillTime, cfg.FormMaxAge)
+	formsHandler := handlers.NewFormsHandler(formTokens)
+	leadsHandler := handlers.NewLeadsHandler(db, newFormGuard(cfg, redisCache, formTokens, "leads"), notifier)
+
+	// Purge job for soft-deleted rows
+	trashPurger := jobs.NewTrashPurger(map[string]jobs.Purger{
+		"web_projects":    webRepository,
+		"mobile_projects": mobileRepository,
+		"bots_projects":   botRepository,
+		"staff":           staffRepository,
+	}, cfg.TrashRetention, cfg.TrashPurgeInterval, appLogger)
+	// Background jobs stop together with the server on SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var jobsWG sync.WaitGroup
+	jobsWG.Add(2)
+	go func() {
+		defer jobsWG.Done()
+		trashPurger.Run(ctx)
+	}()
+
+	// Scheduled publishing; replicas elect a leader per tick via Redis lock
+	publishScheduler := jobs.NewPublishScheduler(map[string]jobs.Scheduled{
+		"web_projects":    webRepository,
+		"mobile_projects": mobileRepository,
+		"bots_projects":   botRepository,
+	}, redisCache, cfg.SchedulerInterval, appLogger)
+	go func() {
+		defer jobsWG.Done()
+		publishScheduler.Run(ctx)
+	}()
 
 	// Initialize router
 	router := gin.Default()
@@ -165,7 +325,7 @@ func main() {
 	// CORS configuration
 	corsConfig := cors.Config{
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "Accept", "X-Requested-With"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "Accept", "X-Requested-With", middleware.APIKeyHeader},
 		ExposeHeaders:    []string{"Content-Length", "Content-Range"},
 		AllowCredentials: true,
 		MaxAge:           12 * 60 * 60,
@@ -224,6 +384,12 @@ func main() {
 	// Logging middleware
 	router.Use(middleware.LoggingMiddleware(appLogger))
 
+	// API keys for machine clients (X-API-Key)
+	router.Use(middleware.APIKeyAuth(apiKeysRepository, redisCache))
+
+	// Язык ответа: ?lang= или Accept-Language
+	router.Use(middleware.Locale(cfg.Locales, cfg.DefaultLocale))
+
 	// Fix favicon
 	router.GET("/favicon.ico", func(c *gin.Context) {
 		c.Status(204)
@@ -232,38 +398,238 @@ func main() {
 	// Health check
 	router.GET("/api/health", healthHandler.HealthCheck)
 
+	// Локальная медиатека раздается самим сервером, S3 - напрямую из бакета
+	if cfg.MediaStorage != "s3" {
+		router.Static("/media", cfg.MediaDir)
+	}
+
+	// Auth routes
+	authRoutes := router.Group("/api/auth")
+	{
+		authRoutes.POST("/login", authHandler.Login)
+		authRoutes.POST("/refresh", authHandler.Refresh)
+		authRoutes.POST("/logout", authHandler.Logout)
+	}
+
+	// Чтение публичное, запись только для администраторов с соответствующим правом
+	requireAdmin := middleware.RequireAuthForWrites(tokenManager)
+	manageProjects := middleware.RequirePermissionForWrites(rolesRepository, auth.PermissionManageProjects)
+	manageStaff := middleware.RequirePermissionForWrites(rolesRepository, auth.PermissionManageStaff)
+	approvePublish := middleware.RequirePermission(rolesRepository, auth.PermissionPublish)
+	// История версий и переводы не публичные, даже на чтение
+	requireAuth := middleware.RequireAuth(tokenManager)
+	projectsAdmin := middleware.RequirePermission(rolesRepository, auth.PermissionManageProjects)
+	staffAdmin := middleware.RequirePermission(rolesRepository, auth.PermissionManageStaff)
+
+	// Admin routes - все методы требуют аутентификации
+	admin := router.Group("/api/admin", middleware.RequireAuth(tokenManager))
+	{
+		users := admin.Group("/users", middleware.RequirePermission(rolesRepository, auth.PermissionManageUsers))
+		{
+			users.GET("/", adminUsersHandler.ListUsers)
+			users.POST("/", adminUsersHandler.CreateUser)
+			users.PUT("/:id/roles", adminUsersHandler.SetUserRoles)
+			users.DELETE("/:id", adminUsersHandler.DeleteUser)
+		}
+		admin.GET("/roles", middleware.RequirePermission(rolesRepository, auth.PermissionManageUsers), adminUsersHandler.ListRoles)
+
+		apiKeys := admin.Group("/api-keys", middleware.RequirePermission(rolesRepository, auth.PermissionManageAPIKeys))
+		{
+			apiKeys.GET("/", apiKeysHandler.ListAPIKeys)
+			apiKeys.POST("/", apiKeysHandler.CreateAPIKey)
+			apiKeys.DELETE("/:id", apiKeysHandler.RevokeAPIKey)
+		}
+
+		admin.GET("/audit", middleware.RequirePermission(rolesRepository, auth.PermissionViewAudit), auditHandler.GetAuditLog)
+		// Корзина и отчет о переводах общие для проектов и сотрудников: каждому видны только записи, которыми он управляет
+		contentAdmin := middleware.RequireAnyPermission(rolesRepository, auth.PermissionManageProjects, auth.PermissionManageStaff)
+		admin.GET("/trash", contentAdmin, trashHandler.GetTrash)
+		admin.GET("/translations/missing", contentAdmin, translationsHandler.GetMissingTranslations)
+
+		media := admin.Group("/media", middleware.RequirePermission(rolesRepository, auth.PermissionManageMedia))
+		{
+			media.GET("/", mediaHandler.ListMedia)
+			media.POST("/", mediaHandler.UploadMedia)
+			media.POST("/import", mediaHandler.ImportMedia)
+			media.DELETE("/:id", mediaHandler.DeleteMedia)
+		}
+
+		leads := admin.Group("/leads", middleware.RequirePermission(rolesRepository, auth.PermissionManageLeads))
+		{
+			leads.GET("/", leadsHandler.GetLeads)
+			leads.GET("/:id", leadsHandler.GetLead)
+			leads.PUT("/:id/assignee", leadsHandler.AssignLead)
+			leads.PUT("/:id/status", leadsHandler.SetLeadStatus)
+		}
+	}
+
+	// Public contact form
+	router.GET("/api/forms/token", formsHandler.GetFormToken)
+	router.POST("/api/leads", leadsHandler.CreateLead)
+
 	// Web Applications routes
-	web := router.Group("/api/WebApplications")
+	web := router.Group("/api/WebApplications", requireAdmin, manageProjects)
 	{
 		web.GET("/:id", webHandler.GetWebProject)
 		web.GET("/", webHandler.GetWebProjects)
 		web.POST("/", webHandler.CreateWebProject)
+		web.PUT("/:id", webHandler.UpdateWebProject)
+		web.PATCH("/:id", webHandler.PatchWebProject)
+		web.DELETE("/:id", webHandler.DeleteWebProject)
+		web.POST("/:id/restore", webHandler.RestoreWebProject)
+		web.POST("/:id/submit", webHandler.TransitionWebProject("submit"))
+		web.POST("/:id/publish", approvePublish, webHandler.TransitionWebProject("publish"))
+		web.POST("/:id/reject", webHandler.TransitionWebProject("reject"))
+		web.POST("/:id/archive", webHandler.TransitionWebProject("archive"))
+		web.POST("/:id/reopen", webHandler.TransitionWebProject("reopen"))
+		web.PUT("/:id/schedule", approvePublish, webHandler.ScheduleWebProject)
+		web.POST("/:id/media", webHandler.AddWebProjectMedia)
+		web.PUT("/:id/media/order", webHandler.ReorderWebProjectMedia)
+		web.DELETE("/:id/media/:item", webHandler.RemoveWebProjectMedia)
+		web.PUT("/:id/tags", webHandler.SetWebProjectTags)
+		web.PUT("/:id/team/:staff_id", webHandler.AssignWebProjectMember)
+		web.DELETE("/:id/team/:staff_id", webHandler.UnassignWebProjectMember)
+
+		webRevisions := web.Group("/:id/revisions", requireAuth, projectsAdmin)
+		{
+			webRevisions.GET("/", webHandler.GetWebProjectRevisions)
+			webRevisions.GET("/:rev", webHandler.GetWebProjectRevision)
+			webRevisions.POST("/:rev/restore", webHandler.RestoreWebProjectRevision)
+		}
+
+		webTranslations := web.Group("/:id/translations", requireAuth, projectsAdmin)
+		{
+			webTranslations.GET("/", webHandler.GetWebProjectTranslations)
+			webTranslations.PUT("/:locale", webHandler.SetWebProjectTranslation)
+			webTranslations.DELETE("/:locale", webHandler.DeleteWebProjectTranslation)
+		}
 	}
 
 	// Mobile Applications routes
-	mobile := router.Group("/api/MobileApplications")
+	mobile := router.Group("/api/MobileApplications", requireAdmin, manageProjects)
 	{
 		mobile.GET("/:id", mobileHandler.GetMobileProject)
 		mobile.GET("/", mobileHandler.GetMobileProjects)
 		mobile.POST("/", mobileHandler.CreateMobileProject)
+		mobile.PUT("/:id", mobileHandler.UpdateMobileProject)
+		mobile.PATCH("/:id", mobileHandler.PatchMobileProject)
+		mobile.DELETE("/:id", mobileHandler.DeleteMobileProject)
+		mobile.POST("/:id/restore", mobileHandler.RestoreMobileProject)
+		mobile.POST("/:id/submit", mobileHandler.TransitionMobileProject("submit"))
+		mobile.POST("/:id/publish", approvePublish, mobileHandler.TransitionMobileProject("publish"))
+		mobile.POST("/:id/reject", mobileHandler.TransitionMobileProject("reject"))
+		mobile.POST("/:id/archive", mobileHandler.TransitionMobileProject("archive"))
+		mobile.POST("/:id/reopen", mobileHandler.TransitionMobileProject("reopen"))
+		mobile.PUT("/:id/schedule", approvePublish, mobileHandler.ScheduleMobileProject)
+		mobile.POST("/:id/media", mobileHandler.AddMobileProjectMedia)
+		mobile.PUT("/:id/media/order", mobileHandler.ReorderMobileProjectMedia)
+		mobile.DELETE("/:id/media/:item", mobileHandler.RemoveMobileProjectMedia)
+		mobile.PUT("/:id/tags", mobileHandler.SetMobileProjectTags)
+		mobile.PUT("/:id/team/:staff_id", mobileHandler.AssignMobileProjectMember)
+		mobile.DELETE("/:id/team/:staff_id", mobileHandler.UnassignMobileProjectMember)
+
+		mobileRevisions := mobile.Group("/:id/revisions", requireAuth, projectsAdmin)
+		{
+			mobileRevisions.GET("/", mobileHandler.GetMobileProjectRevisions)
+			mobileRevisions.GET("/:rev", mobileHandler.GetMobileProjectRevision)
+			mobileRevisions.POST("/:rev/restore", mobileHandler.RestoreMobileProjectRevision)
+		}
+
+		mobileTranslations := mobile.Group("/:id/translations", requireAuth, projectsAdmin)
+		{
+			mobileTranslations.GET("/", mobileHandler.GetMobileProjectTranslations)
+			mobileTranslations.PUT("/:locale", mobileHandler.SetMobileProjectTranslation)
+			mobileTranslations.DELETE("/:locale", mobileHandler.DeleteMobileProjectTranslation)
+		}
 	}
 
 	// Bots routes
-	bots := router.Group("/api/Bots")
+	bots := router.Group("/api/Bots", requireAdmin, manageProjects)
 	{
 		bots.GET("/:id", botHandler.GetBotProject)
 		bots.GET("/", botHandler.GetBotProjects)
 		bots.POST("/", botHandler.CreateBotProject)
+		bots.PUT("/:id", botHandler.UpdateBotProject)
+		bots.PATCH("/:id", botHandler.PatchBotProject)
+		bots.DELETE("/:id", botHandler.DeleteBotProject)
+		bots.POST("/:id/restore", botHandler.RestoreBotProject)
+		bots.POST("/:id/submit", botHandler.TransitionBotProject("submit"))
+		bots.POST("/:id/publish", approvePublish, botHandler.TransitionBotProject("publish"))
+		bots.POST("/:id/reject", botHandler.TransitionBotProject("reject"))
+		bots.POST("/:id/archive", botHandler.TransitionBotProject("archive"))
+		bots.POST("/:id/reopen", botHandler.TransitionBotProject("reopen"))
+		bots.PUT("/:id/schedule", approvePublish, botHandler.ScheduleBotProject)
+		bots.POST("/:id/media", botHandler.AddBotProjectMedia)
+		bots.PUT("/:id/media/order", botHandler.ReorderBotProjectMedia)
+		bots.DELETE("/:id/media/:item", botHandler.RemoveBotProjectMedia)
+		bots.PUT("/:id/tags", botHandler.SetBotProjectTags)
+		bots.PUT("/:id/team/:staff_id", botHandler.AssignBotProjectMember)
+		bots.DELETE("/:id/team/:staff_id", botHandler.UnassignBotProjectMember)
+
+		botsRevisions := bots.Group("/:id/revisions", requireAuth, projectsAdmin)
+		{
+			botsRevisions.GET("/", botHandler.GetBotProjectRevisions)
+			botsRevisions.GET("/:rev", botHandler.GetBotProjectRevision)
+			botsRevisions.POST("/:rev/restore", botHandler.RestoreBotProjectRevision)
+		}
+
+		botsTranslations := bots.Group("/:id/translations", requireAuth, projectsAdmin)
+		{
+			botsTranslations.GET("/", botHandler.GetBotProjectTranslations)
+			botsTranslations.PUT("/:locale", botHandler.SetBotProjectTranslation)
+			botsTranslations.DELETE("/:locale", botHandler.DeleteBotProjectTranslation)
+		}
+	}
+
+	// Unified projects catalog
+	router.GET("/api/Projects", projectsHandler.GetProjects)
+
+	// Tags taxonomy
+	tags := router.Group("/api/Tags", requireAdmin, manageProjects)
+	{
+		tags.GET("/", tagsHandler.GetTags)
+		tags.GET("/cloud", tagsHandler.GetTagCloud)
+		tags.GET("/:id", tagsHandler.GetTag)
+		tags.POST("/", tagsHandler.CreateTag)
+		tags.PATCH("/:id", tagsHandler.PatchTag)
+		tags.DELETE("/:id", tagsHandler.DeleteTag)
 	}
 
 	// Staff routes
-	staff := router.Group("/api/Members")
+	staff := router.Group("/api/Members", requireAdmin, manageStaff)
 	{
 		staff.GET("/:id", staffHandler.GetStaffMember)
 		staff.GET("/", staffHandler.GetStaff)
 		staff.POST("/", staffHandler.CreateStaff)
+		staff.PUT("/order", staffHandler.ReorderStaff)
+		staff.PUT("/:id", staffHandler.UpdateStaff)
+		staff.PATCH("/:id", staffHandler.PatchStaff)
+		staff.DELETE("/:id", staffHandler.DeleteStaff)
+		staff.POST("/:id/restore", staffHandler.RestoreStaff)
+		staff.POST("/:id/submit", staffHandler.TransitionStaff("submit"))
+		staff.POST("/:id/publish", approvePublish, staffHandler.TransitionStaff("publish"))
+		staff.POST("/:id/reject", staffHandler.TransitionStaff("reject"))
+		staff.POST("/:id/archive", staffHandler.TransitionStaff("archive"))
+		staff.POST("/:id/reopen", staffHandler.TransitionStaff("reopen"))
+
+		staffRevisions := staff.Group("/:id/revisions", requireAuth, staffAdmin)
+		{
+			staffRevisions.GET("/", staffHandler.GetStaffRevisions)
+			staffRevisions.GET("/:rev", staffHandler.GetStaffRevision)
+			staffRevisions.POST("/:rev/restore", staffHandler.RestoreStaffRevision)
+		}
+
+		staffTranslations := staff.Group("/:id/translations", requireAuth, staffAdmin)
+		{
+			staffTranslations.GET("/", staffHandler.GetStaffTranslations)
+			staffTranslations.PUT("/:locale", staffHandler.SetStaffTranslation)
+			staffTranslations.DELETE("/:locale", staffHandler.DeleteStaffTranslation)
+		}
 	}
 
+	// Full-text search
+	router.GET("/api/search", searchHandler.Search)
+
 	// Root endpoint
 	router.GET("/", func(c *gin.Context) {
 		c.JSON(200, gin.H{
@@ -292,5 +658,36 @@ func main() {
 		"redis_enabled": true,
 	})
 	log.Printf("Server running in %s mode on http://localhost:%s", cfg.Environment, cfg.Port)
-	log.Fatal(router.Run(":" + cfg.Port))
+
+	server := &http.Server{
+		Addr:    ":" + cfg.Port,
+		Handler: router,
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	appLogger.Info("Server shutting down", nil)
+
+	// Finish in-flight requests, then let running job ticks complete so no new notifications are queued
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		appLogger.Error("Failed to shut down server gracefully", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	jobsWG.Wait()
+
+	// Deliver queued lead and publication emails, including pending retries
+	if err := notifier.Shutdown(shutdownCtx); err != nil {
+		appLogger.Error("Pending notifications dropped on shutdown", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	appLogger.Info("Server stopped", nil)
 }
\ No newline at end of file
@@ -0,0 +1,84 @@
+package antispam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Причины отклонения отправки; хэндлер переводит их в 400, ThrottledError - в 429
+var (
+	ErrSpam      = errors.New("submission looks like spam")
+	ErrFormToken = errors.New("invalid or expired form token")
+	ErrTooFast   = errors.New("form submitted too fast")
+	ErrCaptcha   = errors.New("captcha verification failed")
+)
+
+// ThrottledError лимит отправок исчерпан; RetryAfter - время до начала следующего окна
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("too many submissions, retry after %s", e.RetryAfter)
+}
+
+// Submission данные отправки формы, которые нужны проверкам
+type Submission struct {
+	IP string
+	// Email контакт отправителя для лимита на один адрес; пустой - лимит не применяется
+	Email string
+	// Honeypot скрытое поле формы, человек его не видит и не заполняет
+	Honeypot     string
+	FormToken    string
+	CaptchaToken string
+	// Text свободный текст формы для эвристик
+	Text []string
+}
+
+// Check одна проверка отправки; nil - проверка пройдена
+type Check interface {
+	Check(ctx context.Context, s Submission) error
+}
+
+// CheckFunc позволяет передать функцию как Check
+type CheckFunc func(ctx context.Context, s Submission) error
+
+func (f CheckFunc) Check(ctx context.Context, s Submission) error {
+	return f(ctx, s)
+}
+
+// Guard набор проверок публичной формы; проверки идут по порядку до первой ошибки.
+// nil Guard пропускает все отправки
+type Guard struct {
+	checks []Check
+}
+
+func New(checks ...Check) *Guard {
+	return &Guard{
+		checks: checks,
+	}
+}
+
+func (g *Guard) Check(ctx context.Context, s Submission) error {
+	if g == nil {
+		return nil
+	}
+	for _, check := range g.checks {
+		if err := check.Check(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Honeypot отклоняет отправки с заполненным скрытым полем
+type Honeypot struct{}
+
+func (Honeypot) Check(ctx context.Context, s Submission) error {
+	if s.Honeypot != "" {
+		return ErrSpam
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package antispam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CaptchaVerifier проверяет токен капчи, полученный клиентом от провайдера
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, ip string) (bool, error)
+}
+
+// Captcha отклоняет отправки без токена капчи или с токеном, который не подтвердил провайдер
+type Captcha struct {
+	verifier CaptchaVerifier
+}
+
+func NewCaptcha(verifier CaptchaVerifier) *Captcha {
+	return &Captcha{
+		verifier: verifier,
+	}
+}
+
+func (c *Captcha) Check(ctx context.Context, s Submission) error {
+	if s.CaptchaToken == "" {
+		return ErrCaptcha
+	}
+	ok, err := c.verifier.Verify(ctx, s.CaptchaToken, s.IP)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrCaptcha
+	}
+	return nil
+}
+
+// SiteVerify провайдеры с протоколом siteverify: Cloudflare Turnstile, hCaptcha, reCAPTCHA
+type SiteVerify struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewSiteVerify(verifyURL, secret string) *SiteVerify {
+	return &SiteVerify{
+		url:    verifyURL,
+		secret: secret,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (v *SiteVerify) Verify(ctx context.Context, token, ip string) (bool, error) {
+	form := url.Values{"secret": {v.secret}, "response": {token}}
+	if ip != "" {
+		form.Set("remoteip", ip)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("captcha verify: unexpected status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, err
+	}
+	return result.Success, nil
+}
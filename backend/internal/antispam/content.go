@@ -0,0 +1,43 @@
+package antispam
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// links ссылки в тексте, в том числе без схемы и в BB-коде
+var links = regexp.MustCompile(`(?i)(https?://|www\.|\[url)`)
+
+// Content эвристики по тексту формы: больше MaxLinks ссылок или слово из Blocklist - спам
+type Content struct {
+	MaxLinks  int
+	Blocklist []string
+}
+
+func NewContent(maxLinks int, blocklist []string) *Content {
+	words := make([]string, 0, len(blocklist))
+	for _, word := range blocklist {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			words = append(words, word)
+		}
+	}
+	return &Content{
+		MaxLinks:  maxLinks,
+		Blocklist: words,
+	}
+}
+
+func (c *Content) Check(ctx context.Context, s Submission) error {
+	text := strings.ToLower(strings.Join(s.Text, "\n"))
+
+	if len(links.FindAllStringIndex(text, -1)) > c.MaxLinks {
+		return ErrSpam
+	}
+	for _, word := range c.Blocklist {
+		if strings.Contains(text, word) {
+			return ErrSpam
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package antispam
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"ASMO-site-backend/internal/cache"
+)
+
+// Throttle ограничивает число отправок формы с одного IP и на один email за окно Window.
+// Счетчики в Redis с фиксированным окном; при недоступном Redis лимит не применяется.
+// Нулевой лимит отключает соответствующую проверку
+type Throttle struct {
+	cache cache.Cache
+	// Form имя формы в ключах счетчиков, у каждой формы свои лимиты
+	Form     string
+	PerIP    int
+	PerEmail int
+	Window   time.Duration
+}
+
+func NewThrottle(cache cache.Cache, form string, perIP, perEmail int, window time.Duration) *Throttle {
+	return &Throttle{
+		cache:    cache,
+		Form:     form,
+		PerIP:    perIP,
+		PerEmail: perEmail,
+		Window:   window,
+	}
+}
+
+func (t *Throttle) Check(ctx context.Context, s Submission) error {
+	// Окна считаются в миллисекундах; окно короче миллисекунды считается равным ей
+	size := max(t.Window.Milliseconds(), 1)
+	now := time.Now()
+	window := now.UnixMilli() / size
+	retryAfter := time.UnixMilli((window + 1) * size).Sub(now)
+
+	if t.PerIP > 0 && s.IP != "" && t.exceeded("ip:"+s.IP, window, t.PerIP) {
+		return &ThrottledError{RetryAfter: retryAfter}
+	}
+	email := strings.ToLower(strings.TrimSpace(s.Email))
+	if t.PerEmail > 0 && email != "" && t.exceeded("email:"+email, window, t.PerEmail) {
+		return &ThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+func (t *Throttle) exceeded(subject string, window int64, limit int) bool {
+	key := "form_rate:" + t.Form + ":" + subject + ":" + strconv.FormatInt(window, 10)
+	count, err := t.cache.Increment(key, max(t.Window, time.Millisecond))
+	return err == nil && count > int64(limit)
+}
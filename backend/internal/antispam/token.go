@@ -0,0 +1,106 @@
+package antispam
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"ASMO-site-backend/internal/cache"
+)
+
+// FormTokens подписанные метки времени открытия формы. Токен вида "<unix>.<nonce>.<hmac>" выдается при
+// открытии формы; отправка раньше MinFillTime (бот заполняет мгновенно) или позже MaxAge отклоняется.
+// Одноразовость обеспечивает отдельная проверка SingleUse: nonce принятой отправки запоминается в Redis
+type FormTokens struct {
+	secret      []byte
+	used        cache.Cache
+	MinFillTime time.Duration
+	MaxAge      time.Duration
+}
+
+func NewFormTokens(secret string, used cache.Cache, minFillTime, maxAge time.Duration) *FormTokens {
+	return &FormTokens{
+		secret:      []byte(secret),
+		used:        used,
+		MinFillTime: minFillTime,
+		MaxAge:      maxAge,
+	}
+}
+
+func (t *FormTokens) Issue() string {
+	return t.IssueAt(time.Now())
+}
+
+// IssueAt токен формы, открытой в момент issued
+func (t *FormTokens) IssueAt(issued time.Time) string {
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	payload := strconv.FormatInt(issued.Unix(), 10) + "." + hex.EncodeToString(nonce)
+	return payload + "." + t.sign(payload)
+}
+
+// parse проверяет подпись и возвращает время выдачи и nonce токена
+func (t *FormTokens) parse(token string) (int64, string, bool) {
+	separator := strings.LastIndex(token, ".")
+	if separator < 0 {
+		return 0, "", false
+	}
+	payload, signature := token[:separator], token[separator+1:]
+	if !hmac.Equal([]byte(signature), []byte(t.sign(payload))) {
+		return 0, "", false
+	}
+
+	timestamp, nonce, ok := strings.Cut(payload, ".")
+	if !ok || nonce == "" {
+		return 0, "", false
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return unix, nonce, true
+}
+
+func (t *FormTokens) Check(ctx context.Context, s Submission) error {
+	unix, _, ok := t.parse(s.FormToken)
+	if !ok {
+		return ErrFormToken
+	}
+
+	age := time.Since(time.Unix(unix, 0))
+	if age > t.MaxAge {
+		return ErrFormToken
+	}
+	if age < t.MinFillTime {
+		return ErrTooFast
+	}
+	return nil
+}
+
+// SingleUse гасит токен прошедшей отправки, повтор с тем же токеном отклоняется как ErrFormToken.
+// Ставится последней проверкой, чтобы отказ других проверок не сжигал токен и человек мог исправить форму.
+// Nonce хранится MaxAge - дольше токен все равно не примет Check; при недоступном Redis повтор не отслеживается
+func (t *FormTokens) SingleUse() Check {
+	return CheckFunc(func(ctx context.Context, s Submission) error {
+		_, nonce, ok := t.parse(s.FormToken)
+		if !ok {
+			return ErrFormToken
+		}
+		fresh, err := t.used.SetNX("form_token:"+nonce, "1", t.MaxAge)
+		if err == nil && !fresh {
+			return ErrFormToken
+		}
+		return nil
+	})
+}
+
+func (t *FormTokens) sign(payload string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte("form:" + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
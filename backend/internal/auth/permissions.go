@@ -0,0 +1,17 @@
+package auth
+
+// Права доступа; соответствуют строкам таблицы permissions
+const (
+	PermissionManageProjects = "projects.manage"
+	PermissionManageStaff    = "staff.manage"
+	PermissionManageUsers    = "users.manage"
+	PermissionManageAPIKeys  = "api_keys.manage"
+	PermissionViewAudit      = "audit.view"
+	PermissionManageMedia    = "media.manage"
+	PermissionManageLeads    = "leads.manage"
+	// PermissionPublish одобрение публикации (переход in_review -> published)
+	PermissionPublish = "content.publish"
+)
+
+// RoleSuperadmin получает все права, назначается первому администратору
+const RoleSuperadmin = "superadmin"
@@ -7,5 +7,11 @@ type Cache interface {
 	Set(key string, value interface{}, expiration time.Duration) error
 	Get(key string, dest interface{}) error
 	Delete(key string) error
+	// SetNX записывает значение, только если ключа нет; используется как распределенная блокировка
+	SetNX(key string, value interface{}, expiration time.Duration) (bool, error)
+	// Increment увеличивает счетчик; expiration выставляется при создании ключа (окно rate limit)
+	Increment(key string, expiration time.Duration) (int64, error)
+	// DeletePattern удаляет все ключи, подходящие под glob-шаблон (например "web_projects:all*")
+	DeletePattern(pattern string) error
 	Close() error
 }
\ No newline at end of file
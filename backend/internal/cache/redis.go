@@ -61,6 +61,21 @@ func (r *RedisCache) Set(key string, value interface{}, expiration time.Duration
 	return r.client.Set(r.ctx, key, jsonData, expiration).Err()
 }
 
+// SetNX без Redis считает блокировку полученной: одиночный инстанс продолжает работать
+func (r *RedisCache) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	if !r.connected {
+		fmt.Printf("⚠️  Redis not connected - skipping SETNX for key: %s\n", key)
+		return true, nil
+	}
+
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	return r.client.SetNX(r.ctx, key, jsonData, expiration).Result()
+}
+
 func (r *RedisCache) Get(key string, dest interface{}) error {
 	if !r.connected {
 		fmt.Printf("⚠️  Redis not connected - skipping GET for key: %s\n", key)
@@ -84,6 +99,38 @@ func (r *RedisCache) Delete(key string) error {
 	return r.client.Del(r.ctx, key).Err()
 }
 
+func (r *RedisCache) Increment(key string, expiration time.Duration) (int64, error) {
+	if !r.connected {
+		fmt.Printf("⚠️  Redis not connected - skipping INCR for key: %s\n", key)
+		return 0, nil
+	}
+
+	count, err := r.client.Incr(r.ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		r.client.Expire(r.ctx, key, expiration)
+	}
+	return count, nil
+}
+
+func (r *RedisCache) DeletePattern(pattern string) error {
+	if !r.connected {
+		fmt.Printf("⚠️  Redis not connected - skipping DELETE for pattern: %s\n", pattern)
+		return nil
+	}
+
+	// SCAN вместо KEYS, чтобы не блокировать Redis на больших базах
+	iter := r.client.Scan(r.ctx, 0, pattern, 100).Iterator()
+	for iter.Next(r.ctx) {
+		if err := r.client.Del(r.ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
 func (r *RedisCache) Close() error {
 	if r.client != nil {
 		return r.client.Close()
@@ -1,9 +1,15 @@
 package config
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"fmt"
+	"time"
 )
 
 type Config struct {
@@ -14,10 +20,68 @@ type Config struct {
 	Environment    string
 	AllowedOrigins string
 	PrometheusMetrics bool
+
+	// Аутентификация администраторов
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	AdminUsername   string
+	AdminPassword   string
+
+	// Корзина: сколько хранить удаленные записи и как часто чистить
+	TrashRetention     time.Duration
+	TrashPurgeInterval time.Duration
+
+	// Как часто проверять publish_at/unpublish_at
+	SchedulerInterval time.Duration
+
+	// Основной язык контента в таблицах и языки, для которых хранятся переводы
+	DefaultLocale string
+	Locales       []string
+
+	// Медиатека: local (каталог MediaDir) или s3 (S3/MinIO), лимит размера файла в байтах
+	MediaStorage string
+	MediaDir     string
+	MediaBaseURL string
+	MediaMaxSize int64
+	S3Endpoint   string
+	S3Region     string
+	S3Bucket     string
+	S3AccessKey  string
+	S3SecretKey  string
+	S3PublicURL  string
+
+	// Защита публичных форм от спама: подпись токена формы, минимальное время заполнения и срок
+	// действия токена, лимиты отправок за окно с одного IP и на один email (0 отключает лимит),
+	// эвристики текста (FormMaxLinks = 0 запрещает ссылки).
+	// Капча включается, когда задан CaptchaSecret; проверка по протоколу siteverify (Turnstile, hCaptcha, reCAPTCHA)
+	FormSecret         string
+	FormMinFillTime    time.Duration
+	FormMaxAge         time.Duration
+	FormRateWindow     time.Duration
+	FormRateLimitIP    int
+	FormRateLimitEmail int
+	FormMaxLinks       int
+	FormBlocklist      []string
+	CaptchaVerifyURL   string
+	CaptchaSecret      string
+
+	// Уведомления команды по email о новых заявках и публикации проектов; без SMTPHost или
+	// получателей отключены. Неудачная отправка повторяется NotifyRetries раз (0 - без повторов) с паузой от NotifyBackoff
+	SMTPHost         string
+	SMTPPort         string
+	SMTPUsername     string
+	SMTPPassword     string
+	SMTPFrom         string
+	NotifyRecipients []string
+	NotifyRetries    int
+	NotifyBackoff    time.Duration
 }
 
 func Load() *Config {
 	environment := getEnv("ENVIRONMENT", "development")
+	defaultLocale := strings.ToLower(getEnv("DEFAULT_LOCALE", "ru"))
+	jwtSecret := getEnv("JWT_SECRET", getDefaultJWTSecret(environment))
 
 	return &Config{
 		Port:           getEnv("PORT", "3000"),
@@ -27,6 +91,51 @@ func Load() *Config {
 		Environment:    environment,
 		AllowedOrigins: getEnv("ALLOWED_ORIGINS", getAllowedOrigins(environment)),
 		PrometheusMetrics: getEnv("PROMETHEUS_METRICS", getDefaultPrometheusMetrics(environment)) == "true",
+
+		JWTSecret:       jwtSecret,
+		AccessTokenTTL:  getDurationEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL: getDurationEnv("REFRESH_TOKEN_TTL", 7*24*time.Hour),
+		AdminUsername:   getEnv("ADMIN_USERNAME", ""),
+		AdminPassword:   getEnv("ADMIN_PASSWORD", ""),
+
+		TrashRetention:     getDurationEnv("TRASH_RETENTION", 30*24*time.Hour),
+		TrashPurgeInterval: getDurationEnv("TRASH_PURGE_INTERVAL", time.Hour),
+
+		SchedulerInterval: getDurationEnv("SCHEDULER_INTERVAL", time.Minute),
+
+		DefaultLocale: defaultLocale,
+		Locales:       getLocales(defaultLocale),
+
+		MediaStorage: getEnv("MEDIA_STORAGE", "local"),
+		MediaDir:     getEnv("MEDIA_DIR", "./uploads"),
+		MediaBaseURL: getEnv("MEDIA_BASE_URL", "http://localhost:3000/media"),
+		MediaMaxSize: getInt64Env("MEDIA_MAX_SIZE", 10<<20),
+		S3Endpoint:   getEnv("S3_ENDPOINT", "http://minio:9000"),
+		S3Region:     getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:     getEnv("S3_BUCKET", "media"),
+		S3AccessKey:  getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:  getEnv("S3_SECRET_KEY", ""),
+		S3PublicURL:  getEnv("S3_PUBLIC_URL", ""),
+
+		FormSecret:         getEnv("FORM_SECRET", getDefaultFormSecret(environment, jwtSecret)),
+		FormMinFillTime:    getDurationEnv("FORM_MIN_FILL_TIME", 3*time.Second),
+		FormMaxAge:         getDurationEnv("FORM_MAX_AGE", 2*time.Hour),
+		FormRateWindow:     getDurationEnv("FORM_RATE_WINDOW", time.Hour),
+		FormRateLimitIP:    getCountEnv("FORM_RATE_LIMIT_IP", 5),
+		FormRateLimitEmail: getCountEnv("FORM_RATE_LIMIT_EMAIL", 3),
+		FormMaxLinks:       getCountEnv("FORM_MAX_LINKS", 2),
+		FormBlocklist:      getListEnv("FORM_BLOCKLIST", "casino,viagra,crypto signals,seo promotion"),
+		CaptchaVerifyURL:   getEnv("CAPTCHA_VERIFY_URL", "https://challenges.cloudflare.com/turnstile/v0/siteverify"),
+		CaptchaSecret:      getEnv("CAPTCHA_SECRET", ""),
+
+		SMTPHost:         getEnv("SMTP_HOST", ""),
+		SMTPPort:         getEnv("SMTP_PORT", "587"),
+		SMTPUsername:     getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:     getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:         getEnv("SMTP_FROM", "ASMO <noreply@localhost>"),
+		NotifyRecipients: getListEnv("NOTIFY_RECIPIENTS", ""),
+		NotifyRetries:    getCountEnv("NOTIFY_RETRIES", 3),
+		NotifyBackoff:    getDurationEnv("NOTIFY_BACKOFF", 5*time.Second),
 	}
 }
 
@@ -86,4 +195,70 @@ func getDefaultPrometheusMetrics(environment string) string {
 		return "false"
 	}
 	return "true"
+}
+
+// getDefaultJWTSecret в production секрет обязан прийти из JWT_SECRET
+func getDefaultJWTSecret(environment string) string {
+	if environment == "production" {
+		return ""
+	}
+	return "dev-jwt-secret-change-me"
+}
+
+// getDefaultFormSecret в production секрет обязан прийти из FORM_SECRET; в разработке он выводится из JWT-секрета
+// через HMAC с отдельной меткой, чтобы публичные токены форм не подписывались ключом авторизации
+func getDefaultFormSecret(environment, jwtSecret string) string {
+	if environment == "production" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(jwtSecret))
+	mac.Write([]byte("asmo:form-tokens"))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// getDurationEnv читает длительность в формате time.ParseDuration ("15m", "168h")
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(getEnv(key, "")); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// getInt64Env читает положительное целое, например размер в байтах
+func getInt64Env(key string, defaultValue int64) int64 {
+	if value, err := strconv.ParseInt(getEnv(key, ""), 10, 64); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// getCountEnv читает неотрицательное целое: лимиты и счетчики, для которых 0 - осмысленное значение
+func getCountEnv(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(getEnv(key, "")); err == nil && value >= 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// getListEnv читает список через запятую, пустые элементы пропускаются
+func getListEnv(key, defaultValue string) []string {
+	var items []string
+	for _, item := range strings.Split(getEnv(key, defaultValue), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getLocales читает LOCALES ("ru,en"); основная локаль всегда в списке
+func getLocales(defaultLocale string) []string {
+	locales := []string{defaultLocale}
+	for _, locale := range strings.Split(getEnv("LOCALES", "ru,en"), ",") {
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		if locale != "" && !slices.Contains(locales, locale) {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
 }
\ No newline at end of file
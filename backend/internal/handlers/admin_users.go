@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"ASMO-site-backend/internal/auth"
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/middleware"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminUsersHandler управление администраторами и их ролями (право users.manage)
+type AdminUsersHandler struct {
+	users *repository.AdminUsersRepository
+	roles *repository.RolesRepository
+}
+
+func NewAdminUsersHandler(db *sql.DB, cache cache.Cache) *AdminUsersHandler {
+	return &AdminUsersHandler{
+		users: repository.NewAdminUsersRepository(db, cache),
+		roles: repository.NewRolesRepository(db, cache),
+	}
+}
+
+func (h *AdminUsersHandler) ListUsers(c *gin.Context) {
+	users, err := h.users.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch users",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users": users,
+		"count": len(users),
+	})
+}
+
+func (h *AdminUsersHandler) ListRoles(c *gin.Context) {
+	roles, err := h.roles.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch roles",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"roles": roles,
+		"count": len(roles),
+	})
+}
+
+func (h *AdminUsersHandler) CreateUser(c *gin.Context) {
+	var req models.CreateAdminUserRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create user",
+		})
+		return
+	}
+
+	user, err := h.users.Create(c.Request.Context(), req.Username, hash, req.Roles)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Username already taken",
+		})
+		return
+	} else if errors.Is(err, repository.ErrUnknownRole) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Unknown role",
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create user",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "User created successfully",
+		"id":      user.ID,
+	})
+}
+
+func (h *AdminUsersHandler) SetUserRoles(c *gin.Context) {
+	id, ok := bindID(c, "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	var req models.SetRolesRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if _, err := h.users.FindByID(c.Request.Context(), id); err != nil {
+		respondError(c, err, "User not found", "Failed to update user roles")
+		return
+	}
+
+	if !h.setRoles(c, id, req.Roles) {
+		return
+	}
+
+	user, err := h.users.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "User not found", "Failed to fetch user")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *AdminUsersHandler) DeleteUser(c *gin.Context) {
+	id, ok := bindID(c, "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	// Нельзя удалить самого себя, иначе легко остаться без суперадмина
+	if id == c.GetInt(middleware.ContextUserID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Cannot delete your own account",
+		})
+		return
+	}
+
+	if err := h.users.Delete(c.Request.Context(), id); err != nil {
+		respondError(c, err, "User not found", "Failed to delete user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User deleted successfully",
+		"id":      id,
+	})
+}
+
+// setRoles назначает роли; при ошибке ответ уже отправлен
+func (h *AdminUsersHandler) setRoles(c *gin.Context, userID int, roles []string) bool {
+	err := h.roles.SetUserRoles(c.Request.Context(), userID, roles)
+	if errors.Is(err, repository.ErrUnknownRole) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Unknown role",
+		})
+		return false
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update user roles",
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"ASMO-site-backend/internal/auth"
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/middleware"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultAPIKeyRateLimit запросов в минуту, если лимит не указан при выпуске
+const defaultAPIKeyRateLimit = 60
+
+// APIKeysHandler выпуск и отзыв ключей для машинных клиентов (право api_keys.manage)
+type APIKeysHandler struct {
+	keys *repository.APIKeysRepository
+}
+
+func NewAPIKeysHandler(db *sql.DB, cache cache.Cache) *APIKeysHandler {
+	return &APIKeysHandler{
+		keys: repository.NewAPIKeysRepository(db, cache),
+	}
+}
+
+func (h *APIKeysHandler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.keys.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch API keys",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"api_keys": keys,
+		"count":    len(keys),
+	})
+}
+
+// CreateAPIKey возвращает ключ в открытом виде только один раз
+func (h *APIKeysHandler) CreateAPIKey(c *gin.Context) {
+	var req models.CreateAPIKeyRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	rawKey, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create API key",
+		})
+		return
+	}
+
+	key := models.APIKey{
+		Name:      req.Name,
+		Prefix:    prefix,
+		Scopes:    req.Scopes,
+		RateLimit: req.RateLimit,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if key.RateLimit == 0 {
+		key.RateLimit = defaultAPIKeyRateLimit
+	}
+	if userID := c.GetInt(middleware.ContextUserID); userID != 0 {
+		key.CreatedBy = &userID
+	}
+
+	created, err := h.keys.Create(c.Request.Context(), key, hash)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create API key",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "API key created successfully",
+		"id":      created.ID,
+		"key":     rawKey,
+		"api_key": created,
+	})
+}
+
+func (h *APIKeysHandler) RevokeAPIKey(c *gin.Context) {
+	id, ok := bindID(c, "Invalid API key ID")
+	if !ok {
+		return
+	}
+
+	if err := h.keys.Revoke(c.Request.Context(), id); err != nil {
+		respondError(c, err, "API key not found", "Failed to revoke API key")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API key revoked successfully",
+		"id":      id,
+	})
+}
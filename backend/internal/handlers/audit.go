@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+var auditListParams = []string{"page", "per_page", "order", "actor_user_id", "action", "resource_type", "resource_id", "from", "to"}
+
+// AuditHandler просмотр журнала изменений (право audit.view)
+type AuditHandler struct {
+	repo *repository.AuditRepository
+}
+
+func NewAuditHandler(db *sql.DB) *AuditHandler {
+	return &AuditHandler{
+		repo: repository.NewAuditRepository(db),
+	}
+}
+
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	var query models.ListAuditQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	page, err := h.repo.List(c.Request.Context(), auditListQuery(query))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch audit log",
+		})
+		return
+	}
+
+	response := listResponse(c, "entries", auditListParams, page, false)
+	delete(response, "cached")
+	c.JSON(http.StatusOK, response)
+}
+
+func auditListQuery(q models.ListAuditQuery) repository.ListQuery {
+	list := repository.ListQuery{
+		Page:    q.Page,
+		PerPage: q.PerPage,
+		Order:   q.Order,
+	}
+
+	if q.ActorUserID != nil {
+		list.Filters = append(list.Filters, repository.Filter{Name: "actor_user_id", Condition: "actor_user_id = $?", Value: *q.ActorUserID})
+	}
+	if q.Action != "" {
+		list.Filters = append(list.Filters, repository.Filter{Name: "action", Condition: "action = $?", Value: q.Action})
+	}
+	if q.ResourceType != "" {
+		list.Filters = append(list.Filters, repository.Filter{Name: "resource_type", Condition: "resource_type = $?", Value: q.ResourceType})
+	}
+	if q.ResourceID != nil {
+		list.Filters = append(list.Filters, repository.Filter{Name: "resource_id", Condition: "resource_id = $?", Value: *q.ResourceID})
+	}
+	if q.From != nil {
+		list.Filters = append(list.Filters, repository.Filter{Name: "from", Condition: "created_at >= $?", Value: *q.From})
+	}
+	if q.To != nil {
+		list.Filters = append(list.Filters, repository.Filter{Name: "to", Condition: "created_at < $?", Value: *q.To})
+	}
+
+	return list
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"ASMO-site-backend/internal/auth"
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dummyPasswordHash сравнивается при неизвестном логине, чтобы время ответа не выдавало существующих пользователей
+const dummyPasswordHash = "$2a$10$dgPFuty7cS/sNnKJEa6Yl.kfq0yRBbfX7QFHnCPNPxvAB12JMGBTy"
+
+type AuthHandler struct {
+	users      *repository.AdminUsersRepository
+	tokens     *auth.TokenManager
+	refreshTTL time.Duration
+}
+
+func NewAuthHandler(db *sql.DB, cache cache.Cache, tokens *auth.TokenManager, refreshTTL time.Duration) *AuthHandler {
+	return &AuthHandler{
+		users:      repository.NewAdminUsersRepository(db, cache),
+		tokens:     tokens,
+		refreshTTL: refreshTTL,
+	}
+}
+
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req models.LoginRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.users.FindByUsername(c.Request.Context(), req.Username)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to authenticate",
+		})
+		return
+	}
+
+	passwordHash := user.PasswordHash
+	if err != nil {
+		passwordHash = dummyPasswordHash
+	}
+
+	if !auth.CheckPassword(passwordHash, req.Password) || err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid username or password",
+		})
+		return
+	}
+
+	h.issueTokens(c, user)
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	// Старый refresh-токен отзывается, взамен выдается новая пара
+	userID, err := h.users.ConsumeRefreshToken(c.Request.Context(), auth.HashToken(req.RefreshToken))
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid or expired refresh token",
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to refresh token",
+		})
+		return
+	}
+
+	user, err := h.users.FindByID(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid or expired refresh token",
+		})
+		return
+	}
+
+	h.issueTokens(c, user)
+}
+
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	// Неизвестный или уже отозванный токен не считаем ошибкой
+	if _, err := h.users.ConsumeRefreshToken(c.Request.Context(), auth.HashToken(req.RefreshToken)); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to logout",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out successfully",
+	})
+}
+
+func (h *AuthHandler) issueTokens(c *gin.Context, user models.AdminUser) {
+	accessToken, expiresAt, err := h.tokens.IssueAccessToken(user.ID, user.Username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to issue token",
+		})
+		return
+	}
+
+	refreshToken, refreshHash, err := auth.NewRefreshToken()
+	if err == nil {
+		err = h.users.SaveRefreshToken(c.Request.Context(), user.ID, refreshHash, time.Now().Add(h.refreshTTL))
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to issue token",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresAt:    expiresAt,
+	})
+}
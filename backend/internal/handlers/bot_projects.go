@@ -3,187 +3,320 @@ package handlers
 import (
 	"database/sql"
 	"net/http"
-	"strconv"
-	"time"
+	"strings"
 
+	"ASMO-site-backend/internal/auth"
 	"ASMO-site-backend/internal/cache"
-	"ASMO-site-backend/internal/metrics"
 	"ASMO-site-backend/internal/models"
-	"ASMO-site-backend/internal/validation"
+	"ASMO-site-backend/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
 type BotProjectsHandler struct {
-	db    *sql.DB
-	cache cache.Cache
+	repo repository.Projects[models.BotsProjects]
 }
 
 func NewBotProjectsHandler(db *sql.DB, cache cache.Cache) *BotProjectsHandler {
+	return NewBotProjectsHandlerWithRepository(repository.NewBotProjectsRepository(db, cache))
+}
+
+func NewBotProjectsHandlerWithRepository(repo repository.Projects[models.BotsProjects]) *BotProjectsHandler {
 	return &BotProjectsHandler{
-		db:    db,
-		cache: cache,
+		repo: repo,
 	}
 }
 
 func (h *BotProjectsHandler) GetBotProjects(c *gin.Context) {
-	start := time.Now()
-	cacheKey := "bot_projects:all"
-
-	// Пробуем получить из кэша
-	var projects []models.BotsProjects
-	if err := h.cache.Get(cacheKey, &projects); err == nil {
-		metrics.RecordDatabaseQuery("cache_hit", "bots_projects", time.Since(start))
-		c.JSON(http.StatusOK, gin.H{
-			"projects": projects,
-			"count":    len(projects),
-			"cached":   true,
-		})
+	var query models.ListProjectsQuery
+	if !bindQuery(c, &query) {
 		return
 	}
 
-	// Если нет в кэше, получаем из БД
-	rows, err := h.db.Query(`
-		SELECT id, name, description, img, price, time_develop, created_at, update_at
-		FROM bots_projects
-		ORDER BY created_at DESC
-	`)
-
-	metrics.RecordDatabaseQuery("select", "bots_projects", time.Since(start))
-
+	page, cached, err := h.repo.List(localeContext(c), visibleOnly(c, auth.PermissionManageProjects, projectListQuery(query), query.Status))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch bot projects",
 		})
 		return
 	}
-	defer rows.Close()
 
-	projects = []models.BotsProjects{}
-	for rows.Next() {
-		var project models.BotsProjects
-		err := rows.Scan(
-			&project.ID, &project.Name, &project.Description, &project.Img,
-			&project.Price, &project.TimeDevelop, &project.CreatedAt, &project.UpdateAt,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to process bot projects",
-			})
-			return
-		}
-		projects = append(projects, project)
+	c.JSON(http.StatusOK, listResponse(c, "projects", projectListParams, page, cached))
+}
+
+func (h *BotProjectsHandler) GetBotProject(c *gin.Context) {
+	id, ok := bindKey(c, h.repo, "Invalid project ID", "Bot project not found", "Failed to fetch bot project")
+	if !ok {
+		return
 	}
 
-	if len(projects) == 0 {
-		projects = []models.BotsProjects{}
+	project, _, err := h.repo.Get(localeContext(c), id)
+	if err != nil {
+		respondError(c, err, "Bot project not found", "Failed to fetch bot project")
+		return
 	}
 
-	// Сохраняем в кэш на 5 минут
-	h.cache.Set(cacheKey, projects, 5*time.Minute)
+	// Неопубликованные записи для публичных клиентов не существуют
+	if !isVisible(c, auth.PermissionManageProjects, project.Status) {
+		respondError(c, repository.ErrNotFound, "Bot project not found", "Failed to fetch bot project")
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"projects": projects,
-		"count":    len(projects),
-		"cached":   false,
-	})
+	project.Gallery, err = h.repo.Gallery(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Bot project not found", "Failed to fetch bot project")
+		return
+	}
+	project.Tags, err = h.repo.Tags(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Bot project not found", "Failed to fetch bot project")
+		return
+	}
+	team, err := h.repo.Team(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Bot project not found", "Failed to fetch bot project")
+		return
+	}
+	project.Team = visibleTeam(c, team)
+
+	c.JSON(http.StatusOK, project)
 }
 
-func (h *BotProjectsHandler) GetBotProject(c *gin.Context) {
-	start := time.Now()
-	var req models.GetProjectRequest
-	if err := c.ShouldBindUri(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid project ID",
-		})
+func (h *BotProjectsHandler) CreateBotProject(c *gin.Context) {
+	var req models.CreateBotsProjectRequest
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	if errs := validation.ValidateStruct(req); errs != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Validation failed",
-			"details": errs,
-		})
+	project, err := h.repo.Create(auditContext(c), botProjectFields(req))
+	if err != nil {
+		respondError(c, err, "Bot project not found", "Failed to create bot project")
 		return
 	}
 
-	cacheKey := "bot_project:" + strconv.Itoa(req.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Bot project created successfully",
+		"id":      project.ID,
+	})
+}
 
-	// Пробуем получить из кэша
-	var project models.BotsProjects
-	if err := h.cache.Get(cacheKey, &project); err == nil {
-		metrics.RecordDatabaseQuery("cache_hit", "bots_projects", time.Since(start))
-		c.JSON(http.StatusOK, project)
+func (h *BotProjectsHandler) UpdateBotProject(c *gin.Context) {
+	id, ok := bindID(c, "Invalid project ID")
+	if !ok {
 		return
 	}
 
-	err := h.db.QueryRow(`
-		SELECT id, name, description, img, price, time_develop, created_at, update_at
-		FROM bots_projects WHERE id = $1
-	`, req.ID).Scan(
-		&project.ID, &project.Name, &project.Description, &project.Img,
-		&project.Price, &project.TimeDevelop, &project.CreatedAt, &project.UpdateAt,
-	)
+	var req models.CreateBotsProjectRequest
+	if !bindJSON(c, &req) {
+		return
+	}
 
-	metrics.RecordDatabaseQuery("select", "bots_projects", time.Since(start))
+	project, err := h.repo.Update(auditContext(c), id, botProjectFields(req))
+	if err != nil {
+		respondError(c, err, "Bot project not found", "Failed to update bot project")
+		return
+	}
 
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Bot project not found",
-		})
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *BotProjectsHandler) PatchBotProject(c *gin.Context) {
+	id, ok := bindID(c, "Invalid project ID")
+	if !ok {
 		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch bot project",
+	}
+
+	var req models.PatchBotsProjectRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	// Обновляем только переданные поля
+	fields := repository.Fields{}
+	repository.Optional(fields, "name", req.Name)
+	repository.Optional(fields, "description", req.Description)
+	repository.Optional(fields, "img", req.Img)
+	repository.Optional(fields, "media_id", req.MediaID)
+	repository.Optional(fields, "price", req.Price)
+	repository.Optional(fields, "time_develop", req.TimeDevelop)
+	repository.Optional(fields, "platform", req.Platform)
+	if req.Handle != nil {
+		fields["handle"] = strings.TrimPrefix(*req.Handle, "@")
+	}
+
+	if len(fields) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "No fields to update",
 		})
 		return
 	}
 
-	// Сохраняем в кэш на 10 минут
-	h.cache.Set(cacheKey, project, 10*time.Minute)
+	project, err := h.repo.Update(auditContext(c), id, fields)
+	if err != nil {
+		respondError(c, err, "Bot project not found", "Failed to update bot project")
+		return
+	}
 
 	c.JSON(http.StatusOK, project)
 }
 
-func (h *BotProjectsHandler) CreateBotProject(c *gin.Context) {
-	start := time.Now()
-	var req models.CreateBotsProjectRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-		})
+func (h *BotProjectsHandler) DeleteBotProject(c *gin.Context) {
+	id, ok := bindID(c, "Invalid project ID")
+	if !ok {
 		return
 	}
 
-	if errs := validation.ValidateStruct(req); errs != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Validation failed",
-			"details": errs,
-		})
+	if err := h.repo.Delete(auditContext(c), id); err != nil {
+		respondError(c, err, "Bot project not found", "Failed to delete bot project")
 		return
 	}
 
-	var id int
-	err := h.db.QueryRow(`
-		INSERT INTO bots_projects (name, description, img, price, time_develop, created_at, update_at)
-		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-		RETURNING id
-	`, req.Name, req.Description, req.Img, req.Price, req.TimeDevelop).Scan(&id)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Bot project deleted successfully",
+		"id":      id,
+	})
+}
 
-	metrics.RecordDatabaseQuery("insert", "bots_projects", time.Since(start))
+// RestoreBotProject возвращает запись из корзины
+func (h *BotProjectsHandler) RestoreBotProject(c *gin.Context) {
+	id, ok := bindID(c, "Invalid project ID")
+	if !ok {
+		return
+	}
 
+	project, err := h.repo.Restore(auditContext(c), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to create bot project",
-		})
+		respondError(c, err, "Bot project not found in trash", "Failed to restore bot project")
 		return
 	}
 
-	// Инвалидируем кэш при создании нового проекта
-	h.cache.Delete("bot_projects:all")
+	c.JSON(http.StatusOK, project)
+}
+
+// TransitionBotProject обработчик перехода статуса (submit, publish, reject, archive, reopen)
+func (h *BotProjectsHandler) TransitionBotProject(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		transition(c, h.repo, action, "Invalid project ID", "Bot project not found", "Failed to change bot project status")
+	}
+}
+
+// ScheduleBotProject назначает время автоматической публикации и снятия с публикации
+func (h *BotProjectsHandler) ScheduleBotProject(c *gin.Context) {
+	schedule(c, h.repo, "Invalid project ID", "Bot project not found", "Failed to schedule bot project")
+}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "Bot project created successfully",
-		"id":      id,
+var botProjectRevisionMessages = entityMessages{
+	invalid:  "Invalid revision",
+	notFound: "Bot project revision not found",
+	failed:   "Failed to fetch bot project revisions",
+}
+
+// GetBotProjectRevisions история изменений записи
+func (h *BotProjectsHandler) GetBotProjectRevisions(c *gin.Context) {
+	listRevisions(c, h.repo, botProjectRevisionMessages)
+}
+
+// GetBotProjectRevision снимок версии и отличия от текущей записи
+func (h *BotProjectsHandler) GetBotProjectRevision(c *gin.Context) {
+	getRevision(c, h.repo, botProjectRevisionMessages)
+}
+
+// RestoreBotProjectRevision откатывает запись к выбранной версии
+func (h *BotProjectsHandler) RestoreBotProjectRevision(c *gin.Context) {
+	restoreRevision(c, h.repo, entityMessages{
+		invalid:  "Invalid revision",
+		notFound: "Bot project revision not found",
+		failed:   "Failed to restore bot project revision",
+	})
+}
+
+// GetBotProjectTranslations все переводы записи
+func (h *BotProjectsHandler) GetBotProjectTranslations(c *gin.Context) {
+	listTranslations(c, h.repo, entityMessages{
+		invalid:  "Invalid translation request",
+		notFound: "Bot project not found",
+		failed:   "Failed to fetch bot project translations",
+	})
+}
+
+// SetBotProjectTranslation создает или заменяет перевод на :locale
+func (h *BotProjectsHandler) SetBotProjectTranslation(c *gin.Context) {
+	setTranslation(c, h.repo, entityMessages{
+		invalid:  "Invalid translation request",
+		notFound: "Bot project not found",
+		failed:   "Failed to update bot project translation",
+	})
+}
+
+func (h *BotProjectsHandler) DeleteBotProjectTranslation(c *gin.Context) {
+	deleteTranslation(c, h.repo, entityMessages{
+		invalid:  "Invalid translation request",
+		notFound: "Bot project translation not found",
+		failed:   "Failed to delete bot project translation",
+	})
+}
+
+// AddBotProjectMedia добавляет картинку из медиатеки или видео в конец галереи
+func (h *BotProjectsHandler) AddBotProjectMedia(c *gin.Context) {
+	addGalleryItem(c, h.repo, entityMessages{
+		invalid:  "Invalid project ID",
+		notFound: "Bot project not found",
+		failed:   "Failed to add bot project media",
+	})
+}
+
+func (h *BotProjectsHandler) RemoveBotProjectMedia(c *gin.Context) {
+	removeGalleryItem(c, h.repo, entityMessages{
+		invalid:  "Invalid gallery item request",
+		notFound: "Gallery item not found",
+		failed:   "Failed to delete bot project media",
+	})
+}
+
+// ReorderBotProjectMedia принимает id всех элементов галереи в новом порядке
+func (h *BotProjectsHandler) ReorderBotProjectMedia(c *gin.Context) {
+	reorderGallery(c, h.repo, entityMessages{
+		invalid:  "Invalid project ID",
+		notFound: "Bot project not found",
+		failed:   "Failed to reorder bot project media",
+	})
+}
+
+// SetBotProjectTags заменяет теги проекта: {"tags": ["go", "react"]}
+func (h *BotProjectsHandler) SetBotProjectTags(c *gin.Context) {
+	setTags(c, h.repo, entityMessages{
+		invalid:  "Invalid project ID",
+		notFound: "Bot project not found",
+		failed:   "Failed to update bot project tags",
+	})
+}
+
+// AssignBotProjectMember добавляет сотрудника в команду или меняет его роль: {"role": "backend"}
+func (h *BotProjectsHandler) AssignBotProjectMember(c *gin.Context) {
+	assignTeamMember(c, h.repo, entityMessages{
+		invalid:  "Invalid team member request",
+		notFound: "Bot project not found",
+		failed:   "Failed to assign bot project team member",
 	})
-}
\ No newline at end of file
+}
+
+func (h *BotProjectsHandler) UnassignBotProjectMember(c *gin.Context) {
+	unassignTeamMember(c, h.repo, entityMessages{
+		invalid:  "Invalid team member request",
+		notFound: "Team member not found",
+		failed:   "Failed to remove bot project team member",
+	})
+}
+
+func botProjectFields(req models.CreateBotsProjectRequest) repository.Fields {
+	return repository.Fields{
+		"name":         req.Name,
+		"description":  req.Description,
+		"img":          req.Img,
+		"media_id":     req.MediaID,
+		"price":        req.Price,
+		"time_develop": req.TimeDevelop,
+		"platform":     req.Platform,
+		"handle":       strings.TrimPrefix(req.Handle, "@"),
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"ASMO-site-backend/internal/antispam"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FormsHandler выдает подписанные токены для публичных форм
+type FormsHandler struct {
+	tokens *antispam.FormTokens
+}
+
+func NewFormsHandler(tokens *antispam.FormTokens) *FormsHandler {
+	return &FormsHandler{
+		tokens: tokens,
+	}
+}
+
+// GetFormToken запрашивается при открытии формы; токен отправляется вместе с формой в form_token
+func (h *FormsHandler) GetFormToken(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"token":            h.tokens.Issue(),
+		"min_fill_seconds": int(h.tokens.MinFillTime.Seconds()),
+	})
+}
+
+// guardSubmission прогоняет отправку публичной формы через антиспам; при отказе ответ уже отправлен
+func guardSubmission(c *gin.Context, guard *antispam.Guard, submission antispam.Submission) bool {
+	err := guard.Check(c.Request.Context(), submission)
+	if err == nil {
+		return true
+	}
+
+	var throttled *antispam.ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":   "Too many requests",
+			"message": "Submission limit exceeded, try again later",
+		})
+	case errors.Is(err, antispam.ErrFormToken):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Form expired, reload the page and try again",
+		})
+	case errors.Is(err, antispam.ErrTooFast):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Form submitted too fast",
+		})
+	case errors.Is(err, antispam.ErrCaptcha):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Captcha verification failed",
+		})
+	case errors.Is(err, antispam.ErrSpam):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Submission rejected",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to verify submission",
+		})
+	}
+	return false
+}
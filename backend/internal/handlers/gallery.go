@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+	"ASMO-site-backend/internal/video"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindGalleryItem читает :id и :item из URI; при ошибке ответ уже отправлен
+func bindGalleryItem(c *gin.Context, invalidMessage string) (models.GalleryItemRequest, bool) {
+	var req models.GalleryItemRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": invalidMessage,
+		})
+		return req, false
+	}
+
+	return req, validateRequest(c, req)
+}
+
+func addGalleryItem(c *gin.Context, repo repository.Gallery, messages entityMessages) {
+	id, ok := bindID(c, messages.invalid)
+	if !ok {
+		return
+	}
+
+	var req models.AddProjectMediaRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	item := models.ProjectMedia{Kind: req.Kind, Caption: req.Caption}
+	if req.Kind == models.GalleryImage {
+		item.MediaID = req.MediaID
+	} else {
+		embed, err := video.Parse(req.URL)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Unsupported video URL",
+			})
+			return
+		}
+		item.URL = req.URL
+		item.Provider = embed.Provider
+		item.EmbedURL = embed.EmbedURL
+	}
+
+	created, err := repo.AddGalleryItem(auditContext(c), id, item)
+	if err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func removeGalleryItem(c *gin.Context, repo repository.Gallery, messages entityMessages) {
+	req, ok := bindGalleryItem(c, messages.invalid)
+	if !ok {
+		return
+	}
+
+	if err := repo.RemoveGalleryItem(auditContext(c), req.ID, req.Item); err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Gallery item deleted successfully",
+	})
+}
+
+func reorderGallery(c *gin.Context, repo repository.Gallery, messages entityMessages) {
+	id, ok := bindID(c, messages.invalid)
+	if !ok {
+		return
+	}
+
+	var req models.ReorderProjectMediaRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	gallery, err := repo.ReorderGallery(auditContext(c), id, req.IDs)
+	if err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"gallery": gallery,
+		"count":   len(gallery),
+	})
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"ASMO-site-backend/internal/antispam"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/notify"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+var leadListParams = []string{"page", "per_page", "order", "status", "assigned_to", "unassigned", "project_type", "from", "to"}
+
+// LeadsHandler заявки с формы "заказать проект": отправка публичная, обработка требует leads.manage
+type LeadsHandler struct {
+	repo     *repository.LeadRepository
+	guard    *antispam.Guard
+	notifier *notify.Notifier
+}
+
+// NewLeadsHandler guard проверяет отправки формы на спам, notifier сообщает команде о новых заявках;
+// nil отключает соответствующее
+func NewLeadsHandler(db *sql.DB, guard *antispam.Guard, notifier *notify.Notifier) *LeadsHandler {
+	return &LeadsHandler{
+		repo:     repository.NewLeadRepository(db),
+		guard:    guard,
+		notifier: notifier,
+	}
+}
+
+// CreateLead принимает заявку с сайта; в ответе только id, содержимое видят администраторы
+func (h *LeadsHandler) CreateLead(c *gin.Context) {
+	var req models.CreateLeadRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if !guardSubmission(c, h.guard, antispam.Submission{
+		IP:           c.ClientIP(),
+		Email:        strings.ToLower(strings.TrimSpace(req.Contact)),
+		Honeypot:     req.Website,
+		FormToken:    req.FormToken,
+		CaptchaToken: req.CaptchaToken,
+		Text:         []string{req.Name, req.Message},
+	}) {
+		return
+	}
+
+	lead, err := h.repo.Create(c.Request.Context(), models.Lead{
+		Name:        strings.TrimSpace(req.Name),
+		Contact:     strings.TrimSpace(req.Contact),
+		Message:     strings.TrimSpace(req.Message),
+		Budget:      req.Budget,
+		ProjectType: req.ProjectType,
+		ProjectID:   req.ProjectID,
+	})
+	if err != nil {
+		respondError(c, err, "Lead not found", "Failed to submit lead")
+		return
+	}
+	h.notifier.LeadCreated(lead)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Lead submitted successfully",
+		"id":      lead.ID,
+	})
+}
+
+func (h *LeadsHandler) GetLeads(c *gin.Context) {
+	var query models.ListLeadsQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	page, err := h.repo.List(c.Request.Context(), leadListQuery(query))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch leads",
+		})
+		return
+	}
+
+	response := listResponse(c, "leads", leadListParams, page, false)
+	delete(response, "cached")
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *LeadsHandler) GetLead(c *gin.Context) {
+	id, ok := bindID(c, "Invalid lead ID")
+	if !ok {
+		return
+	}
+
+	lead, err := h.repo.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Lead not found", "Failed to fetch lead")
+		return
+	}
+
+	c.JSON(http.StatusOK, lead)
+}
+
+// AssignLead назначает ответственного: {"user_id": 3}; {"user_id": null} снимает назначение
+func (h *LeadsHandler) AssignLead(c *gin.Context) {
+	id, ok := bindID(c, "Invalid lead ID")
+	if !ok {
+		return
+	}
+
+	var req models.AssignLeadRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	lead, err := h.repo.Assign(auditContext(c), id, req.UserID)
+	if err != nil {
+		respondError(c, err, "Lead not found", "Failed to assign lead")
+		return
+	}
+
+	c.JSON(http.StatusOK, lead)
+}
+
+// SetLeadStatus меняет статус заявки: new, contacted, won или lost; переходы не ограничены
+func (h *LeadsHandler) SetLeadStatus(c *gin.Context) {
+	id, ok := bindID(c, "Invalid lead ID")
+	if !ok {
+		return
+	}
+
+	var req models.LeadStatusRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	lead, err := h.repo.SetStatus(auditContext(c), id, req.Status)
+	if err != nil {
+		respondError(c, err, "Lead not found", "Failed to update lead status")
+		return
+	}
+
+	c.JSON(http.StatusOK, lead)
+}
+
+func leadListQuery(q models.ListLeadsQuery) repository.ListQuery {
+	list := repository.ListQuery{
+		Page:    q.Page,
+		PerPage: q.PerPage,
+		Order:   q.Order,
+	}
+
+	if q.Status != "" {
+		list.Filters = append(list.Filters, repository.Filter{Name: "status", Condition: "l.status = $?", Value: q.Status})
+	}
+	if q.AssignedTo != nil {
+		list.Filters = append(list.Filters, repository.Filter{Name: "assigned_to", Condition: "l.assigned_to = $?", Value: *q.AssignedTo})
+	} else if q.Unassigned {
+		list.Filters = append(list.Filters, repository.Filter{Name: "unassigned", Condition: "COALESCE(l.assigned_to, 0) = $?", Value: 0})
+	}
+	if q.ProjectType != "" {
+		list.Filters = append(list.Filters, repository.Filter{Name: "project_type", Condition: "l.project_type = $?", Value: q.ProjectType})
+	}
+	if q.From != nil {
+		list.Filters = append(list.Filters, repository.Filter{Name: "from", Condition: "l.created_at >= $?", Value: *q.From})
+	}
+	if q.To != nil {
+		list.Filters = append(list.Filters, repository.Filter{Name: "to", Condition: "l.created_at < $?", Value: *q.To})
+	}
+
+	return list
+}
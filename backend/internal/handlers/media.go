@@ -0,0 +1,486 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/imaging"
+	"ASMO-site-backend/internal/middleware"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+	"ASMO-site-backend/internal/storage"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	mediaListParams = []string{"page", "per_page", "mime_type"}
+	// mediaTypes допустимые типы; определяются по содержимому, а не по расширению или заголовку
+	mediaTypes = []string{"image/jpeg", "image/png", "image/webp", "image/gif"}
+)
+
+// multipartOverhead запас на заголовки multipart сверх размера самого файла
+const multipartOverhead = 64 << 10
+
+// MediaHandler медиатека: загрузка картинок для img проектов и сотрудников (право media.manage)
+type MediaHandler struct {
+	repo    *repository.MediaRepository
+	store   storage.Storage
+	maxSize int64
+	client  *http.Client
+}
+
+func NewMediaHandler(db *sql.DB, cache cache.Cache, store storage.Storage, maxSize int64) *MediaHandler {
+	return &MediaHandler{
+		repo:    repository.NewMediaRepository(db, cache),
+		store:   store,
+		maxSize: maxSize,
+		client:  publicHTTPClient(),
+	}
+}
+
+// errPrivateAddress импорт по URL не должен открывать доступ к внутренней сети (SSRF)
+var errPrivateAddress = errors.New("private network address")
+
+// nonPublicNetworks диапазоны, которые не покрыты проверками net.IP: "эта сеть" и CGNAT
+var nonPublicNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+}
+
+func mustParseCIDR(s string) *net.IPNet {
+	_, network, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// isPublicIP разрешает только глобальные unicast-адреса вне внутренних диапазонов
+func isPublicIP(ip net.IP) bool {
+	if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() || ip.IsLoopback() ||
+		ip.IsLinkLocalUnicast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// publicHTTPClient проверяет уже разрешенный IP при каждом соединении, в том числе после редиректов
+func publicHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicIP(net.ParseIP(host)) {
+				return errPrivateAddress
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+	}
+}
+
+func (h *MediaHandler) ListMedia(c *gin.Context) {
+	var query models.ListMediaQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	list := repository.ListQuery{Page: query.Page, PerPage: query.PerPage}
+	if query.MimeType != "" {
+		list.Filters = append(list.Filters, repository.Filter{Name: "mime_type", Condition: "mime_type = $?", Value: query.MimeType})
+	}
+
+	page, err := h.repo.List(c.Request.Context(), list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch media",
+		})
+		return
+	}
+
+	response := listResponse(c, "media", mediaListParams, page, false)
+	delete(response, "cached")
+	c.JSON(http.StatusOK, response)
+}
+
+// UploadMedia принимает multipart-поле file; в ответе id для media_id, url для img и копии в images
+func (h *MediaHandler) UploadMedia(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+multipartOverhead)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.respondTooLarge(c)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "File is required",
+		})
+		return
+	}
+	if header.Size > h.maxSize {
+		h.respondTooLarge(c)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "File is required",
+		})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, h.maxSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to read file",
+		})
+		return
+	}
+	if int64(len(data)) > h.maxSize {
+		h.respondTooLarge(c)
+		return
+	}
+
+	h.save(c, header.Filename, data, "")
+}
+
+// ImportMedia скачивает картинку по внешнему URL в медиатеку, чтобы для нее построились копии
+func (h *MediaHandler) ImportMedia(c *gin.Context) {
+	var req models.ImportMediaRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	source, err := importSource(req.URL)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Only http and https URLs can be imported",
+		})
+		return
+	}
+
+	data, err := h.download(c.Request.Context(), source)
+	var status *fetchStatusError
+	if errors.Is(err, errMediaTooLarge) {
+		h.respondTooLarge(c)
+		return
+	} else if errors.As(err, &status) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Failed to fetch image",
+			"status": status.code,
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to fetch image",
+		})
+		return
+	}
+
+	h.save(c, importName(source), data, req.URL)
+}
+
+// externalImageTimeout сколько сохранение записи ждет скачивания ее внешнего img
+const externalImageTimeout = 10 * time.Second
+
+// ImportImage привязывает внешний img записи к медиатеке (repository.ImageImporter): адрес, который уже есть
+// в медиатеке как url или source_url, берется готовым, иначе картинка скачивается и для нее строятся копии
+func (h *MediaHandler) ImportImage(ctx context.Context, rawURL string) (int, error) {
+	media, err := h.repo.FindByURL(ctx, rawURL)
+	if err == nil {
+		return media.ID, nil
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return 0, err
+	}
+
+	source, err := importSource(rawURL)
+	if err != nil {
+		return 0, err
+	}
+
+	downloadCtx, cancel := context.WithTimeout(ctx, externalImageTimeout)
+	defer cancel()
+	data, err := h.download(downloadCtx, source)
+	if err == nil {
+		media, err = h.create(ctx, importName(source), data, rawURL, nil)
+	}
+	if err != nil {
+		log.Printf("Failed to import external image %s: %v", rawURL, err)
+		return 0, err
+	}
+	return media.ID, nil
+}
+
+var (
+	errUnsupportedScheme = errors.New("only http and https URLs can be imported")
+	errMediaTooLarge     = errors.New("file too large")
+	errUnsupportedType   = errors.New("unsupported file type")
+)
+
+// fetchStatusError источник ответил не 200
+type fetchStatusError struct {
+	code int
+}
+
+func (e *fetchStatusError) Error() string {
+	return "unexpected status " + strconv.Itoa(e.code)
+}
+
+func importSource(rawURL string) (*url.URL, error) {
+	source, err := url.Parse(rawURL)
+	if err != nil || (source.Scheme != "http" && source.Scheme != "https") {
+		return nil, errUnsupportedScheme
+	}
+	return source, nil
+}
+
+// importName имя файла для медиатеки из пути URL, для адреса без пути - хост
+func importName(source *url.URL) string {
+	name := path.Base(source.Path)
+	if name == "/" || name == "." {
+		name = source.Host
+	}
+	return name
+}
+
+// download скачивает не больше maxSize байт через клиент с защитой от SSRF
+func (h *MediaHandler) download(ctx context.Context, source *url.URL) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, source.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &fetchStatusError{code: resp.StatusCode}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, h.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > h.maxSize {
+		return nil, errMediaTooLarge
+	}
+	return data, nil
+}
+
+// save сохраняет файл в медиатеку и отвечает созданной записью; sourceURL пустой для загрузки с диска
+func (h *MediaHandler) save(c *gin.Context, name string, data []byte, sourceURL string) {
+	var uploadedBy *int
+	if userID := c.GetInt(middleware.ContextUserID); userID != 0 {
+		uploadedBy = &userID
+	}
+
+	created, err := h.create(c.Request.Context(), name, data, sourceURL, uploadedBy)
+	if errors.Is(err, errUnsupportedType) {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"error":   "Unsupported file type",
+			"allowed": mediaTypes,
+		})
+		return
+	} else if errors.Is(err, imaging.ErrTooLarge) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Image dimensions too large",
+		})
+		return
+	} else if errors.Is(err, imaging.ErrInvalidImage) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid image",
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to upload file",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// create проверяет тип, строит копии и сохраняет оригинал с копиями в хранилище и БД
+func (h *MediaHandler) create(ctx context.Context, name string, data []byte, sourceURL string, uploadedBy *int) (models.Media, error) {
+	detected := mimetype.Detect(data)
+	if !slices.Contains(mediaTypes, detected.String()) {
+		return models.Media{}, errUnsupportedType
+	}
+
+	width, height, resized, err := imaging.Generate(data)
+	if err != nil {
+		return models.Media{}, err
+	}
+
+	key, err := storage.NewKey(detected.Extension())
+	if err != nil {
+		return models.Media{}, err
+	}
+
+	media := models.Media{
+		Key:          key,
+		URL:          h.store.URL(key),
+		OriginalName: filepath.Base(name),
+		MimeType:     detected.String(),
+		Size:         int64(len(data)),
+		UploadedBy:   uploadedBy,
+	}
+	if sourceURL != "" {
+		media.SourceURL = &sourceURL
+	}
+	media.Images.Original = models.ImageVariant{URL: media.URL, Width: width, Height: height}
+
+	stored := []string{key}
+	err = h.store.Put(ctx, key, data, detected.String())
+	for _, variant := range resized {
+		if err != nil {
+			break
+		}
+		base := strings.TrimSuffix(key, detected.Extension()) + "_" + variant.Name
+		jpegKey, webpKey := base+".jpg", base+".webp"
+		err = h.store.Put(ctx, jpegKey, variant.JPEG, "image/jpeg")
+		if err == nil {
+			stored = append(stored, jpegKey)
+			err = h.store.Put(ctx, webpKey, variant.WebP, "image/webp")
+		}
+		if err == nil {
+			stored = append(stored, webpKey)
+			media.VariantKeys = append(media.VariantKeys, jpegKey, webpKey)
+			setImageVariant(&media.Images, variant.Name, models.ImageVariant{
+				URL:    h.store.URL(jpegKey),
+				WebP:   h.store.URL(webpKey),
+				Width:  variant.Width,
+				Height: variant.Height,
+			})
+		}
+	}
+	media.Images.Srcset = srcset(media.Images, false)
+	if detected.String() == "image/webp" {
+		media.Images.Original.WebP = media.URL
+	}
+	media.Images.SrcsetWebP = srcset(media.Images, true)
+
+	var created models.Media
+	if err == nil {
+		created, err = h.repo.Create(ctx, media)
+	}
+	if err != nil {
+		// Файлы без записи никто не найдет, убираем их сразу
+		h.deleteFiles(ctx, stored)
+		return created, err
+	}
+	return created, nil
+}
+
+func setImageVariant(images *models.Images, name string, variant models.ImageVariant) {
+	switch name {
+	case "thumbnail":
+		images.Thumbnail = &variant
+	case "card":
+		images.Card = &variant
+	case "hero":
+		images.Hero = &variant
+	}
+}
+
+// srcset перечисляет копии от меньшей к большей и оригинал последним.
+// Для webp берутся только WebP-адреса: оригинал попадает туда, лишь если он сам в WebP
+func srcset(images models.Images, webp bool) string {
+	var candidates []string
+	for _, variant := range []*models.ImageVariant{images.Thumbnail, images.Card, images.Hero, &images.Original} {
+		if variant == nil {
+			continue
+		}
+		url := variant.URL
+		if webp {
+			url = variant.WebP
+		}
+		if url == "" {
+			continue
+		}
+		if variant.Width > 0 {
+			candidates = append(candidates, url+" "+strconv.Itoa(variant.Width)+"w")
+		} else {
+			candidates = append(candidates, url)
+		}
+	}
+	return strings.Join(candidates, ", ")
+}
+
+func (h *MediaHandler) deleteFiles(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := h.store.Delete(ctx, key); err != nil {
+			log.Printf("Failed to delete media file %s: %v", key, err)
+		}
+	}
+}
+
+// DeleteMedia удаляет запись, файл и его копии; файл, который еще стоит картинкой записей, не удаляется
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+	id, ok := bindID(c, "Invalid media ID")
+	if !ok {
+		return
+	}
+
+	media, err := h.repo.Delete(c.Request.Context(), id)
+	var inUse *repository.MediaInUseError
+	if errors.As(err, &inUse) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Media is in use",
+			"used_by": inUse.UsedBy,
+		})
+		return
+	} else if err != nil {
+		respondError(c, err, "Media not found", "Failed to delete media")
+		return
+	}
+
+	// Запись уже удалена, поэтому ошибку хранилища только логируем: повторить удаление по id нельзя
+	h.deleteFiles(c.Request.Context(), append([]string{media.Key}, media.VariantKeys...))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Media deleted successfully",
+	})
+}
+
+func (h *MediaHandler) respondTooLarge(c *gin.Context) {
+	c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error":    "File too large",
+		"max_size": h.maxSize,
+	})
+}
@@ -3,187 +3,319 @@ package handlers
 import (
 	"database/sql"
 	"net/http"
-	"strconv"
-	"time"
 
+	"ASMO-site-backend/internal/auth"
 	"ASMO-site-backend/internal/cache"
-	"ASMO-site-backend/internal/metrics"
 	"ASMO-site-backend/internal/models"
-	"ASMO-site-backend/internal/validation"
+	"ASMO-site-backend/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
 type MobileProjectsHandler struct {
-	db    *sql.DB
-	cache cache.Cache
+	repo repository.Projects[models.MobileProjects]
 }
 
 func NewMobileProjectsHandler(db *sql.DB, cache cache.Cache) *MobileProjectsHandler {
+	return NewMobileProjectsHandlerWithRepository(repository.NewMobileProjectsRepository(db, cache))
+}
+
+func NewMobileProjectsHandlerWithRepository(repo repository.Projects[models.MobileProjects]) *MobileProjectsHandler {
 	return &MobileProjectsHandler{
-		db:    db,
-		cache: cache,
+		repo: repo,
 	}
 }
 
 func (h *MobileProjectsHandler) GetMobileProjects(c *gin.Context) {
-	start := time.Now()
-	cacheKey := "mobile_projects:all"
-
-	// Пробуем получить из кэша
-	var projects []models.MobileProjects
-	if err := h.cache.Get(cacheKey, &projects); err == nil {
-		metrics.RecordDatabaseQuery("cache_hit", "mobile_projects", time.Since(start))
-		c.JSON(http.StatusOK, gin.H{
-			"projects": projects,
-			"count":    len(projects),
-			"cached":   true,
-		})
+	var query models.ListProjectsQuery
+	if !bindQuery(c, &query) {
 		return
 	}
 
-	// Если нет в кэше, получаем из БД
-	rows, err := h.db.Query(`
-		SELECT id, name, description, img, price, time_develop, created_at, update_at
-		FROM mobile_projects
-		ORDER BY created_at DESC
-	`)
-
-	metrics.RecordDatabaseQuery("select", "mobile_projects", time.Since(start))
-
+	page, cached, err := h.repo.List(localeContext(c), visibleOnly(c, auth.PermissionManageProjects, projectListQuery(query), query.Status))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch mobile projects",
 		})
 		return
 	}
-	defer rows.Close()
 
-	projects = []models.MobileProjects{}
-	for rows.Next() {
-		var project models.MobileProjects
-		err := rows.Scan(
-			&project.ID, &project.Name, &project.Description, &project.Img,
-			&project.Price, &project.TimeDevelop, &project.CreatedAt, &project.UpdateAt,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to process mobile projects",
-			})
-			return
-		}
-		projects = append(projects, project)
+	c.JSON(http.StatusOK, listResponse(c, "projects", projectListParams, page, cached))
+}
+
+func (h *MobileProjectsHandler) GetMobileProject(c *gin.Context) {
+	id, ok := bindKey(c, h.repo, "Invalid project ID", "Mobile project not found", "Failed to fetch mobile project")
+	if !ok {
+		return
 	}
 
-	if len(projects) == 0 {
-		projects = []models.MobileProjects{}
+	project, _, err := h.repo.Get(localeContext(c), id)
+	if err != nil {
+		respondError(c, err, "Mobile project not found", "Failed to fetch mobile project")
+		return
 	}
 
-	// Сохраняем в кэш на 5 минут
-	h.cache.Set(cacheKey, projects, 5*time.Minute)
+	// Неопубликованные записи для публичных клиентов не существуют
+	if !isVisible(c, auth.PermissionManageProjects, project.Status) {
+		respondError(c, repository.ErrNotFound, "Mobile project not found", "Failed to fetch mobile project")
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"projects": projects,
-		"count":    len(projects),
-		"cached":   false,
-	})
+	project.Gallery, err = h.repo.Gallery(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Mobile project not found", "Failed to fetch mobile project")
+		return
+	}
+	project.Tags, err = h.repo.Tags(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Mobile project not found", "Failed to fetch mobile project")
+		return
+	}
+	team, err := h.repo.Team(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Mobile project not found", "Failed to fetch mobile project")
+		return
+	}
+	project.Team = visibleTeam(c, team)
+
+	c.JSON(http.StatusOK, project)
 }
 
-func (h *MobileProjectsHandler) GetMobileProject(c *gin.Context) {
-	start := time.Now()
-	var req models.GetProjectRequest
-	if err := c.ShouldBindUri(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid project ID",
-		})
+func (h *MobileProjectsHandler) CreateMobileProject(c *gin.Context) {
+	var req models.CreateMobileProjectRequest
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	if errs := validation.ValidateStruct(req); errs != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Validation failed",
-			"details": errs,
-		})
+	project, err := h.repo.Create(auditContext(c), mobileProjectFields(req))
+	if err != nil {
+		respondError(c, err, "Mobile project not found", "Failed to create mobile project")
 		return
 	}
 
-	cacheKey := "mobile_project:" + strconv.Itoa(req.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Mobile project created successfully",
+		"id":      project.ID,
+	})
+}
 
-	// Пробуем получить из кэша
-	var project models.MobileProjects
-	if err := h.cache.Get(cacheKey, &project); err == nil {
-		metrics.RecordDatabaseQuery("cache_hit", "mobile_projects", time.Since(start))
-		c.JSON(http.StatusOK, project)
+func (h *MobileProjectsHandler) UpdateMobileProject(c *gin.Context) {
+	id, ok := bindID(c, "Invalid project ID")
+	if !ok {
 		return
 	}
 
-	err := h.db.QueryRow(`
-		SELECT id, name, description, img, price, time_develop, created_at, update_at
-		FROM mobile_projects WHERE id = $1
-	`, req.ID).Scan(
-		&project.ID, &project.Name, &project.Description, &project.Img,
-		&project.Price, &project.TimeDevelop, &project.CreatedAt, &project.UpdateAt,
-	)
+	var req models.CreateMobileProjectRequest
+	if !bindJSON(c, &req) {
+		return
+	}
 
-	metrics.RecordDatabaseQuery("select", "mobile_projects", time.Since(start))
+	project, err := h.repo.Update(auditContext(c), id, mobileProjectFields(req))
+	if err != nil {
+		respondError(c, err, "Mobile project not found", "Failed to update mobile project")
+		return
+	}
 
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Mobile project not found",
-		})
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *MobileProjectsHandler) PatchMobileProject(c *gin.Context) {
+	id, ok := bindID(c, "Invalid project ID")
+	if !ok {
 		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch mobile project",
+	}
+
+	var req models.PatchMobileProjectRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	// Обновляем только переданные поля
+	fields := repository.Fields{}
+	repository.Optional(fields, "name", req.Name)
+	repository.Optional(fields, "description", req.Description)
+	repository.Optional(fields, "img", req.Img)
+	repository.Optional(fields, "media_id", req.MediaID)
+	repository.Optional(fields, "price", req.Price)
+	repository.Optional(fields, "time_develop", req.TimeDevelop)
+	repository.Optional(fields, "app_store_url", req.AppStoreURL)
+	repository.Optional(fields, "google_play_url", req.GooglePlayURL)
+	repository.Optional(fields, "platforms", req.Platforms)
+
+	if len(fields) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "No fields to update",
 		})
 		return
 	}
 
-	// Сохраняем в кэш на 10 минут
-	h.cache.Set(cacheKey, project, 10*time.Minute)
+	project, err := h.repo.Update(auditContext(c), id, fields)
+	if err != nil {
+		respondError(c, err, "Mobile project not found", "Failed to update mobile project")
+		return
+	}
 
 	c.JSON(http.StatusOK, project)
 }
 
-func (h *MobileProjectsHandler) CreateMobileProject(c *gin.Context) {
-	start := time.Now()
-	var req models.CreateMobileProjectRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-		})
+func (h *MobileProjectsHandler) DeleteMobileProject(c *gin.Context) {
+	id, ok := bindID(c, "Invalid project ID")
+	if !ok {
 		return
 	}
 
-	if errs := validation.ValidateStruct(req); errs != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Validation failed",
-			"details": errs,
-		})
+	if err := h.repo.Delete(auditContext(c), id); err != nil {
+		respondError(c, err, "Mobile project not found", "Failed to delete mobile project")
 		return
 	}
 
-	var id int
-	err := h.db.QueryRow(`
-		INSERT INTO mobile_projects (name, description, img, price, time_develop, created_at, update_at)
-		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-		RETURNING id
-	`, req.Name, req.Description, req.Img, req.Price, req.TimeDevelop).Scan(&id)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Mobile project deleted successfully",
+		"id":      id,
+	})
+}
 
-	metrics.RecordDatabaseQuery("insert", "mobile_projects", time.Since(start))
+// RestoreMobileProject возвращает запись из корзины
+func (h *MobileProjectsHandler) RestoreMobileProject(c *gin.Context) {
+	id, ok := bindID(c, "Invalid project ID")
+	if !ok {
+		return
+	}
 
+	project, err := h.repo.Restore(auditContext(c), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to create mobile project",
-		})
+		respondError(c, err, "Mobile project not found in trash", "Failed to restore mobile project")
 		return
 	}
 
-	// Инвалидируем кэш при создании нового проекта
-	h.cache.Delete("mobile_projects:all")
+	c.JSON(http.StatusOK, project)
+}
+
+// TransitionMobileProject обработчик перехода статуса (submit, publish, reject, archive, reopen)
+func (h *MobileProjectsHandler) TransitionMobileProject(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		transition(c, h.repo, action, "Invalid project ID", "Mobile project not found", "Failed to change mobile project status")
+	}
+}
+
+// ScheduleMobileProject назначает время автоматической публикации и снятия с публикации
+func (h *MobileProjectsHandler) ScheduleMobileProject(c *gin.Context) {
+	schedule(c, h.repo, "Invalid project ID", "Mobile project not found", "Failed to schedule mobile project")
+}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "Mobile project created successfully",
-		"id":      id,
+var mobileProjectRevisionMessages = entityMessages{
+	invalid:  "Invalid revision",
+	notFound: "Mobile project revision not found",
+	failed:   "Failed to fetch mobile project revisions",
+}
+
+// GetMobileProjectRevisions история изменений записи
+func (h *MobileProjectsHandler) GetMobileProjectRevisions(c *gin.Context) {
+	listRevisions(c, h.repo, mobileProjectRevisionMessages)
+}
+
+// GetMobileProjectRevision снимок версии и отличия от текущей записи
+func (h *MobileProjectsHandler) GetMobileProjectRevision(c *gin.Context) {
+	getRevision(c, h.repo, mobileProjectRevisionMessages)
+}
+
+// RestoreMobileProjectRevision откатывает запись к выбранной версии
+func (h *MobileProjectsHandler) RestoreMobileProjectRevision(c *gin.Context) {
+	restoreRevision(c, h.repo, entityMessages{
+		invalid:  "Invalid revision",
+		notFound: "Mobile project revision not found",
+		failed:   "Failed to restore mobile project revision",
+	})
+}
+
+// GetMobileProjectTranslations все переводы записи
+func (h *MobileProjectsHandler) GetMobileProjectTranslations(c *gin.Context) {
+	listTranslations(c, h.repo, entityMessages{
+		invalid:  "Invalid translation request",
+		notFound: "Mobile project not found",
+		failed:   "Failed to fetch mobile project translations",
+	})
+}
+
+// SetMobileProjectTranslation создает или заменяет перевод на :locale
+func (h *MobileProjectsHandler) SetMobileProjectTranslation(c *gin.Context) {
+	setTranslation(c, h.repo, entityMessages{
+		invalid:  "Invalid translation request",
+		notFound: "Mobile project not found",
+		failed:   "Failed to update mobile project translation",
+	})
+}
+
+func (h *MobileProjectsHandler) DeleteMobileProjectTranslation(c *gin.Context) {
+	deleteTranslation(c, h.repo, entityMessages{
+		invalid:  "Invalid translation request",
+		notFound: "Mobile project translation not found",
+		failed:   "Failed to delete mobile project translation",
+	})
+}
+
+// AddMobileProjectMedia добавляет картинку из медиатеки или видео в конец галереи
+func (h *MobileProjectsHandler) AddMobileProjectMedia(c *gin.Context) {
+	addGalleryItem(c, h.repo, entityMessages{
+		invalid:  "Invalid project ID",
+		notFound: "Mobile project not found",
+		failed:   "Failed to add mobile project media",
+	})
+}
+
+func (h *MobileProjectsHandler) RemoveMobileProjectMedia(c *gin.Context) {
+	removeGalleryItem(c, h.repo, entityMessages{
+		invalid:  "Invalid gallery item request",
+		notFound: "Gallery item not found",
+		failed:   "Failed to delete mobile project media",
+	})
+}
+
+// ReorderMobileProjectMedia принимает id всех элементов галереи в новом порядке
+func (h *MobileProjectsHandler) ReorderMobileProjectMedia(c *gin.Context) {
+	reorderGallery(c, h.repo, entityMessages{
+		invalid:  "Invalid project ID",
+		notFound: "Mobile project not found",
+		failed:   "Failed to reorder mobile project media",
+	})
+}
+
+// SetMobileProjectTags заменяет теги проекта: {"tags": ["go", "react"]}
+func (h *MobileProjectsHandler) SetMobileProjectTags(c *gin.Context) {
+	setTags(c, h.repo, entityMessages{
+		invalid:  "Invalid project ID",
+		notFound: "Mobile project not found",
+		failed:   "Failed to update mobile project tags",
+	})
+}
+
+// AssignMobileProjectMember добавляет сотрудника в команду или меняет его роль: {"role": "backend"}
+func (h *MobileProjectsHandler) AssignMobileProjectMember(c *gin.Context) {
+	assignTeamMember(c, h.repo, entityMessages{
+		invalid:  "Invalid team member request",
+		notFound: "Mobile project not found",
+		failed:   "Failed to assign mobile project team member",
 	})
-}
\ No newline at end of file
+}
+
+func (h *MobileProjectsHandler) UnassignMobileProjectMember(c *gin.Context) {
+	unassignTeamMember(c, h.repo, entityMessages{
+		invalid:  "Invalid team member request",
+		notFound: "Team member not found",
+		failed:   "Failed to remove mobile project team member",
+	})
+}
+
+func mobileProjectFields(req models.CreateMobileProjectRequest) repository.Fields {
+	return repository.Fields{
+		"name":            req.Name,
+		"description":     req.Description,
+		"img":             req.Img,
+		"media_id":        req.MediaID,
+		"price":           req.Price,
+		"time_develop":    req.TimeDevelop,
+		"app_store_url":   req.AppStoreURL,
+		"google_play_url": req.GooglePlayURL,
+		"platforms":       req.Platforms,
+	}
+}
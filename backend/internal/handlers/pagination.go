@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/url"
+	"strconv"
+
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	projectListParams = []string{"page", "per_page", "sort", "order", "price_min", "price_max", "time_develop_max", "tags", "lang"}
+	catalogListParams = append(append([]string{}, projectListParams...), "type")
+	staffListParams   = []string{"page", "per_page", "sort", "order", "role", "department", "lang"}
+)
+
+// projectListQuery общие фильтры для web/mobile/bots проектов
+func projectListQuery(q models.ListProjectsQuery) repository.ListQuery {
+	list := repository.ListQuery{
+		Page:    q.Page,
+		PerPage: q.PerPage,
+		Sort:    q.Sort,
+		Order:   q.Order,
+		Tags:    parseTags(q.Tags),
+	}
+
+	if q.PriceMin != nil {
+		list.Filters = append(list.Filters, repository.Filter{Name: "price_min", Condition: "price >= $?", Value: *q.PriceMin})
+	}
+	if q.PriceMax != nil {
+		list.Filters = append(list.Filters, repository.Filter{Name: "price_max", Condition: "price <= $?", Value: *q.PriceMax})
+	}
+	if q.TimeDevelopMax != nil {
+		list.Filters = append(list.Filters, repository.Filter{Name: "time_develop_max", Condition: "time_develop <= $?", Value: *q.TimeDevelopMax})
+	}
+
+	return list
+}
+
+// staffListQuery по умолчанию страница команды идет в ручном порядке display_order
+func staffListQuery(q models.ListStaffQuery) repository.ListQuery {
+	list := repository.ListQuery{
+		Page:    q.Page,
+		PerPage: q.PerPage,
+		Sort:    q.Sort,
+		Order:   q.Order,
+	}
+	if list.Sort == "" {
+		list.Sort = "display_order"
+	}
+	if list.Order == "" && list.Sort == "display_order" {
+		list.Order = "asc"
+	}
+
+	if q.Role != "" {
+		list.Filters = append(list.Filters, repository.Filter{Name: "role", Condition: "LOWER(role) = LOWER($?)", Value: q.Role})
+	}
+	if q.Department != "" {
+		list.Filters = append(list.Filters, repository.Filter{Name: "department", Condition: "LOWER(department) = LOWER($?)", Value: q.Department})
+	}
+
+	return list
+}
+
+// listResponse ответ со страницей списка; key - имя массива в JSON ("projects", "staff")
+func listResponse[T any](c *gin.Context, key string, params []string, page repository.Page[T], cached bool) gin.H {
+	return gin.H{
+		key:        page.Items,
+		"count":    len(page.Items),
+		"total":    page.Total,
+		"page":     page.Page,
+		"per_page": page.PerPage,
+		"links":    paginationLinks(c, params, page.Page, page.PerPage, page.Total),
+		"cached":   cached,
+	}
+}
+
+// knownParams оставляет только поддерживаемые параметры
+func knownParams(c *gin.Context, params []string) url.Values {
+	query := c.Request.URL.Query()
+	values := url.Values{}
+	for _, name := range params {
+		if v := query.Get(name); v != "" {
+			values.Set(name, v)
+		}
+	}
+	return values
+}
+
+// paginationLinks ссылки на соседние страницы с сохранением остальных параметров запроса
+func paginationLinks(c *gin.Context, params []string, page, perPage, total int) gin.H {
+	link := func(p int) string {
+		values := knownParams(c, params)
+		values.Set("page", strconv.Itoa(p))
+		values.Set("per_page", strconv.Itoa(perPage))
+		u := url.URL{Path: c.Request.URL.Path, RawQuery: values.Encode()}
+		return u.String()
+	}
+
+	links := gin.H{"next": nil, "prev": nil}
+	if page*perPage < total {
+		links["next"] = link(page + 1)
+	}
+	if page > 1 {
+		links["prev"] = link(page - 1)
+	}
+	return links
+}
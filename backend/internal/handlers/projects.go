@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectsHandler общий каталог поверх web_projects, mobile_projects и bots_projects
+type ProjectsHandler struct {
+	repo repository.Lister[models.Project]
+}
+
+func NewProjectsHandler(db *sql.DB, cache cache.Cache) *ProjectsHandler {
+	return NewProjectsHandlerWithRepository(repository.NewProjectsCatalogRepository(db, cache))
+}
+
+func NewProjectsHandlerWithRepository(repo repository.Lister[models.Project]) *ProjectsHandler {
+	return &ProjectsHandler{
+		repo: repo,
+	}
+}
+
+func (h *ProjectsHandler) GetProjects(c *gin.Context) {
+	var query models.ListCatalogQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	list := projectListQuery(query.ListProjectsQuery)
+	if query.Type != "" {
+		list.Filters = append(list.Filters, repository.Filter{Name: "type", Condition: "type = $?", Value: query.Type})
+	}
+
+	page, cached, err := h.repo.List(localeContext(c), list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch projects",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, listResponse(c, "projects", catalogListParams, page, cached))
+}
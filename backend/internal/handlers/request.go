@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"ASMO-site-backend/internal/middleware"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+	"ASMO-site-backend/internal/slug"
+	"ASMO-site-backend/internal/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// entityMessages тексты ошибок вложенных ручек (история, переводы) конкретной сущности
+type entityMessages struct {
+	invalid  string
+	notFound string
+	failed   string
+}
+
+// bindID читает и валидирует :id из URI; при ошибке ответ уже отправлен
+func bindID(c *gin.Context, invalidMessage string) (int, bool) {
+	var req models.GetProjectRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": invalidMessage,
+		})
+		return 0, false
+	}
+
+	if errs := validation.ValidateStruct(req); errs != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Validation failed",
+			"details": errs,
+		})
+		return 0, false
+	}
+
+	return req.ID, true
+}
+
+// bindKey читает :id публичного GET: число - это id, иначе slug. Для старого slug
+// отправляется 301 на текущий адрес, как и любая ошибка, это значит, что ответ уже отправлен
+func bindKey[T any](c *gin.Context, repo repository.CRUD[T], invalidMessage, notFoundMessage, failedMessage string) (int, bool) {
+	var req models.GetByKeyRequest
+	if err := c.ShouldBindUri(&req); err != nil || validation.ValidateStruct(req) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": invalidMessage,
+		})
+		return 0, false
+	}
+
+	if id, err := strconv.Atoi(req.Key); err == nil {
+		if id < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": invalidMessage,
+			})
+			return 0, false
+		}
+		return id, true
+	}
+
+	if !slug.Valid(req.Key) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": invalidMessage,
+		})
+		return 0, false
+	}
+
+	id, current, err := repo.ResolveSlug(c.Request.Context(), req.Key)
+	if err != nil {
+		respondError(c, err, notFoundMessage, failedMessage)
+		return 0, false
+	}
+
+	if current != req.Key {
+		location := url.URL{Path: path.Join(path.Dir(c.Request.URL.Path), current), RawQuery: c.Request.URL.RawQuery}
+		c.Redirect(http.StatusMovedPermanently, location.String())
+		return 0, false
+	}
+
+	return id, true
+}
+
+// bindJSON разбирает и валидирует тело запроса; при ошибке ответ уже отправлен
+func bindJSON(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return false
+	}
+
+	return validateRequest(c, req)
+}
+
+// bindQuery разбирает и валидирует query-параметры; при ошибке ответ уже отправлен
+func bindQuery(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid query parameters",
+		})
+		return false
+	}
+
+	return validateRequest(c, req)
+}
+
+func validateRequest(c *gin.Context, req interface{}) bool {
+	if errs := validation.ValidateStruct(req); errs != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Validation failed",
+			"details": errs,
+		})
+		return false
+	}
+	return true
+}
+
+// localeContext контекст чтения с локалью, выбранной middleware.Locale
+func localeContext(c *gin.Context) context.Context {
+	return repository.WithLocale(c.Request.Context(), middleware.TranslationLocale(c))
+}
+
+// auditContext контекст запроса с автором изменения для журнала аудита
+func auditContext(c *gin.Context) context.Context {
+	actor := repository.Actor{
+		UserID:    c.GetInt(middleware.ContextUserID),
+		APIKeyID:  c.GetInt(middleware.ContextAPIKeyID),
+		Name:      c.GetString(middleware.ContextUsername),
+		IP:        c.ClientIP(),
+		RequestID: c.GetString("requestID"),
+	}
+	if actor.Name == "" && actor.APIKeyID != 0 {
+		actor.Name = "api_key:" + strconv.Itoa(actor.APIKeyID)
+	}
+	return repository.WithActor(c.Request.Context(), actor)
+}
+
+// respondError переводит ошибку репозитория в 404, 400 (неверная ссылка или порядок) или 500
+func respondError(c *gin.Context, err error, notFoundMessage, failedMessage string) {
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": notFoundMessage,
+		})
+		return
+	}
+	if errors.Is(err, repository.ErrMediaNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Media not found",
+		})
+		return
+	}
+	if errors.Is(err, repository.ErrTagNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Tag not found",
+		})
+		return
+	}
+	if errors.Is(err, repository.ErrStaffNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Staff member not found",
+		})
+		return
+	}
+	if errors.Is(err, repository.ErrProjectNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Project not found",
+		})
+		return
+	}
+	if errors.Is(err, repository.ErrAdminUserNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Admin user not found",
+		})
+		return
+	}
+	if errors.Is(err, repository.ErrInvalidGalleryOrder) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Order must list every gallery item exactly once",
+		})
+		return
+	}
+	if errors.Is(err, repository.ErrInvalidOrder) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Order must list every record exactly once",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": failedMessage,
+	})
+}
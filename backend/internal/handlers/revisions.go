@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+	"ASMO-site-backend/internal/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// revisionSource ревизии вместе с текущей версией записи, с которой сравнивается снимок
+type revisionSource[T any] interface {
+	repository.Revisions[T]
+	Get(ctx context.Context, id int) (T, bool, error)
+}
+
+// bindRevision читает :id и :rev из URI; при ошибке ответ уже отправлен
+func bindRevision(c *gin.Context, invalidMessage string) (models.GetRevisionRequest, bool) {
+	var req models.GetRevisionRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": invalidMessage,
+		})
+		return req, false
+	}
+
+	if errs := validation.ValidateStruct(req); errs != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Validation failed",
+			"details": errs,
+		})
+		return req, false
+	}
+
+	return req, true
+}
+
+func listRevisions[T any](c *gin.Context, repo repository.Revisions[T], messages entityMessages) {
+	id, ok := bindID(c, messages.invalid)
+	if !ok {
+		return
+	}
+
+	revisions, err := repo.Revisions(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  revisions,
+		"count": len(revisions),
+	})
+}
+
+// getRevision отдает снимок и отличия от текущей версии по полям
+func getRevision[T any](c *gin.Context, repo revisionSource[T], messages entityMessages) {
+	req, ok := bindRevision(c, messages.invalid)
+	if !ok {
+		return
+	}
+
+	revision, err := repo.Revision(c.Request.Context(), req.ID, req.Rev)
+	if err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	current, _, err := repo.Get(c.Request.Context(), req.ID)
+	if err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	before, after, err := repository.AuditDiff(revision.Snapshot, current)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": messages.failed,
+		})
+		return
+	}
+
+	diff := map[string]gin.H{}
+	for field, value := range after {
+		diff[field] = gin.H{
+			"revision": before[field],
+			"current":  value,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"revision": revision,
+		"diff":     diff,
+	})
+}
+
+// restoreRevision откатывает редактируемые поля к снимку
+func restoreRevision[T any](c *gin.Context, repo repository.Revisions[T], messages entityMessages) {
+	req, ok := bindRevision(c, messages.invalid)
+	if !ok {
+		return
+	}
+
+	item, err := repo.RestoreRevision(auditContext(c), req.ID, req.Rev)
+	if err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultSearchLimit = 10
+
+// searchTables таблицы, по которым идет поиск; ключ совпадает с группой в ответе
+var searchTables = []string{"web_projects", "mobile_projects", "bots_projects", "staff"}
+
+// escapedDescription описание с экранированным HTML: snippet отдается с разметкой <mark>,
+// и клиент, вставляющий его как HTML, не должен исполнить разметку из самого описания
+const escapedDescription = `replace(replace(replace(replace(replace(description,
+	'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+
+type SearchHandler struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+func NewSearchHandler(db *sql.DB, cache cache.Cache) *SearchHandler {
+	return &SearchHandler{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (h *SearchHandler) Search(c *gin.Context) {
+	start := time.Now()
+	var req models.SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid query parameters",
+		})
+		return
+	}
+
+	req.Query = strings.TrimSpace(req.Query)
+	if errs := validation.ValidateStruct(req); errs != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Validation failed",
+			"details": errs,
+		})
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultSearchLimit
+	}
+
+	// Ключ не зависит от регистра, чтобы "Telegram" и "telegram" попадали в один кэш
+	cacheKey := "search:" + strconv.Itoa(req.Limit) + ":" + strings.ToLower(req.Query)
+
+	// Пробуем получить из кэша
+	var results map[string][]models.SearchHit
+	if err := h.cache.Get(cacheKey, &results); err == nil {
+		metrics.RecordDatabaseQuery("cache_hit", "search", time.Since(start))
+		c.JSON(http.StatusOK, gin.H{
+			"query":   req.Query,
+			"results": results,
+			"total":   countHits(results),
+			"cached":  true,
+		})
+		return
+	}
+
+	results = make(map[string][]models.SearchHit, len(searchTables))
+	for _, table := range searchTables {
+		hits, err := h.searchTable(table, req.Query, req.Limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to search",
+			})
+			return
+		}
+		results[table] = hits
+	}
+
+	// Сохраняем в кэш на 5 минут
+	h.cache.Set(cacheKey, results, 5*time.Minute)
+
+	c.JSON(http.StatusOK, gin.H{
+		"query":   req.Query,
+		"results": results,
+		"total":   countHits(results),
+		"cached":  false,
+	})
+}
+
+func (h *SearchHandler) searchTable(table, query string, limit int) ([]models.SearchHit, error) {
+	start := time.Now()
+	// websearch_to_tsquery понимает кавычки, OR и минус, и не падает на синтаксисе пользователя
+	rows, err := h.db.Query(`
+		SELECT id, name, slug,
+			ts_headline('russian', `+escapedDescription+`, q, 'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15') AS snippet,
+			ts_rank(search_vector, q) AS rank
+		FROM `+table+`, websearch_to_tsquery('russian', $1) q
+		WHERE search_vector @@ q AND deleted_at IS NULL AND status = 'published'
+		ORDER BY rank DESC, id DESC
+		LIMIT $2
+	`, query, limit)
+
+	metrics.RecordDatabaseQuery("search", table, time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := []models.SearchHit{}
+	for rows.Next() {
+		var hit models.SearchHit
+		if err := rows.Scan(&hit.ID, &hit.Name, &hit.Slug, &hit.Snippet, &hit.Rank); err != nil {
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
+
+func countHits(results map[string][]models.SearchHit) int {
+	total := 0
+	for _, hits := range results {
+		total += len(hits)
+	}
+	return total
+}
@@ -3,187 +3,296 @@ package handlers
 import (
 	"database/sql"
 	"net/http"
-	"strconv"
-	"time"
 
+	"ASMO-site-backend/internal/auth"
 	"ASMO-site-backend/internal/cache"
-	"ASMO-site-backend/internal/metrics"
 	"ASMO-site-backend/internal/models"
-	"ASMO-site-backend/internal/validation"
+	"ASMO-site-backend/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
 type StaffHandler struct {
-	db    *sql.DB
-	cache cache.Cache
+	repo repository.StaffDirectory
 }
 
 func NewStaffHandler(db *sql.DB, cache cache.Cache) *StaffHandler {
+	return NewStaffHandlerWithRepository(repository.NewStaffRepository(db, cache))
+}
+
+func NewStaffHandlerWithRepository(repo repository.StaffDirectory) *StaffHandler {
 	return &StaffHandler{
-		db:    db,
-		cache: cache,
+		repo: repo,
 	}
 }
 
 func (h *StaffHandler) GetStaff(c *gin.Context) {
-	start := time.Now()
-	cacheKey := "staff:all"
-
-	// Пробуем получить из кэша
-	var staff []models.Staff
-	if err := h.cache.Get(cacheKey, &staff); err == nil {
-		metrics.RecordDatabaseQuery("cache_hit", "staff", time.Since(start))
-		c.JSON(http.StatusOK, gin.H{
-			"staff":  staff,
-			"count":  len(staff),
-			"cached": true,
-		})
+	var query models.ListStaffQuery
+	if !bindQuery(c, &query) {
 		return
 	}
 
-	// Если нет в кэше, получаем из БД
-	rows, err := h.db.Query(`
-		SELECT id, name, description, img, role, created_at, update_at
-		FROM staff
-		ORDER BY created_at DESC
-	`)
-
-	metrics.RecordDatabaseQuery("select", "staff", time.Since(start))
-
+	page, cached, err := h.repo.List(localeContext(c), visibleOnly(c, auth.PermissionManageStaff, staffListQuery(query), query.Status))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch staff",
 		})
 		return
 	}
-	defer rows.Close()
+	for i := range page.Items {
+		hidePrivateContacts(c, &page.Items[i])
+	}
+
+	c.JSON(http.StatusOK, listResponse(c, "staff", staffListParams, page, cached))
+}
+
+func (h *StaffHandler) GetStaffMember(c *gin.Context) {
+	id, ok := bindKey(c, h.repo, "Invalid staff ID", "Staff member not found", "Failed to fetch staff member")
+	if !ok {
+		return
+	}
 
-	staff = []models.Staff{}
-	for rows.Next() {
-		var member models.Staff
-		err := rows.Scan(
-			&member.ID, &member.Name, &member.Description, &member.Img,
-			&member.Role, &member.CreatedAt, &member.UpdateAt,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to process staff",
-			})
-			return
-		}
-		staff = append(staff, member)
+	member, _, err := h.repo.Get(localeContext(c), id)
+	if err != nil {
+		respondError(c, err, "Staff member not found", "Failed to fetch staff member")
+		return
 	}
 
-	if len(staff) == 0 {
-		staff = []models.Staff{}
+	// Неопубликованные записи для публичных клиентов не существуют
+	if !isVisible(c, auth.PermissionManageStaff, member.Status) {
+		respondError(c, repository.ErrNotFound, "Staff member not found", "Failed to fetch staff member")
+		return
 	}
 
-	// Сохраняем в кэш на 5 минут
-	h.cache.Set(cacheKey, staff, 5*time.Minute)
+	projects, err := h.repo.MemberProjects(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Staff member not found", "Failed to fetch staff member")
+		return
+	}
+	member.Projects = visibleProjects(c, projects)
+	hidePrivateContacts(c, &member)
 
-	c.JSON(http.StatusOK, gin.H{
-		"staff":  staff,
-		"count":  len(staff),
-		"cached": false,
-	})
+	c.JSON(http.StatusOK, member)
 }
 
-func (h *StaffHandler) GetStaffMember(c *gin.Context) {
-	start := time.Now()
-	var req models.GetProjectRequest
-	if err := c.ShouldBindUri(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid staff ID",
-		})
+func (h *StaffHandler) CreateStaff(c *gin.Context) {
+	var req models.CreateStaffRequest
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	if errs := validation.ValidateStruct(req); errs != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Validation failed",
-			"details": errs,
-		})
+	member, err := h.repo.Create(auditContext(c), staffFields(req))
+	if err != nil {
+		respondError(c, err, "Staff not found", "Failed to create staff member")
 		return
 	}
 
-	cacheKey := "staff:" + strconv.Itoa(req.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Staff member created successfully",
+		"id":      member.ID,
+	})
+}
 
-	// Пробуем получить из кэша
-	var member models.Staff
-	if err := h.cache.Get(cacheKey, &member); err == nil {
-		metrics.RecordDatabaseQuery("cache_hit", "staff", time.Since(start))
-		c.JSON(http.StatusOK, member)
+func (h *StaffHandler) UpdateStaff(c *gin.Context) {
+	id, ok := bindID(c, "Invalid staff ID")
+	if !ok {
 		return
 	}
 
-	err := h.db.QueryRow(`
-		SELECT id, name, description, img, role, created_at, update_at
-		FROM staff WHERE id = $1
-	`, req.ID).Scan(
-		&member.ID, &member.Name, &member.Description, &member.Img,
-		&member.Role, &member.CreatedAt, &member.UpdateAt,
-	)
+	var req models.CreateStaffRequest
+	if !bindJSON(c, &req) {
+		return
+	}
 
-	metrics.RecordDatabaseQuery("select", "staff", time.Since(start))
+	member, err := h.repo.Update(auditContext(c), id, staffFields(req))
+	if err != nil {
+		respondError(c, err, "Staff member not found", "Failed to update staff member")
+		return
+	}
 
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Staff member not found",
-		})
+	c.JSON(http.StatusOK, member)
+}
+
+func (h *StaffHandler) PatchStaff(c *gin.Context) {
+	id, ok := bindID(c, "Invalid staff ID")
+	if !ok {
 		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch staff member",
+	}
+
+	var req models.PatchStaffRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	// Обновляем только переданные поля
+	fields := repository.Fields{}
+	repository.Optional(fields, "name", req.Name)
+	repository.Optional(fields, "description", req.Description)
+	repository.Optional(fields, "img", req.Img)
+	repository.Optional(fields, "media_id", req.MediaID)
+	repository.Optional(fields, "role", req.Role)
+	repository.Optional(fields, "department", req.Department)
+	repository.Optional(fields, "skills", req.Skills)
+	repository.Optional(fields, "email", req.Email)
+	repository.Optional(fields, "show_email", req.ShowEmail)
+	repository.Optional(fields, "github_url", req.GitHubURL)
+	repository.Optional(fields, "linkedin_url", req.LinkedInURL)
+	repository.Optional(fields, "telegram_url", req.TelegramURL)
+	repository.Optional(fields, "display_order", req.DisplayOrder)
+
+	if len(fields) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "No fields to update",
 		})
 		return
 	}
 
-	// Сохраняем в кэш на 10 минут
-	h.cache.Set(cacheKey, member, 10*time.Minute)
+	member, err := h.repo.Update(auditContext(c), id, fields)
+	if err != nil {
+		respondError(c, err, "Staff member not found", "Failed to update staff member")
+		return
+	}
 
 	c.JSON(http.StatusOK, member)
 }
 
-func (h *StaffHandler) CreateStaff(c *gin.Context) {
-	start := time.Now()
-	var req models.CreateStaffRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-		})
+func (h *StaffHandler) DeleteStaff(c *gin.Context) {
+	id, ok := bindID(c, "Invalid staff ID")
+	if !ok {
 		return
 	}
 
-	if errs := validation.ValidateStruct(req); errs != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Validation failed",
-			"details": errs,
-		})
+	if err := h.repo.Delete(auditContext(c), id); err != nil {
+		respondError(c, err, "Staff member not found", "Failed to delete staff member")
 		return
 	}
 
-	var id int
-	err := h.db.QueryRow(`
-		INSERT INTO staff (name, description, img, role, created_at, update_at)
-		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-		RETURNING id
-	`, req.Name, req.Description, req.Img, req.Role).Scan(&id)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Staff member deleted successfully",
+		"id":      id,
+	})
+}
 
-	metrics.RecordDatabaseQuery("insert", "staff", time.Since(start))
+// RestoreStaff возвращает запись из корзины
+func (h *StaffHandler) RestoreStaff(c *gin.Context) {
+	id, ok := bindID(c, "Invalid staff ID")
+	if !ok {
+		return
+	}
 
+	member, err := h.repo.Restore(auditContext(c), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to create staff member",
-		})
+		respondError(c, err, "Staff member not found in trash", "Failed to restore staff member")
 		return
 	}
 
-	// Инвалидируем кэш при создании нового сотрудника
-	h.cache.Delete("staff:all")
+	c.JSON(http.StatusOK, member)
+}
+
+// TransitionStaff обработчик перехода статуса (submit, publish, reject, archive, reopen)
+func (h *StaffHandler) TransitionStaff(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		transition(c, h.repo, action, "Invalid staff ID", "Staff member not found", "Failed to change staff member status")
+	}
+}
+
+var staffRevisionMessages = entityMessages{
+	invalid:  "Invalid revision",
+	notFound: "Staff member revision not found",
+	failed:   "Failed to fetch staff member revisions",
+}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "Staff member created successfully",
-		"id":      id,
+// GetStaffRevisions история изменений записи
+func (h *StaffHandler) GetStaffRevisions(c *gin.Context) {
+	listRevisions(c, h.repo, staffRevisionMessages)
+}
+
+// GetStaffRevision снимок версии и отличия от текущей записи
+func (h *StaffHandler) GetStaffRevision(c *gin.Context) {
+	getRevision(c, h.repo, staffRevisionMessages)
+}
+
+// RestoreStaffRevision откатывает запись к выбранной версии
+func (h *StaffHandler) RestoreStaffRevision(c *gin.Context) {
+	restoreRevision(c, h.repo, entityMessages{
+		invalid:  "Invalid revision",
+		notFound: "Staff member revision not found",
+		failed:   "Failed to restore staff member revision",
 	})
-}
\ No newline at end of file
+}
+
+// GetStaffTranslations все переводы записи
+func (h *StaffHandler) GetStaffTranslations(c *gin.Context) {
+	listTranslations(c, h.repo, entityMessages{
+		invalid:  "Invalid translation request",
+		notFound: "Staff member not found",
+		failed:   "Failed to fetch staff member translations",
+	})
+}
+
+// SetStaffTranslation создает или заменяет перевод на :locale
+func (h *StaffHandler) SetStaffTranslation(c *gin.Context) {
+	setTranslation(c, h.repo, entityMessages{
+		invalid:  "Invalid translation request",
+		notFound: "Staff member not found",
+		failed:   "Failed to update staff member translation",
+	})
+}
+
+func (h *StaffHandler) DeleteStaffTranslation(c *gin.Context) {
+	deleteTranslation(c, h.repo, entityMessages{
+		invalid:  "Invalid translation request",
+		notFound: "Staff member translation not found",
+		failed:   "Failed to delete staff member translation",
+	})
+}
+
+// ReorderStaff задает порядок страницы команды: {"ids": [3, 1, 2]} - все сотрудники, кроме удаленных
+func (h *StaffHandler) ReorderStaff(c *gin.Context) {
+	var req models.ReorderStaffRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.repo.Reorder(auditContext(c), req.IDs); err != nil {
+		respondError(c, err, "Staff member not found", "Failed to reorder staff")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Staff order updated successfully",
+		"count":   len(req.IDs),
+	})
+}
+
+// hidePrivateContacts email видят публичные клиенты, только если сотрудник разрешил его показывать
+func hidePrivateContacts(c *gin.Context, member *models.Staff) {
+	if !member.ShowEmail && !canSeeUnpublished(c, auth.PermissionManageStaff) {
+		member.Email = ""
+	}
+}
+
+func staffFields(req models.CreateStaffRequest) repository.Fields {
+	skills := req.Skills
+	if skills == nil {
+		skills = []models.Skill{}
+	}
+
+	fields := repository.Fields{
+		"name":         req.Name,
+		"description":  req.Description,
+		"img":          req.Img,
+		"media_id":     req.MediaID,
+		"role":         req.Role,
+		"department":   req.Department,
+		"skills":       skills,
+		"email":        req.Email,
+		"show_email":   req.ShowEmail,
+		"github_url":   req.GitHubURL,
+		"linkedin_url": req.LinkedInURL,
+		"telegram_url": req.TelegramURL,
+	}
+	// Без display_order срабатывает значение по умолчанию - конец списка
+	repository.Optional(fields, "display_order", req.DisplayOrder)
+	return fields
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+	"ASMO-site-backend/internal/slug"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagsHandler справочник тегов; чтение публичное, изменение требует projects.manage
+type TagsHandler struct {
+	repo *repository.TagRepository
+}
+
+func NewTagsHandler(db *sql.DB, cache cache.Cache) *TagsHandler {
+	return &TagsHandler{
+		repo: repository.NewTagRepository(db, cache),
+	}
+}
+
+func (h *TagsHandler) GetTags(c *gin.Context) {
+	var query models.ListTagsQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	tags, cached, err := h.repo.List(c.Request.Context(), query.Kind)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch tags",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tags":   tags,
+		"count":  len(tags),
+		"cached": cached,
+	})
+}
+
+// GetTagCloud теги с числом опубликованных проектов для облака тегов
+func (h *TagsHandler) GetTagCloud(c *gin.Context) {
+	var query models.ListTagsQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	cloud, cached, err := h.repo.Cloud(c.Request.Context(), query.Kind)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch tag cloud",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tags":   cloud,
+		"count":  len(cloud),
+		"cached": cached,
+	})
+}
+
+func (h *TagsHandler) GetTag(c *gin.Context) {
+	id, ok := bindID(c, "Invalid tag ID")
+	if !ok {
+		return
+	}
+
+	tag, err := h.repo.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Tag not found", "Failed to fetch tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+func (h *TagsHandler) CreateTag(c *gin.Context) {
+	var req models.CreateTagRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	tag := models.Tag{Name: strings.TrimSpace(req.Name), Slug: req.Slug, Kind: req.Kind}
+	if tag.Slug == "" {
+		tag.Slug = slug.Make(tag.Name)
+	}
+	if tag.Kind == "" {
+		tag.Kind = models.TagKindTag
+	}
+
+	created, err := h.repo.Create(c.Request.Context(), tag)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Tag with this slug already exists",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create tag",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *TagsHandler) PatchTag(c *gin.Context) {
+	id, ok := bindID(c, "Invalid tag ID")
+	if !ok {
+		return
+	}
+
+	var req models.PatchTagRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	// Обновляем только переданные поля
+	fields := repository.Fields{}
+	if req.Name != nil {
+		fields["name"] = strings.TrimSpace(*req.Name)
+	}
+	repository.Optional(fields, "slug", req.Slug)
+	repository.Optional(fields, "kind", req.Kind)
+
+	if len(fields) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "No fields to update",
+		})
+		return
+	}
+
+	tag, err := h.repo.Update(c.Request.Context(), id, fields)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Tag with this slug already exists",
+		})
+		return
+	}
+	if err != nil {
+		respondError(c, err, "Tag not found", "Failed to update tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// DeleteTag удаляет тег вместе с его связями с проектами
+func (h *TagsHandler) DeleteTag(c *gin.Context) {
+	id, ok := bindID(c, "Invalid tag ID")
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
+		respondError(c, err, "Tag not found", "Failed to delete tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag deleted successfully",
+		"id":      id,
+	})
+}
+
+// parseTags разбирает ?tags=Go,react: нижний регистр, без пустых и повторов,
+// отсортированы, чтобы одинаковые фильтры давали один ключ кэша
+func parseTags(raw string) []string {
+	var slugs []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part != "" && !slices.Contains(slugs, part) {
+			slugs = append(slugs, part)
+		}
+	}
+	slices.Sort(slugs)
+	return slugs
+}
+
+// setTags заменяет теги проекта списком slug
+func setTags(c *gin.Context, repo repository.Tags, messages entityMessages) {
+	id, ok := bindID(c, messages.invalid)
+	if !ok {
+		return
+	}
+
+	var req models.SetProjectTagsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	tags, err := repo.SetTags(auditContext(c), id, parseTags(strings.Join(req.Tags, ",")))
+	if err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tags":  tags,
+		"count": len(tags),
+	})
+}
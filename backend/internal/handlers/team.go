@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ASMO-site-backend/internal/auth"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindTeamMember читает :id и :staff_id из URI; при ошибке ответ уже отправлен
+func bindTeamMember(c *gin.Context, invalidMessage string) (models.TeamMemberRequest, bool) {
+	var req models.TeamMemberRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": invalidMessage,
+		})
+		return req, false
+	}
+
+	return req, validateRequest(c, req)
+}
+
+func assignTeamMember(c *gin.Context, repo repository.Team, messages entityMessages) {
+	req, ok := bindTeamMember(c, messages.invalid)
+	if !ok {
+		return
+	}
+
+	var body models.AssignTeamMemberRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+
+	member, err := repo.AssignTeamMember(auditContext(c), req.ID, req.StaffID, body.Role)
+	if err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+func unassignTeamMember(c *gin.Context, repo repository.Team, messages entityMessages) {
+	req, ok := bindTeamMember(c, messages.invalid)
+	if !ok {
+		return
+	}
+
+	if err := repo.UnassignTeamMember(auditContext(c), req.ID, req.StaffID); err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Team member removed successfully",
+	})
+}
+
+// visibleTeam скрывает от публичных клиентов неопубликованных сотрудников
+func visibleTeam(c *gin.Context, team []models.TeamMember) []models.TeamMember {
+	visible := team[:0:0]
+	for _, m := range team {
+		if isVisible(c, auth.PermissionManageStaff, m.Status) {
+			visible = append(visible, m)
+		}
+	}
+	return visible
+}
+
+// visibleProjects скрывает от публичных клиентов неопубликованные проекты сотрудника
+func visibleProjects(c *gin.Context, projects []models.StaffProject) []models.StaffProject {
+	visible := projects[:0:0]
+	for _, p := range projects {
+		if isVisible(c, auth.PermissionManageProjects, p.Status) {
+			visible = append(visible, p)
+		}
+	}
+	return visible
+}
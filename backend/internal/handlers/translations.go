@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"slices"
+
+	"ASMO-site-backend/internal/middleware"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+var missingTranslationsListParams = []string{"page", "per_page", "type", "locale"}
+
+// bindTranslation читает :id и :locale; локаль должна быть поддерживаемой и не основной
+func bindTranslation(c *gin.Context, invalidMessage string) (models.TranslationRequest, bool) {
+	var req models.TranslationRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": invalidMessage,
+		})
+		return req, false
+	}
+
+	if !validateRequest(c, req) {
+		return req, false
+	}
+
+	if allowed := middleware.TranslatableLocales(c); !slices.Contains(allowed, req.Locale) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Unsupported locale",
+			"allowed": allowed,
+		})
+		return req, false
+	}
+
+	return req, true
+}
+
+func listTranslations(c *gin.Context, repo repository.Translations, messages entityMessages) {
+	id, ok := bindID(c, messages.invalid)
+	if !ok {
+		return
+	}
+
+	translations, err := repo.Translations(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  translations,
+		"count": len(translations),
+	})
+}
+
+func setTranslation(c *gin.Context, repo repository.Translations, messages entityMessages) {
+	req, ok := bindTranslation(c, messages.invalid)
+	if !ok {
+		return
+	}
+
+	var body models.SetTranslationRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+
+	translation, err := repo.SetTranslation(auditContext(c), req.ID, req.Locale, repository.Fields{
+		"name":        body.Name,
+		"description": body.Description,
+	})
+	if err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+func deleteTranslation(c *gin.Context, repo repository.Translations, messages entityMessages) {
+	req, ok := bindTranslation(c, messages.invalid)
+	if !ok {
+		return
+	}
+
+	if err := repo.DeleteTranslation(auditContext(c), req.ID, req.Locale); err != nil {
+		respondError(c, err, messages.notFound, messages.failed)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Translation deleted successfully",
+	})
+}
+
+// TranslationsHandler отчет о записях без перевода
+type TranslationsHandler struct {
+	repo *repository.TranslationsRepository
+}
+
+func NewTranslationsHandler(db *sql.DB) *TranslationsHandler {
+	return &TranslationsHandler{
+		repo: repository.NewTranslationsRepository(db),
+	}
+}
+
+// GetMissingTranslations без ?locale= отчет строится по всем локалям, кроме основной
+func (h *TranslationsHandler) GetMissingTranslations(c *gin.Context) {
+	var query models.ListMissingTranslationsQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	locales := middleware.TranslatableLocales(c)
+	if query.Locale != "" {
+		if !slices.Contains(locales, query.Locale) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Unsupported locale",
+				"allowed": locales,
+			})
+			return
+		}
+		locales = []string{query.Locale}
+	}
+
+	filter, ok := typeFilter(c, query.Type)
+	if !ok {
+		return
+	}
+	list := repository.ListQuery{Page: query.Page, PerPage: query.PerPage, Filters: []repository.Filter{filter}}
+
+	page, err := h.repo.Missing(c.Request.Context(), locales, list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch missing translations",
+		})
+		return
+	}
+
+	response := listResponse(c, "items", missingTranslationsListParams, page, false)
+	delete(response, "cached")
+	c.JSON(http.StatusOK, response)
+}
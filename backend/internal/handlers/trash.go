@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"slices"
+
+	"ASMO-site-backend/internal/auth"
+	"ASMO-site-backend/internal/middleware"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+var trashListParams = []string{"page", "per_page", "type"}
+
+// contentTypes метки type в сводных списках админки и права, без которых эти записи не видны
+var contentTypes = []struct {
+	Type       string
+	Permission string
+}{
+	{"web", auth.PermissionManageProjects},
+	{"mobile", auth.PermissionManageProjects},
+	{"bot", auth.PermissionManageProjects},
+	{"staff", auth.PermissionManageStaff},
+}
+
+// typeFilter ограничивает сводный список типами, которыми клиент может управлять.
+// Запрошенный недоступный type - 403; при false ответ уже отправлен
+func typeFilter(c *gin.Context, requested string) (repository.Filter, bool) {
+	permissions := c.GetStringSlice(middleware.ContextPermissions)
+	allowed := []string{}
+	for _, t := range contentTypes {
+		if slices.Contains(permissions, t.Permission) {
+			allowed = append(allowed, t.Type)
+		}
+	}
+
+	if requested == "" {
+		return repository.Filter{Name: "type", Condition: "type = ANY($?)", Value: pq.Array(allowed)}, true
+	}
+	if !slices.Contains(allowed, requested) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Insufficient permissions",
+		})
+		return repository.Filter{}, false
+	}
+	return repository.Filter{Name: "type", Condition: "type = $?", Value: requested}, true
+}
+
+// TrashHandler корзина удаленных проектов и сотрудников
+type TrashHandler struct {
+	repo *repository.TrashRepository
+}
+
+func NewTrashHandler(db *sql.DB) *TrashHandler {
+	return &TrashHandler{
+		repo: repository.NewTrashRepository(db),
+	}
+}
+
+func (h *TrashHandler) GetTrash(c *gin.Context) {
+	var query models.ListTrashQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	filter, ok := typeFilter(c, query.Type)
+	if !ok {
+		return
+	}
+	list := repository.ListQuery{Page: query.Page, PerPage: query.PerPage, Filters: []repository.Filter{filter}}
+
+	page, err := h.repo.List(c.Request.Context(), list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch trash",
+		})
+		return
+	}
+
+	response := listResponse(c, "items", trashListParams, page, false)
+	delete(response, "cached")
+	c.JSON(http.StatusOK, response)
+}
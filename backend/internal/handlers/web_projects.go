@@ -3,188 +3,317 @@ package handlers
 import (
 	"database/sql"
 	"net/http"
-	"strconv"
-	"time"
 
+	"ASMO-site-backend/internal/auth"
 	"ASMO-site-backend/internal/cache"
-	"ASMO-site-backend/internal/metrics"
 	"ASMO-site-backend/internal/models"
-	"ASMO-site-backend/internal/validation"
+	"ASMO-site-backend/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
 type WebProjectsHandler struct {
-	db    *sql.DB
-	cache cache.Cache
+	repo repository.Projects[models.WebProjects]
 }
 
 func NewWebProjectsHandler(db *sql.DB, cache cache.Cache) *WebProjectsHandler {
+	return NewWebProjectsHandlerWithRepository(repository.NewWebProjectsRepository(db, cache))
+}
+
+func NewWebProjectsHandlerWithRepository(repo repository.Projects[models.WebProjects]) *WebProjectsHandler {
 	return &WebProjectsHandler{
-		db:    db,
-		cache: cache,
+		repo: repo,
 	}
 }
 
 func (h *WebProjectsHandler) GetWebProjects(c *gin.Context) {
-	start := time.Now()
-	cacheKey := "web_projects:all"
-
-	// Пробуем получить из кэша
-	var projects []models.WebProjects
-	if err := h.cache.Get(cacheKey, &projects); err == nil {
-		metrics.RecordDatabaseQuery("cache_hit", "web_projects", time.Since(start))
-
-		c.JSON(http.StatusOK, gin.H{
-			"projects": projects,
-			"count":    len(projects),
-			"cached":   true,
-		})
+	var query models.ListProjectsQuery
+	if !bindQuery(c, &query) {
 		return
 	}
 
-	// Если нет в кэше, получаем из БД
-	rows, err := h.db.Query(`
-		SELECT id, name, description, img, price, time_develop, created_at, update_at
-		FROM web_projects
-		ORDER BY created_at DESC
-	`)
-
-	metrics.RecordDatabaseQuery("select", "web_projects", time.Since(start))
-
+	page, cached, err := h.repo.List(localeContext(c), visibleOnly(c, auth.PermissionManageProjects, projectListQuery(query), query.Status))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch web projects",
 		})
 		return
 	}
-	defer rows.Close()
 
-	projects = []models.WebProjects{}
-	for rows.Next() {
-		var project models.WebProjects
-		err := rows.Scan(
-			&project.ID, &project.Name, &project.Description, &project.Img,
-			&project.Price, &project.TimeDevelop, &project.CreatedAt, &project.UpdateAt,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to process web projects",
-			})
-			return
-		}
-		projects = append(projects, project)
+	c.JSON(http.StatusOK, listResponse(c, "projects", projectListParams, page, cached))
+}
+
+func (h *WebProjectsHandler) GetWebProject(c *gin.Context) {
+	id, ok := bindKey(c, h.repo, "Invalid project ID", "Web project not found", "Failed to fetch web project")
+	if !ok {
+		return
 	}
 
-	if len(projects) == 0 {
-		projects = []models.WebProjects{}
+	project, _, err := h.repo.Get(localeContext(c), id)
+	if err != nil {
+		respondError(c, err, "Web project not found", "Failed to fetch web project")
+		return
 	}
 
-	// Сохраняем в кэш на 5 минут
-	h.cache.Set(cacheKey, projects, 5*time.Minute)
+	// Неопубликованные записи для публичных клиентов не существуют
+	if !isVisible(c, auth.PermissionManageProjects, project.Status) {
+		respondError(c, repository.ErrNotFound, "Web project not found", "Failed to fetch web project")
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"projects": projects,
-		"count":    len(projects),
-		"cached":   false,
-	})
+	project.Gallery, err = h.repo.Gallery(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Web project not found", "Failed to fetch web project")
+		return
+	}
+	project.Tags, err = h.repo.Tags(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Web project not found", "Failed to fetch web project")
+		return
+	}
+	team, err := h.repo.Team(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Web project not found", "Failed to fetch web project")
+		return
+	}
+	project.Team = visibleTeam(c, team)
+
+	c.JSON(http.StatusOK, project)
 }
 
-func (h *WebProjectsHandler) GetWebProject(c *gin.Context) {
-	start := time.Now()
-	var req models.GetProjectRequest
-	if err := c.ShouldBindUri(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid project ID",
-		})
+func (h *WebProjectsHandler) CreateWebProject(c *gin.Context) {
+	var req models.CreateWebProjectRequest
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	if errs := validation.ValidateStruct(req); errs != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Validation failed",
-			"details": errs,
-		})
+	project, err := h.repo.Create(auditContext(c), webProjectFields(req))
+	if err != nil {
+		respondError(c, err, "Web project not found", "Failed to create web project")
 		return
 	}
 
-	cacheKey := "web_project:" + strconv.Itoa(req.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Web project created successfully",
+		"id":      project.ID,
+	})
+}
 
-	// Пробуем получить из кэша
-	var project models.WebProjects
-	if err := h.cache.Get(cacheKey, &project); err == nil {
-		metrics.RecordDatabaseQuery("cache_hit", "web_projects", time.Since(start))
-		c.JSON(http.StatusOK, project)
+func (h *WebProjectsHandler) UpdateWebProject(c *gin.Context) {
+	id, ok := bindID(c, "Invalid project ID")
+	if !ok {
 		return
 	}
 
-	err := h.db.QueryRow(`
-		SELECT id, name, description, img, price, time_develop, created_at, update_at
-		FROM web_projects WHERE id = $1
-	`, req.ID).Scan(
-		&project.ID, &project.Name, &project.Description, &project.Img,
-		&project.Price, &project.TimeDevelop, &project.CreatedAt, &project.UpdateAt,
-	)
+	var req models.CreateWebProjectRequest
+	if !bindJSON(c, &req) {
+		return
+	}
 
-	metrics.RecordDatabaseQuery("select", "web_projects", time.Since(start))
+	project, err := h.repo.Update(auditContext(c), id, webProjectFields(req))
+	if err != nil {
+		respondError(c, err, "Web project not found", "Failed to update web project")
+		return
+	}
 
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Web project not found",
-		})
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *WebProjectsHandler) PatchWebProject(c *gin.Context) {
+	id, ok := bindID(c, "Invalid project ID")
+	if !ok {
 		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch web project",
+	}
+
+	var req models.PatchWebProjectRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	// Обновляем только переданные поля
+	fields := repository.Fields{}
+	repository.Optional(fields, "name", req.Name)
+	repository.Optional(fields, "description", req.Description)
+	repository.Optional(fields, "img", req.Img)
+	repository.Optional(fields, "media_id", req.MediaID)
+	repository.Optional(fields, "price", req.Price)
+	repository.Optional(fields, "time_develop", req.TimeDevelop)
+	repository.Optional(fields, "live_url", req.LiveURL)
+	repository.Optional(fields, "stack", req.Stack)
+
+	if len(fields) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "No fields to update",
 		})
 		return
 	}
 
-	// Сохраняем в кэш на 10 минут
-	h.cache.Set(cacheKey, project, 10*time.Minute)
+	project, err := h.repo.Update(auditContext(c), id, fields)
+	if err != nil {
+		respondError(c, err, "Web project not found", "Failed to update web project")
+		return
+	}
 
 	c.JSON(http.StatusOK, project)
 }
 
-func (h *WebProjectsHandler) CreateWebProject(c *gin.Context) {
-	start := time.Now()
-	var req models.CreateWebProjectRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-		})
+func (h *WebProjectsHandler) DeleteWebProject(c *gin.Context) {
+	id, ok := bindID(c, "Invalid project ID")
+	if !ok {
 		return
 	}
 
-	if errs := validation.ValidateStruct(req); errs != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Validation failed",
-			"details": errs,
-		})
+	if err := h.repo.Delete(auditContext(c), id); err != nil {
+		respondError(c, err, "Web project not found", "Failed to delete web project")
 		return
 	}
 
-	var id int
-	err := h.db.QueryRow(`
-		INSERT INTO web_projects (name, description, img, price, time_develop, created_at, update_at)
-		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-		RETURNING id
-	`, req.Name, req.Description, req.Img, req.Price, req.TimeDevelop).Scan(&id)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Web project deleted successfully",
+		"id":      id,
+	})
+}
 
-	metrics.RecordDatabaseQuery("insert", "web_projects", time.Since(start))
+// RestoreWebProject возвращает запись из корзины
+func (h *WebProjectsHandler) RestoreWebProject(c *gin.Context) {
+	id, ok := bindID(c, "Invalid project ID")
+	if !ok {
+		return
+	}
 
+	project, err := h.repo.Restore(auditContext(c), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to create web project",
-		})
+		respondError(c, err, "Web project not found in trash", "Failed to restore web project")
 		return
 	}
 
-	// Инвалидируем кэш при создании нового проекта
-	h.cache.Delete("web_projects:all")
+	c.JSON(http.StatusOK, project)
+}
+
+// TransitionWebProject обработчик перехода статуса (submit, publish, reject, archive, reopen)
+func (h *WebProjectsHandler) TransitionWebProject(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		transition(c, h.repo, action, "Invalid project ID", "Web project not found", "Failed to change web project status")
+	}
+}
+
+// ScheduleWebProject назначает время автоматической публикации и снятия с публикации
+func (h *WebProjectsHandler) ScheduleWebProject(c *gin.Context) {
+	schedule(c, h.repo, "Invalid project ID", "Web project not found", "Failed to schedule web project")
+}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "Web project created successfully",
-		"id":      id,
+var webProjectRevisionMessages = entityMessages{
+	invalid:  "Invalid revision",
+	notFound: "Web project revision not found",
+	failed:   "Failed to fetch web project revisions",
+}
+
+// GetWebProjectRevisions история изменений записи
+func (h *WebProjectsHandler) GetWebProjectRevisions(c *gin.Context) {
+	listRevisions(c, h.repo, webProjectRevisionMessages)
+}
+
+// GetWebProjectRevision снимок версии и отличия от текущей записи
+func (h *WebProjectsHandler) GetWebProjectRevision(c *gin.Context) {
+	getRevision(c, h.repo, webProjectRevisionMessages)
+}
+
+// RestoreWebProjectRevision откатывает запись к выбранной версии
+func (h *WebProjectsHandler) RestoreWebProjectRevision(c *gin.Context) {
+	restoreRevision(c, h.repo, entityMessages{
+		invalid:  "Invalid revision",
+		notFound: "Web project revision not found",
+		failed:   "Failed to restore web project revision",
+	})
+}
+
+// GetWebProjectTranslations все переводы записи
+func (h *WebProjectsHandler) GetWebProjectTranslations(c *gin.Context) {
+	listTranslations(c, h.repo, entityMessages{
+		invalid:  "Invalid translation request",
+		notFound: "Web project not found",
+		failed:   "Failed to fetch web project translations",
+	})
+}
+
+// SetWebProjectTranslation создает или заменяет перевод на :locale
+func (h *WebProjectsHandler) SetWebProjectTranslation(c *gin.Context) {
+	setTranslation(c, h.repo, entityMessages{
+		invalid:  "Invalid translation request",
+		notFound: "Web project not found",
+		failed:   "Failed to update web project translation",
+	})
+}
+
+func (h *WebProjectsHandler) DeleteWebProjectTranslation(c *gin.Context) {
+	deleteTranslation(c, h.repo, entityMessages{
+		invalid:  "Invalid translation request",
+		notFound: "Web project translation not found",
+		failed:   "Failed to delete web project translation",
+	})
+}
+
+// AddWebProjectMedia добавляет картинку из медиатеки или видео в конец галереи
+func (h *WebProjectsHandler) AddWebProjectMedia(c *gin.Context) {
+	addGalleryItem(c, h.repo, entityMessages{
+		invalid:  "Invalid project ID",
+		notFound: "Web project not found",
+		failed:   "Failed to add web project media",
+	})
+}
+
+func (h *WebProjectsHandler) RemoveWebProjectMedia(c *gin.Context) {
+	removeGalleryItem(c, h.repo, entityMessages{
+		invalid:  "Invalid gallery item request",
+		notFound: "Gallery item not found",
+		failed:   "Failed to delete web project media",
+	})
+}
+
+// ReorderWebProjectMedia принимает id всех элементов галереи в новом порядке
+func (h *WebProjectsHandler) ReorderWebProjectMedia(c *gin.Context) {
+	reorderGallery(c, h.repo, entityMessages{
+		invalid:  "Invalid project ID",
+		notFound: "Web project not found",
+		failed:   "Failed to reorder web project media",
+	})
+}
+
+// SetWebProjectTags заменяет теги проекта: {"tags": ["go", "react"]}
+func (h *WebProjectsHandler) SetWebProjectTags(c *gin.Context) {
+	setTags(c, h.repo, entityMessages{
+		invalid:  "Invalid project ID",
+		notFound: "Web project not found",
+		failed:   "Failed to update web project tags",
+	})
+}
+
+// AssignWebProjectMember добавляет сотрудника в команду или меняет его роль: {"role": "backend"}
+func (h *WebProjectsHandler) AssignWebProjectMember(c *gin.Context) {
+	assignTeamMember(c, h.repo, entityMessages{
+		invalid:  "Invalid team member request",
+		notFound: "Web project not found",
+		failed:   "Failed to assign web project team member",
 	})
-}
\ No newline at end of file
+}
+
+func (h *WebProjectsHandler) UnassignWebProjectMember(c *gin.Context) {
+	unassignTeamMember(c, h.repo, entityMessages{
+		invalid:  "Invalid team member request",
+		notFound: "Team member not found",
+		failed:   "Failed to remove web project team member",
+	})
+}
+
+func webProjectFields(req models.CreateWebProjectRequest) repository.Fields {
+	return repository.Fields{
+		"name":         req.Name,
+		"description":  req.Description,
+		"img":          req.Img,
+		"media_id":     req.MediaID,
+		"price":        req.Price,
+		"time_develop": req.TimeDevelop,
+		"live_url":     req.LiveURL,
+		"stack":        req.Stack,
+	}
+}
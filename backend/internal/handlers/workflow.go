@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"ASMO-site-backend/internal/middleware"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// workflowAction переход статуса: целевой статус и статусы, из которых он допустим
+type workflowAction struct {
+	to   string
+	from []string
+}
+
+// workflowActions draft -> in_review -> published -> archived; reject и reopen возвращают в черновик
+var workflowActions = map[string]workflowAction{
+	"submit":  {to: models.StatusInReview, from: []string{models.StatusDraft}},
+	"publish": {to: models.StatusPublished, from: []string{models.StatusInReview}},
+	"reject":  {to: models.StatusDraft, from: []string{models.StatusInReview}},
+	"archive": {to: models.StatusArchived, from: []string{models.StatusPublished}},
+	"reopen":  {to: models.StatusDraft, from: []string{models.StatusArchived}},
+}
+
+// canSeeUnpublished черновики и архив видят только администраторы и API-ключи с правом permission
+// на управление этим контентом (права загружает RequirePermissionForWrites)
+func canSeeUnpublished(c *gin.Context, permission string) bool {
+	return slices.Contains(c.GetStringSlice(middleware.ContextPermissions), permission)
+}
+
+func isVisible(c *gin.Context, permission, status string) bool {
+	return status == models.StatusPublished || canSeeUnpublished(c, permission)
+}
+
+// visibleOnly публичный список всегда только published, администратор с правом permission может отфильтровать любой статус
+func visibleOnly(c *gin.Context, permission string, list repository.ListQuery, status string) repository.ListQuery {
+	if !canSeeUnpublished(c, permission) {
+		status = models.StatusPublished
+	}
+	if status != "" {
+		list.Filters = append(list.Filters, repository.Filter{Name: "status", Condition: "status = $?", Value: status})
+	}
+	return list
+}
+
+// transition выполняет переход статуса; при публикации записывает, кто ее одобрил
+func transition[T any](c *gin.Context, repo repository.Workflow[T], action, invalidMessage, notFoundMessage, failedMessage string) {
+	id, ok := bindID(c, invalidMessage)
+	if !ok {
+		return
+	}
+
+	step := workflowActions[action]
+	fields := repository.Fields{}
+	if step.to == models.StatusPublished {
+		var approver interface{}
+		if userID := c.GetInt(middleware.ContextUserID); userID != 0 {
+			approver = userID
+		}
+		fields["approved_by"] = approver
+		fields["approved_at"] = time.Now()
+	}
+
+	item, err := repo.Transition(auditContext(c), id, step.to, step.from, fields)
+	if errors.Is(err, repository.ErrInvalidTransition) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Status transition not allowed",
+			"allowed": step.from,
+		})
+		return
+	} else if err != nil {
+		respondError(c, err, notFoundMessage, failedMessage)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// schedule задает publish_at/unpublish_at; назначивший публикацию считается одобрившим ее
+func schedule[T any](c *gin.Context, repo repository.CRUD[T], invalidMessage, notFoundMessage, failedMessage string) {
+	id, ok := bindID(c, invalidMessage)
+	if !ok {
+		return
+	}
+
+	var req models.ScheduleRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if req.PublishAt != nil && req.UnpublishAt != nil && !req.UnpublishAt.After(*req.PublishAt) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "unpublish_at must be after publish_at",
+		})
+		return
+	}
+
+	fields := repository.Fields{
+		"publish_at":   req.PublishAt,
+		"unpublish_at": req.UnpublishAt,
+	}
+	if req.PublishAt != nil {
+		var approver interface{}
+		if userID := c.GetInt(middleware.ContextUserID); userID != 0 {
+			approver = userID
+		}
+		fields["approved_by"] = approver
+		fields["approved_at"] = time.Now()
+	}
+
+	item, err := repo.Update(auditContext(c), id, fields)
+	if err != nil {
+		respondError(c, err, notFoundMessage, failedMessage)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
@@ -0,0 +1,97 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// Декодеры регистрируются для image.Decode
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Variant размер уменьшенной копии; высота считается по пропорциям оригинала
+type Variant struct {
+	Name  string
+	Width int
+}
+
+// Variants копии для карточек и страниц проекта, от меньшей к большей
+var Variants = []Variant{
+	{Name: "thumbnail", Width: 320},
+	{Name: "card", Width: 640},
+	{Name: "hero", Width: 1280},
+}
+
+// MaxPixels защита от картинок, которые при декодировании займут сотни мегабайт памяти
+const MaxPixels = 40_000_000
+
+// jpegQuality компромисс между размером и артефактами на скриншотах
+const jpegQuality = 82
+
+var (
+	ErrInvalidImage = errors.New("invalid image")
+	ErrTooLarge     = errors.New("image dimensions too large")
+)
+
+// Resized копия картинки в двух форматах: WebP для браузеров, которые его поддерживают, и JPEG для остальных.
+// WebP без потерь (чистый Go без cgo умеет только так) и с прозрачностью, JPEG - на белом фоне
+type Resized struct {
+	Variant
+	Height int
+	JPEG   []byte
+	WebP   []byte
+}
+
+// Generate декодирует картинку и строит копии из Variants. Копии не шире оригинала не строятся:
+// для этих размеров подходит сам оригинал. Возвращает размеры оригинала
+func Generate(data []byte) (width, height int, resized []Resized, err error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, nil, ErrInvalidImage
+	}
+	if config.Width*config.Height > MaxPixels {
+		return 0, 0, nil, ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, nil, ErrInvalidImage
+	}
+	bounds := src.Bounds()
+	width, height = bounds.Dx(), bounds.Dy()
+
+	for _, variant := range Variants {
+		if variant.Width >= width {
+			break
+		}
+
+		h := max(1, height*variant.Width/width)
+		scaled := image.NewNRGBA(image.Rect(0, 0, variant.Width, h))
+		draw.CatmullRom.Scale(scaled, scaled.Bounds(), src, bounds, draw.Src, nil)
+
+		var webp bytes.Buffer
+		if err := nativewebp.Encode(&webp, scaled, nil); err != nil {
+			return 0, 0, nil, err
+		}
+
+		// JPEG без альфа-канала: прозрачные PNG/WebP кладем на белый фон, а не на черный
+		flat := image.NewRGBA(scaled.Bounds())
+		draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.Draw(flat, flat.Bounds(), scaled, image.Point{}, draw.Over)
+
+		var jpg bytes.Buffer
+		if err := jpeg.Encode(&jpg, flat, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return 0, 0, nil, err
+		}
+		resized = append(resized, Resized{Variant: variant, Height: h, JPEG: jpg.Bytes(), WebP: webp.Bytes()})
+	}
+
+	return width, height, resized, nil
+}
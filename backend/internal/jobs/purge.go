@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"ASMO-site-backend/pkg/logger"
+)
+
+// Purger таблица с корзиной (repository.PostgresRepository с SoftDelete)
+type Purger interface {
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+// TrashPurger периодически окончательно удаляет записи, пролежавшие в корзине дольше retention
+type TrashPurger struct {
+	purgers   map[string]Purger
+	retention time.Duration
+	interval  time.Duration
+	logger    *logger.Logger
+}
+
+func NewTrashPurger(purgers map[string]Purger, retention, interval time.Duration, logger *logger.Logger) *TrashPurger {
+	return &TrashPurger{
+		purgers:   purgers,
+		retention: retention,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+// Run блокирует до отмены ctx; первый проход выполняется сразу при старте
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.PurgeOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *TrashPurger) PurgeOnce(ctx context.Context) {
+	deletedBefore := time.Now().Add(-p.retention)
+	for name, purger := range p.purgers {
+		purged, err := purger.Purge(ctx, deletedBefore)
+		if err != nil {
+			p.logger.Error("Failed to purge trash", map[string]interface{}{
+				"table": name,
+				"error": err.Error(),
+			})
+			continue
+		}
+		if purged > 0 {
+			p.logger.Info("Trash purged", map[string]interface{}{
+				"table":  name,
+				"purged": purged,
+			})
+		}
+	}
+}
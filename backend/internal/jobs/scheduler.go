@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/repository"
+	"ASMO-site-backend/pkg/logger"
+)
+
+// schedulerLockKey блокировка лидера; кто ее взял, тот и обрабатывает текущий тик
+const schedulerLockKey = "scheduler:publish:lock"
+
+// Scheduled таблица с publish_at/unpublish_at (repository.PostgresRepository со Scheduled)
+type Scheduled interface {
+	ApplySchedule(ctx context.Context, now time.Time) (int, error)
+}
+
+// PublishScheduler публикует и снимает с публикации проекты по расписанию.
+// Запускается в каждой реплике, но работу за тик выполняет только та, что получила Redis-блокировку
+type PublishScheduler struct {
+	tables     map[string]Scheduled
+	lock       cache.Cache
+	interval   time.Duration
+	instanceID string
+	logger     *logger.Logger
+}
+
+func NewPublishScheduler(tables map[string]Scheduled, lock cache.Cache, interval time.Duration, logger *logger.Logger) *PublishScheduler {
+	hostname, _ := os.Hostname()
+	return &PublishScheduler{
+		tables:     tables,
+		lock:       lock,
+		interval:   interval,
+		instanceID: fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+		logger:     logger,
+	}
+}
+
+// Run блокирует до отмены ctx
+func (s *PublishScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.RunOnce(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce возвращает false, если тик уже обрабатывает другая реплика
+func (s *PublishScheduler) RunOnce(ctx context.Context, now time.Time) bool {
+	// TTL чуть меньше интервала: блокировка гарантированно освободится к следующему тику,
+	// а явно ее не снимаем, чтобы отставшая реплика не обработала тот же тик повторно
+	acquired, err := s.lock.SetNX(schedulerLockKey, s.instanceID, s.interval*9/10)
+	if err != nil {
+		s.logger.Error("Failed to acquire scheduler lock", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return false
+	}
+	if !acquired {
+		return false
+	}
+
+	ctx = repository.WithActor(ctx, repository.Actor{Name: "scheduler"})
+	for name, table := range s.tables {
+		changed, err := table.ApplySchedule(ctx, now)
+		if err != nil {
+			s.logger.Error("Failed to apply publication schedule", map[string]interface{}{
+				"table": name,
+				"error": err.Error(),
+			})
+			continue
+		}
+		if changed > 0 {
+			s.logger.Info("Publication schedule applied", map[string]interface{}{
+				"table":   name,
+				"changed": changed,
+			})
+		}
+	}
+	return true
+}
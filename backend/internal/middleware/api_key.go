@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"ASMO-site-backend/internal/auth"
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Ключи контекста для запросов, аутентифицированных API-ключом
+const (
+	ContextAPIKeyID     = "apiKeyID"
+	ContextAPIKeyScopes = "apiKeyScopes"
+)
+
+// APIKeyHeader заголовок, в котором машинные клиенты передают ключ
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyStore источник API-ключей (хранятся в Postgres)
+type APIKeyStore interface {
+	FindActiveByHash(ctx context.Context, hash string) (models.APIKey, error)
+	TouchLastUsed(ctx context.Context, id int) error
+}
+
+// APIKeyAuth проверяет X-API-Key, если он передан, и ограничивает частоту запросов по ключу.
+// Запросы без заголовка пропускаются дальше к обычной JWT-аутентификации
+func APIKeyAuth(store APIKeyStore, limiter cache.Cache) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := c.GetHeader(APIKeyHeader)
+		if rawKey == "" {
+			c.Next()
+			return
+		}
+
+		key, err := store.FindActiveByHash(c.Request.Context(), auth.HashToken(rawKey))
+		if errors.Is(err, repository.ErrNotFound) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid or revoked API key",
+			})
+			return
+		} else if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to check API key",
+			})
+			return
+		}
+
+		// Фиксированное окно в минуту; при недоступном Redis лимит не применяется
+		window := time.Now().Unix() / 60
+		count, err := limiter.Increment("api_key_rate:"+strconv.Itoa(key.ID)+":"+strconv.FormatInt(window, 10), time.Minute)
+		if err == nil && count > int64(key.RateLimit) {
+			c.Header("Retry-After", strconv.FormatInt(60-time.Now().Unix()%60, 10))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":   "Too many requests",
+				"message": "API key rate limit exceeded",
+			})
+			return
+		}
+
+		store.TouchLastUsed(c.Request.Context(), key.ID)
+
+		c.Set(ContextAPIKeyID, key.ID)
+		c.Set(ContextAPIKeyScopes, key.Scopes)
+		c.Next()
+	}
+}
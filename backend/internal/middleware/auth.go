@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"ASMO-site-backend/internal/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Ключи контекста с данными аутентифицированного администратора
+const (
+	ContextUserID   = "adminUserID"
+	ContextUsername = "adminUsername"
+)
+
+// RequireAuth требует действующий access-токен для любого метода
+func RequireAuth(tokens *auth.TokenManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authenticate(c, tokens)
+	}
+}
+
+// RequireAuthForWrites оставляет чтение публичным и требует токен только для изменяющих методов.
+// Если токен передан и на чтение, он проверяется, чтобы администратор видел неопубликованные записи
+func RequireAuthForWrites(tokens *auth.TokenManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if isReadOnly(c.Request.Method) && c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		authenticate(c, tokens)
+	}
+}
+
+func isReadOnly(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func authenticate(c *gin.Context, tokens *auth.TokenManager) {
+	// Клиент уже прошел проверку в APIKeyAuth
+	if c.GetInt(ContextAPIKeyID) != 0 {
+		c.Next()
+		return
+	}
+
+	header := c.GetHeader("Authorization")
+	tokenString, found := strings.CutPrefix(header, "Bearer ")
+	if !found || tokenString == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+		})
+		return
+	}
+
+	claims, err := tokens.ParseAccessToken(tokenString)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid or expired token",
+		})
+		return
+	}
+
+	c.Set(ContextUserID, claims.UserID())
+	c.Set(ContextUsername, claims.Username)
+	c.Next()
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Ключи контекста gin с выбранной локалью и настройками локалей
+const (
+	ContextLocale        = "locale"
+	contextDefaultLocale = "defaultLocale"
+	contextLocales       = "locales"
+)
+
+// Locale выбирает язык ответа: ?lang=, затем Accept-Language, иначе локаль по умолчанию.
+// Неподдерживаемые значения молча пропускаются
+func Locale(supported []string, defaultLocale string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := defaultLocale
+		if lang := strings.ToLower(c.Query("lang")); slices.Contains(supported, lang) {
+			locale = lang
+		} else if accepted := acceptedLocale(c.GetHeader("Accept-Language"), supported); accepted != "" {
+			locale = accepted
+		}
+
+		c.Set(ContextLocale, locale)
+		c.Set(contextDefaultLocale, defaultLocale)
+		c.Set(contextLocales, supported)
+		c.Header("Content-Language", locale)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
+
+// acceptedLocale первая поддерживаемая локаль из Accept-Language с учетом q; en-US подходит под en
+func acceptedLocale(header string, supported []string) string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if tag != "" && q > 0 {
+			tags = append(tags, weighted{tag: strings.ToLower(tag), q: q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	for _, t := range tags {
+		primary, _, _ := strings.Cut(t.tag, "-")
+		if slices.Contains(supported, t.tag) {
+			return t.tag
+		}
+		if slices.Contains(supported, primary) {
+			return primary
+		}
+	}
+	return ""
+}
+
+// TranslationLocale локаль, в которую нужно перевести ответ; пусто, если это основной язык контента
+func TranslationLocale(c *gin.Context) string {
+	locale := c.GetString(ContextLocale)
+	if locale == c.GetString(contextDefaultLocale) {
+		return ""
+	}
+	return locale
+}
+
+// TranslatableLocales поддерживаемые локали, кроме основной: только для них хранятся переводы
+func TranslatableLocales(c *gin.Context) []string {
+	defaultLocale := c.GetString(contextDefaultLocale)
+	var locales []string
+	for _, locale := range c.GetStringSlice(contextLocales) {
+		if locale != defaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
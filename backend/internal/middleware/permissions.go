@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextPermissions права клиента на этом запросе: права ролей пользователя или scopes API-ключа
+const ContextPermissions = "permissions"
+
+// PermissionChecker источник прав пользователя (роли хранятся в Postgres)
+type PermissionChecker interface {
+	Permissions(ctx context.Context, userID int) ([]string, error)
+}
+
+// RequirePermission требует право для любого метода; ставится после RequireAuth
+func RequirePermission(checker PermissionChecker, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorize(c, checker, permission)
+	}
+}
+
+// RequireAnyPermission пускает клиента хотя бы с одним из прав; ставится после RequireAuth.
+// Для сводных отчетов по разным ресурсам: какие записи показать, хэндлер решает по ContextPermissions
+func RequireAnyPermission(checker PermissionChecker, permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorize(c, checker, permissions...)
+	}
+}
+
+// RequirePermissionForWrites проверяет право только для изменяющих методов; ставится после RequireAuthForWrites.
+// На чтение права аутентифицированного клиента только загружаются в ContextPermissions:
+// по ним хэндлеры решают, показывать ли неопубликованные записи
+func RequirePermissionForWrites(checker PermissionChecker, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if isReadOnly(c.Request.Method) {
+			if c.GetInt(ContextUserID) != 0 || c.GetInt(ContextAPIKeyID) != 0 {
+				if _, ok := loadPermissions(c, checker); !ok {
+					return
+				}
+			}
+			c.Next()
+			return
+		}
+		authorize(c, checker, permission)
+	}
+}
+
+// authorize пропускает запрос, если у клиента есть хотя бы одно из required
+func authorize(c *gin.Context, checker PermissionChecker, required ...string) {
+	if c.GetInt(ContextAPIKeyID) == 0 && c.GetInt(ContextUserID) == 0 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+		})
+		return
+	}
+
+	permissions, ok := loadPermissions(c, checker)
+	if !ok {
+		return
+	}
+
+	if !slices.ContainsFunc(required, func(permission string) bool { return slices.Contains(permissions, permission) }) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "Insufficient permissions",
+		})
+		return
+	}
+
+	c.Next()
+}
+
+// loadPermissions права клиента в ContextPermissions; для API-ключа права ограничены его scopes.
+// При ошибке ответ уже отправлен
+func loadPermissions(c *gin.Context, checker PermissionChecker) ([]string, bool) {
+	if c.GetInt(ContextAPIKeyID) != 0 {
+		scopes := c.GetStringSlice(ContextAPIKeyScopes)
+		c.Set(ContextPermissions, scopes)
+		return scopes, true
+	}
+
+	permissions, err := checker.Permissions(c.Request.Context(), c.GetInt(ContextUserID))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to check permissions",
+		})
+		return nil, false
+	}
+	c.Set(ContextPermissions, permissions)
+	return permissions, true
+}
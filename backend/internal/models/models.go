@@ -1,50 +1,389 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
+// Статусы публикации проектов и сотрудников; публично видны только published
+const (
+	StatusDraft     = "draft"
+	StatusInReview  = "in_review"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
 type WebProjects struct {
-	ID          int       `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name" validate:"required,min=15,max=100"`
-	Description string    `json:"description" db:"description" validate:"required,min=20,max=1500"`
-	Img         string    `json:"img" db:"img" validate:"url"`
-	Price       float64   `json:"price" db:"price" validate:"required,min=0"`
-	TimeDevelop int       `json:"time_develop" db:"time_develop" validate:"required,min=1,max=1825"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdateAt    time.Time `json:"update_at" db:"update_at"`
+	ID          int        `json:"id" db:"id"`
+	Name        string     `json:"name" db:"name" validate:"required,min=15,max=100"`
+	Slug        string     `json:"slug" db:"slug"`
+	Description string     `json:"description" db:"description" validate:"required,min=20,max=1500"`
+	Img         string     `json:"img" db:"img" validate:"url"`
+	MediaID     *int       `json:"media_id" db:"media_id"`
+	Images      *Images    `json:"images" db:"images"`
+	Price       float64    `json:"price" db:"price" validate:"required,min=0"`
+	TimeDevelop int        `json:"time_develop" db:"time_develop" validate:"required,min=1,max=1825"`
+	LiveURL     string     `json:"live_url" db:"live_url"`
+	Stack       []string   `json:"stack" db:"stack"`
+	Status      string     `json:"status" db:"status"`
+	ApprovedBy  *int       `json:"approved_by" db:"approved_by"`
+	ApprovedAt  *time.Time `json:"approved_at" db:"approved_at"`
+	PublishAt   *time.Time `json:"publish_at" db:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at" db:"unpublish_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdateAt    time.Time  `json:"update_at" db:"update_at"`
+	// Gallery, Tags и Team заполняются только в ответе GET по id
+	Gallery []ProjectMedia `json:"gallery,omitempty" db:"-"`
+	Tags    []Tag          `json:"tags,omitempty" db:"-"`
+	Team    []TeamMember   `json:"team,omitempty" db:"-"`
 }
 
 type MobileProjects struct {
-	ID          int       `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name" validate:"required,min=15,max=100"`
-	Description string    `json:"description" db:"description" validate:"required,min=20,max=1500"`
-	Img         string    `json:"img" db:"img" validate:"url"`
-	Price       float64   `json:"price" db:"price" validate:"required,min=0"`
-	TimeDevelop int       `json:"time_develop" db:"time_develop" validate:"required,min=1,max=1825"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdateAt    time.Time `json:"update_at" db:"update_at"`
+	ID            int        `json:"id" db:"id"`
+	Name          string     `json:"name" db:"name" validate:"required,min=15,max=100"`
+	Slug          string     `json:"slug" db:"slug"`
+	Description   string     `json:"description" db:"description" validate:"required,min=20,max=1500"`
+	Img           string     `json:"img" db:"img" validate:"url"`
+	MediaID       *int       `json:"media_id" db:"media_id"`
+	Images        *Images    `json:"images" db:"images"`
+	Price         float64    `json:"price" db:"price" validate:"required,min=0"`
+	TimeDevelop   int        `json:"time_develop" db:"time_develop" validate:"required,min=1,max=1825"`
+	AppStoreURL   string     `json:"app_store_url" db:"app_store_url"`
+	GooglePlayURL string     `json:"google_play_url" db:"google_play_url"`
+	Platforms     []string   `json:"platforms" db:"platforms"`
+	Status        string     `json:"status" db:"status"`
+	ApprovedBy    *int       `json:"approved_by" db:"approved_by"`
+	ApprovedAt    *time.Time `json:"approved_at" db:"approved_at"`
+	PublishAt     *time.Time `json:"publish_at" db:"publish_at"`
+	UnpublishAt   *time.Time `json:"unpublish_at" db:"unpublish_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdateAt      time.Time  `json:"update_at" db:"update_at"`
+	// Gallery, Tags и Team заполняются только в ответе GET по id
+	Gallery []ProjectMedia `json:"gallery,omitempty" db:"-"`
+	Tags    []Tag          `json:"tags,omitempty" db:"-"`
+	Team    []TeamMember   `json:"team,omitempty" db:"-"`
 }
 
 type BotsProjects struct {
-	ID          int       `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name" validate:"required,min=15,max=100"`
-	Description string    `json:"description" db:"description" validate:"required,min=20,max=1500"`
-	Img         string    `json:"img" db:"img" validate:"url"`
-	Price       float64   `json:"price" db:"price" validate:"required,min=0"`
-	TimeDevelop int       `json:"time_develop" db:"time_develop" validate:"required,min=1,max=1825"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdateAt    time.Time `json:"update_at" db:"update_at"`
+	ID          int        `json:"id" db:"id"`
+	Name        string     `json:"name" db:"name" validate:"required,min=15,max=100"`
+	Slug        string     `json:"slug" db:"slug"`
+	Description string     `json:"description" db:"description" validate:"required,min=20,max=1500"`
+	Img         string     `json:"img" db:"img" validate:"url"`
+	MediaID     *int       `json:"media_id" db:"media_id"`
+	Images      *Images    `json:"images" db:"images"`
+	Price       float64    `json:"price" db:"price" validate:"required,min=0"`
+	TimeDevelop int        `json:"time_develop" db:"time_develop" validate:"required,min=1,max=1825"`
+	Platform    string     `json:"platform" db:"platform"`
+	Handle      string     `json:"handle" db:"handle"`
+	Status      string     `json:"status" db:"status"`
+	ApprovedBy  *int       `json:"approved_by" db:"approved_by"`
+	ApprovedAt  *time.Time `json:"approved_at" db:"approved_at"`
+	PublishAt   *time.Time `json:"publish_at" db:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at" db:"unpublish_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdateAt    time.Time  `json:"update_at" db:"update_at"`
+	// Gallery, Tags и Team заполняются только в ответе GET по id
+	Gallery []ProjectMedia `json:"gallery,omitempty" db:"-"`
+	Tags    []Tag          `json:"tags,omitempty" db:"-"`
+	Team    []TeamMember   `json:"team,omitempty" db:"-"`
+}
+
+// Project элемент общего каталога: проект любого типа с меткой type (web, mobile, bot)
+type Project struct {
+	Type        string     `json:"type"`
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	Img         string     `json:"img"`
+	MediaID     *int       `json:"media_id"`
+	Images      *Images    `json:"images"`
+	Price       float64    `json:"price"`
+	TimeDevelop int        `json:"time_develop"`
+	Status      string     `json:"status"`
+	ApprovedBy  *int       `json:"approved_by"`
+	ApprovedAt  *time.Time `json:"approved_at"`
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdateAt    time.Time  `json:"update_at"`
 }
 
 type Staff struct {
-	ID          int       `json:"id" db:"id"`
-	Name        string    `json:"name" validate:"required,min=15,max=100"`
-	Description string    `json:"description" validate:"required,min=20,max=1500"`
-	Img         string    `json:"img" validate:"url"`
-	Role        string    `json:"role" validate:"required,min=1,max=500"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdateAt    time.Time `json:"update_at" db:"update_at"`
+	ID           int        `json:"id" db:"id"`
+	Name         string     `json:"name" validate:"required,min=15,max=100"`
+	Slug         string     `json:"slug" db:"slug"`
+	Description  string     `json:"description" validate:"required,min=20,max=1500"`
+	Img          string     `json:"img" validate:"url"`
+	MediaID      *int       `json:"media_id" db:"media_id"`
+	Images       *Images    `json:"images" db:"images"`
+	Role         string     `json:"role" validate:"required,min=1,max=500"`
+	Department   string     `json:"department" db:"department"`
+	Skills       []Skill    `json:"skills" db:"skills"`
+	Email        string     `json:"email" db:"email"`
+	ShowEmail    bool       `json:"show_email" db:"show_email"`
+	GitHubURL    string     `json:"github_url" db:"github_url"`
+	LinkedInURL  string     `json:"linkedin_url" db:"linkedin_url"`
+	TelegramURL  string     `json:"telegram_url" db:"telegram_url"`
+	DisplayOrder int        `json:"display_order" db:"display_order"`
+	Status       string     `json:"status" db:"status"`
+	ApprovedBy   *int       `json:"approved_by" db:"approved_by"`
+	ApprovedAt   *time.Time `json:"approved_at" db:"approved_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdateAt     time.Time  `json:"update_at" db:"update_at"`
+	// Email публичные клиенты видят только при ShowEmail, Projects заполняется только в ответе GET по id
+	Projects []StaffProject `json:"projects,omitempty" db:"-"`
+}
+
+type AdminUser struct {
+	ID           int       `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"-"`
+	Roles        []string  `json:"roles"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdateAt     time.Time `json:"update_at"`
+}
+
+type APIKey struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	RateLimit  int        `json:"rate_limit"`
+	CreatedBy  *int       `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// AuditEntry запись журнала изменений; before/after содержат только измененные поля
+type AuditEntry struct {
+	ID            int64           `json:"id"`
+	ActorUserID   *int            `json:"actor_user_id"`
+	ActorAPIKeyID *int            `json:"actor_api_key_id"`
+	ActorName     string          `json:"actor_name"`
+	Action        string          `json:"action"`
+	ResourceType  string          `json:"resource_type"`
+	ResourceID    int             `json:"resource_id"`
+	Before        json.RawMessage `json:"before"`
+	After         json.RawMessage `json:"after"`
+	IP            string          `json:"ip"`
+	RequestID     string          `json:"request_id"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// Revision сохраненная версия записи до очередного изменения; snapshot отдается только для одной ревизии
+type Revision struct {
+	Revision      int             `json:"revision"`
+	CreatedBy     *int            `json:"created_by"`
+	CreatedByName string          `json:"created_by_name"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Snapshot      json.RawMessage `json:"snapshot,omitempty"`
+}
+
+// TrashItem запись в корзине; type - web, mobile, bot или staff
+type TrashItem struct {
+	Type      string    `json:"type"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// Media загруженный файл медиатеки; записи ссылаются на него через media_id
+type Media struct {
+	ID           int       `json:"id"`
+	Key          string    `json:"key"`
+	URL          string    `json:"url"`
+	OriginalName string    `json:"original_name"`
+	MimeType     string    `json:"mime_type"`
+	Size         int64     `json:"size"`
+	Images       Images    `json:"images"`
+	VariantKeys  []string  `json:"-"`
+	SourceURL    *string   `json:"source_url"`
+	UploadedBy   *int      `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// MediaUsage запись, у которой файл медиатеки стоит картинкой
+type MediaUsage struct {
+	Type string `json:"type"`
+	ID   int    `json:"id"`
+}
+
+// ImageVariant одна из копий картинки: url в JPEG, webp - та же копия в WebP (у оригинала не заполняется)
+type ImageVariant struct {
+	URL    string `json:"url"`
+	WebP   string `json:"webp,omitempty"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// Images оригинал и уменьшенные копии; srcset готов для атрибута <img srcset>,
+// srcset_webp - для <source type="image/webp"> в <picture>.
+// Копии шире оригинала не создаются, поэтому у маленьких картинок часть полей пустая
+type Images struct {
+	Original   ImageVariant  `json:"original"`
+	Thumbnail  *ImageVariant `json:"thumbnail,omitempty"`
+	Card       *ImageVariant `json:"card,omitempty"`
+	Hero       *ImageVariant `json:"hero,omitempty"`
+	Srcset     string        `json:"srcset"`
+	SrcsetWebP string        `json:"srcset_webp,omitempty"`
+}
+
+// Виды элементов галереи проекта
+const (
+	GalleryImage = "image"
+	GalleryVideo = "video"
+)
+
+// ProjectMedia элемент галереи проекта: картинка из медиатеки или видео YouTube/Vimeo/файл
+type ProjectMedia struct {
+	ID        int       `json:"id"`
+	Kind      string    `json:"kind"`
+	URL       string    `json:"url"`
+	MediaID   *int      `json:"media_id,omitempty"`
+	Images    *Images   `json:"images,omitempty"`
+	Provider  string    `json:"provider,omitempty"`
+	EmbedURL  string    `json:"embed_url,omitempty"`
+	Caption   string    `json:"caption"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Статусы заявки
+const (
+	LeadNew       = "new"
+	LeadContacted = "contacted"
+	LeadWon       = "won"
+	LeadLost      = "lost"
+)
+
+// Lead заявка с формы сайта; project_type/project_id - проект, со страницы которого она отправлена
+type Lead struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Contact      string    `json:"contact"`
+	Message      string    `json:"message"`
+	Budget       *float64  `json:"budget"`
+	ProjectType  string    `json:"project_type"`
+	ProjectID    *int      `json:"project_id"`
+	Status       string    `json:"status"`
+	AssignedTo   *int      `json:"assigned_to"`
+	AssigneeName string    `json:"assignee_name"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdateAt     time.Time `json:"update_at"`
+}
+
+// CreateLeadRequest публичная форма заявки
+type CreateLeadRequest struct {
+	Name        string   `json:"name" validate:"required,min=2,max=100"`
+	Contact     string   `json:"contact" validate:"required,max=255,contact"`
+	Message     string   `json:"message" validate:"required,min=10,max=5000"`
+	Budget      *float64 `json:"budget" validate:"omitempty,min=0,max=9999999999"`
+	ProjectType string   `json:"project_type" validate:"required_with=ProjectID,omitempty,oneof=web mobile bot"`
+	ProjectID   *int     `json:"project_id" validate:"omitempty,min=1"`
+
+	// Антиспам: website - скрытое поле-ловушка, form_token из GET /api/forms/token, captcha_token от виджета капчи
+	Website      string `json:"website"`
+	FormToken    string `json:"form_token"`
+	CaptchaToken string `json:"captcha_token"`
+}
+
+// AssignLeadRequest ответственный за заявку; null снимает назначение
+type AssignLeadRequest struct {
+	UserID *int `json:"user_id" validate:"omitempty,min=1"`
+}
+
+type LeadStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=new contacted won lost"`
+}
+
+// Уровни владения навыком
+const (
+	SkillBeginner     = "beginner"
+	SkillIntermediate = "intermediate"
+	SkillAdvanced     = "advanced"
+	SkillExpert       = "expert"
+)
+
+// Skill навык сотрудника с уровнем владения
+type Skill struct {
+	Name  string `json:"name" validate:"required,min=1,max=50"`
+	Level string `json:"level" validate:"required,oneof=beginner intermediate advanced expert"`
+}
+
+// Виды тегов
+const (
+	TagKindTag        = "tag"
+	TagKindTechnology = "technology"
+	TagKindCategory   = "category"
+)
+
+// Tag тег проекта; slug используется в фильтре ?tags=go,react
+type Tag struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Kind      string    `json:"kind"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdateAt  time.Time `json:"update_at"`
+}
+
+// TagCount элемент облака тегов: тег и число опубликованных проектов с ним
+type TagCount struct {
+	Tag
+	Count int `json:"count"`
+}
+
+// TeamMember участник проекта: сотрудник и его роль в этом проекте
+type TeamMember struct {
+	StaffID int     `json:"staff_id"`
+	Name    string  `json:"name"`
+	Slug    string  `json:"slug"`
+	Img     string  `json:"img"`
+	Images  *Images `json:"images"`
+	// Title должность сотрудника (staff.role), Role - роль в проекте
+	Title  string `json:"title"`
+	Role   string `json:"role"`
+	Status string `json:"status"`
+}
+
+// StaffProject проект сотрудника любого типа с его ролью в проекте
+type StaffProject struct {
+	Type   string  `json:"type"`
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	Slug   string  `json:"slug"`
+	Img    string  `json:"img"`
+	Images *Images `json:"images"`
+	Role   string  `json:"role"`
+	Status string  `json:"status"`
+}
+
+// Translation перевод name/description на локаль, отличную от основной
+type Translation struct {
+	Locale      string    `json:"locale"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	UpdateAt    time.Time `json:"update_at"`
+}
+
+// MissingTranslation запись, у которой нет перевода на locale
+type MissingTranslation struct {
+	Type   string `json:"type"`
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Locale string `json:"locale"`
+}
+
+type Role struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
 }
 
 type HealthResponse struct {
@@ -56,36 +395,317 @@ type HealthResponse struct {
 }
 
 type CreateWebProjectRequest struct {
-	Name        string  `json:"name" validate:"required,min=15,max=100"`
-	Description string  `json:"description" validate:"required,min=20,max=1500"`
-	Img         string  `json:"img" validate:"url"`
-	Price       float64 `json:"price" validate:"required,min=0"`
-	TimeDevelop int     `json:"time_develop" validate:"required,min=1,max=1825"`
+	Name        string   `json:"name" validate:"required,min=15,max=100"`
+	Description string   `json:"description" validate:"required,min=20,max=1500"`
+	Img         string   `json:"img" validate:"required_without=MediaID,omitempty,url"`
+	MediaID     *int     `json:"media_id" validate:"omitempty,min=1"`
+	Price       float64  `json:"price" validate:"required,min=0"`
+	TimeDevelop int      `json:"time_develop" validate:"required,min=1,max=1825"`
+	LiveURL     string   `json:"live_url" validate:"omitempty,url,max=500"`
+	Stack       []string `json:"stack" validate:"omitempty,max=20,unique,dive,min=1,max=50"`
 }
 
 type CreateMobileProjectRequest struct {
-	Name        string  `json:"name" validate:"required,min=15,max=100"`
-	Description string  `json:"description" validate:"required,min=20,max=1500"`
-	Img         string  `json:"img" validate:"url"`
-	Price       float64 `json:"price" validate:"required,min=0"`
-	TimeDevelop int     `json:"time_develop" validate:"required,min=1,max=1825"`
+	Name          string   `json:"name" validate:"required,min=15,max=100"`
+	Description   string   `json:"description" validate:"required,min=20,max=1500"`
+	Img           string   `json:"img" validate:"required_without=MediaID,omitempty,url"`
+	MediaID       *int     `json:"media_id" validate:"omitempty,min=1"`
+	Price         float64  `json:"price" validate:"required,min=0"`
+	TimeDevelop   int      `json:"time_develop" validate:"required,min=1,max=1825"`
+	AppStoreURL   string   `json:"app_store_url" validate:"omitempty,max=500,app_store_url"`
+	GooglePlayURL string   `json:"google_play_url" validate:"omitempty,max=500,google_play_url"`
+	Platforms     []string `json:"platforms" validate:"omitempty,max=2,unique,dive,oneof=ios android"`
 }
 
 type CreateBotsProjectRequest struct {
 	Name        string  `json:"name" validate:"required,min=15,max=100"`
 	Description string  `json:"description" validate:"required,min=20,max=1500"`
-	Img         string  `json:"img" validate:"url"`
+	Img         string  `json:"img" validate:"required_without=MediaID,omitempty,url"`
+	MediaID     *int    `json:"media_id" validate:"omitempty,min=1"`
 	Price       float64 `json:"price" validate:"required,min=0"`
 	TimeDevelop int     `json:"time_develop" validate:"required,min=1,max=1825"`
+	Platform    string  `json:"platform" validate:"omitempty,oneof=telegram discord vk"`
+	Handle      string  `json:"handle" validate:"required_with=Platform,omitempty,bot_handle"`
 }
 
 type CreateStaffRequest struct {
-	Name        string `json:"name" validate:"required,min=15,max=100"`
-	Description string `json:"description" validate:"required,min=20,max=1500"`
-	Img         string `json:"img" validate:"url"`
-	Role        string `json:"role" validate:"required,min=1,max=500"`
+	Name        string  `json:"name" validate:"required,min=15,max=100"`
+	Description string  `json:"description" validate:"required,min=20,max=1500"`
+	Img         string  `json:"img" validate:"required_without=MediaID,omitempty,url"`
+	MediaID     *int    `json:"media_id" validate:"omitempty,min=1"`
+	Role        string  `json:"role" validate:"required,min=1,max=500"`
+	Department  string  `json:"department" validate:"omitempty,max=100"`
+	Skills      []Skill `json:"skills" validate:"omitempty,max=30,unique=Name,dive"`
+	Email       string  `json:"email" validate:"required_if=ShowEmail true,omitempty,max=254,email"`
+	ShowEmail   bool    `json:"show_email"`
+	GitHubURL   string  `json:"github_url" validate:"omitempty,max=500,social_url=github"`
+	LinkedInURL string  `json:"linkedin_url" validate:"omitempty,max=500,social_url=linkedin"`
+	TelegramURL string  `json:"telegram_url" validate:"omitempty,max=500,social_url=telegram"`
+	// DisplayOrder без значения ставит сотрудника в конец списка
+	DisplayOrder *int `json:"display_order" validate:"omitempty,min=0"`
+}
+
+type PatchWebProjectRequest struct {
+	Name        *string   `json:"name" validate:"omitempty,min=15,max=100"`
+	Description *string   `json:"description" validate:"omitempty,min=20,max=1500"`
+	Img         *string   `json:"img" validate:"omitempty,url"`
+	MediaID     *int      `json:"media_id" validate:"omitempty,min=1"`
+	Price       *float64  `json:"price" validate:"omitempty,min=0"`
+	TimeDevelop *int      `json:"time_develop" validate:"omitempty,min=1,max=1825"`
+	LiveURL     *string   `json:"live_url" validate:"omitempty,url,max=500"`
+	Stack       *[]string `json:"stack" validate:"omitempty,max=20,unique,dive,min=1,max=50"`
+}
+
+type PatchMobileProjectRequest struct {
+	Name          *string   `json:"name" validate:"omitempty,min=15,max=100"`
+	Description   *string   `json:"description" validate:"omitempty,min=20,max=1500"`
+	Img           *string   `json:"img" validate:"omitempty,url"`
+	MediaID       *int      `json:"media_id" validate:"omitempty,min=1"`
+	Price         *float64  `json:"price" validate:"omitempty,min=0"`
+	TimeDevelop   *int      `json:"time_develop" validate:"omitempty,min=1,max=1825"`
+	AppStoreURL   *string   `json:"app_store_url" validate:"omitempty,max=500,app_store_url"`
+	GooglePlayURL *string   `json:"google_play_url" validate:"omitempty,max=500,google_play_url"`
+	Platforms     *[]string `json:"platforms" validate:"omitempty,max=2,unique,dive,oneof=ios android"`
+}
+
+type PatchBotsProjectRequest struct {
+	Name        *string  `json:"name" validate:"omitempty,min=15,max=100"`
+	Description *string  `json:"description" validate:"omitempty,min=20,max=1500"`
+	Img         *string  `json:"img" validate:"omitempty,url"`
+	MediaID     *int     `json:"media_id" validate:"omitempty,min=1"`
+	Price       *float64 `json:"price" validate:"omitempty,min=0"`
+	TimeDevelop *int     `json:"time_develop" validate:"omitempty,min=1,max=1825"`
+	Platform    *string  `json:"platform" validate:"omitempty,oneof=telegram discord vk"`
+	Handle      *string  `json:"handle" validate:"omitempty,bot_handle"`
+}
+
+type PatchStaffRequest struct {
+	Name         *string  `json:"name" validate:"omitempty,min=15,max=100"`
+	Description  *string  `json:"description" validate:"omitempty,min=20,max=1500"`
+	Img          *string  `json:"img" validate:"omitempty,url"`
+	MediaID      *int     `json:"media_id" validate:"omitempty,min=1"`
+	Role         *string  `json:"role" validate:"omitempty,min=1,max=500"`
+	Department   *string  `json:"department" validate:"omitempty,max=100"`
+	Skills       *[]Skill `json:"skills" validate:"omitempty,max=30,unique=Name,dive"`
+	Email        *string  `json:"email" validate:"omitempty,max=254,email"`
+	ShowEmail    *bool    `json:"show_email"`
+	GitHubURL    *string  `json:"github_url" validate:"omitempty,max=500,social_url=github"`
+	LinkedInURL  *string  `json:"linkedin_url" validate:"omitempty,max=500,social_url=linkedin"`
+	TelegramURL  *string  `json:"telegram_url" validate:"omitempty,max=500,social_url=telegram"`
+	DisplayOrder *int     `json:"display_order" validate:"omitempty,min=0"`
+}
+
+// ListProjectsQuery параметры пагинации, сортировки и фильтрации списка проектов
+type ListProjectsQuery struct {
+	Page           int      `form:"page" validate:"omitempty,min=1"`
+	PerPage        int      `form:"per_page" validate:"omitempty,min=1,max=100"`
+	Sort           string   `form:"sort" validate:"omitempty,oneof=name price time_develop created_at"`
+	Order          string   `form:"order" validate:"omitempty,oneof=asc desc"`
+	PriceMin       *float64 `form:"price_min" validate:"omitempty,min=0"`
+	PriceMax       *float64 `form:"price_max" validate:"omitempty,min=0"`
+	TimeDevelopMax *int     `form:"time_develop_max" validate:"omitempty,min=1"`
+	// Tags slug тегов через запятую; проект должен иметь все перечисленные
+	Tags string `form:"tags" validate:"omitempty,max=500"`
+	// Status учитывается только для администраторов, публичные списки всегда published
+	Status string `form:"status" validate:"omitempty,oneof=draft in_review published archived"`
+}
+
+// ListCatalogQuery параметры общего каталога проектов, type ограничивает выборку одним типом
+type ListCatalogQuery struct {
+	ListProjectsQuery
+	Type string `form:"type" validate:"omitempty,oneof=web mobile bot"`
+}
+
+// ListStaffQuery параметры пагинации, сортировки и фильтрации списка сотрудников
+type ListStaffQuery struct {
+	Page       int    `form:"page" validate:"omitempty,min=1"`
+	PerPage    int    `form:"per_page" validate:"omitempty,min=1,max=100"`
+	Sort       string `form:"sort" validate:"omitempty,oneof=name role department display_order created_at"`
+	Order      string `form:"order" validate:"omitempty,oneof=asc desc"`
+	Role       string `form:"role" validate:"omitempty,max=500"`
+	Department string `form:"department" validate:"omitempty,max=100"`
+	Status     string `form:"status" validate:"omitempty,oneof=draft in_review published archived"`
+}
+
+// ScheduleRequest время автоматической публикации и снятия; null отменяет расписание
+type ScheduleRequest struct {
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+type SearchRequest struct {
+	Query string `form:"q" validate:"required,min=2,max=200"`
+	Limit int    `form:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+// SearchHit одна запись в результатах полнотекстового поиска
+type SearchHit struct {
+	ID      int     `json:"id"`
+	Name    string  `json:"name"`
+	Slug    string  `json:"slug"`
+	Snippet string  `json:"snippet"`
+	Rank    float64 `json:"rank"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=50"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type CreateAdminUserRequest struct {
+	Username string   `json:"username" validate:"required,min=3,max=50"`
+	Password string   `json:"password" validate:"required,min=8,max=72"`
+	Roles    []string `json:"roles" validate:"required,min=1,dive,required,max=50"`
+}
+
+type SetRolesRequest struct {
+	Roles []string `json:"roles" validate:"required,dive,required,max=50"`
+}
+
+// CreateAPIKeyRequest rate_limit - число запросов в минуту
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,min=3,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,oneof=projects.manage staff.manage"`
+	RateLimit int        `json:"rate_limit" validate:"omitempty,min=1,max=10000"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ListAuditQuery фильтры журнала изменений
+type ListAuditQuery struct {
+	Page         int        `form:"page" validate:"omitempty,min=1"`
+	PerPage      int        `form:"per_page" validate:"omitempty,min=1,max=100"`
+	Order        string     `form:"order" validate:"omitempty,oneof=asc desc"`
+	ActorUserID  *int       `form:"actor_user_id" validate:"omitempty,min=1"`
+	Action       string     `form:"action" validate:"omitempty,oneof=create update delete restore purge"`
+	ResourceType string     `form:"resource_type" validate:"omitempty,oneof=web_projects mobile_projects bots_projects staff leads"`
+	ResourceID   *int       `form:"resource_id" validate:"omitempty,min=1"`
+	From         *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To           *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// ListLeadsQuery фильтры списка заявок; unassigned=true - только без ответственного
+type ListLeadsQuery struct {
+	Page        int        `form:"page" validate:"omitempty,min=1"`
+	PerPage     int        `form:"per_page" validate:"omitempty,min=1,max=100"`
+	Order       string     `form:"order" validate:"omitempty,oneof=asc desc"`
+	Status      string     `form:"status" validate:"omitempty,oneof=new contacted won lost"`
+	AssignedTo  *int       `form:"assigned_to" validate:"omitempty,min=1"`
+	Unassigned  bool       `form:"unassigned"`
+	ProjectType string     `form:"project_type" validate:"omitempty,oneof=web mobile bot"`
+	From        *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To          *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type ListTrashQuery struct {
+	Page    int    `form:"page" validate:"omitempty,min=1"`
+	PerPage int    `form:"per_page" validate:"omitempty,min=1,max=100"`
+	Type    string `form:"type" validate:"omitempty,oneof=web mobile bot staff"`
+}
+
+type TokenResponse struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	TokenType    string    `json:"token_type"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+type GetRevisionRequest struct {
+	ID  int `uri:"id" validate:"required,min=1"`
+	Rev int `uri:"rev" validate:"required,min=1"`
 }
 
 type GetProjectRequest struct {
 	ID int `json:"id" uri:"id" validate:"required,min=1"`
 }
+
+// TranslationRequest :id записи и :locale перевода
+type TranslationRequest struct {
+	ID     int    `uri:"id" validate:"required,min=1"`
+	Locale string `uri:"locale" validate:"required,min=2,max=10"`
+}
+
+type SetTranslationRequest struct {
+	Name        string `json:"name" validate:"required,min=3,max=100"`
+	Description string `json:"description" validate:"required,min=20,max=1500"`
+}
+
+type GalleryItemRequest struct {
+	ID   int `uri:"id" validate:"required,min=1"`
+	Item int `uri:"item" validate:"required,min=1"`
+}
+
+// AddProjectMediaRequest картинка добавляется из медиатеки по media_id, видео - по ссылке
+type AddProjectMediaRequest struct {
+	Kind    string `json:"kind" validate:"required,oneof=image video"`
+	MediaID *int   `json:"media_id" validate:"required_if=Kind image,omitempty,min=1"`
+	URL     string `json:"url" validate:"required_if=Kind video,omitempty,max=2000"`
+	Caption string `json:"caption" validate:"max=300"`
+}
+
+// ReorderProjectMediaRequest новый порядок: все id элементов галереи
+type ReorderProjectMediaRequest struct {
+	IDs []int `json:"ids" validate:"required,min=1,dive,min=1"`
+}
+
+// ReorderStaffRequest id всех сотрудников (кроме удаленных в корзину) в порядке страницы команды
+type ReorderStaffRequest struct {
+	IDs []int `json:"ids" validate:"required,min=1,dive,min=1"`
+}
+
+// CreateTagRequest slug строится из name, если не задан явно (например "c-sharp" для "C#")
+type CreateTagRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+	Slug string `json:"slug" validate:"omitempty,max=100,slug"`
+	Kind string `json:"kind" validate:"omitempty,oneof=tag technology category"`
+}
+
+type PatchTagRequest struct {
+	Name *string `json:"name" validate:"omitempty,min=1,max=50"`
+	Slug *string `json:"slug" validate:"omitempty,max=100,slug"`
+	Kind *string `json:"kind" validate:"omitempty,oneof=tag technology category"`
+}
+
+type ListTagsQuery struct {
+	Kind string `form:"kind" validate:"omitempty,oneof=tag technology category"`
+}
+
+// SetProjectTagsRequest полный список slug тегов проекта; пустой список снимает все теги
+type SetProjectTagsRequest struct {
+	Tags []string `json:"tags" validate:"max=30,dive,min=1,max=110"`
+}
+
+type TeamMemberRequest struct {
+	ID      int `uri:"id" validate:"required,min=1"`
+	StaffID int `uri:"staff_id" validate:"required,min=1"`
+}
+
+// AssignTeamMemberRequest роль сотрудника в проекте; повторное назначение меняет роль
+type AssignTeamMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=lead manager analyst designer frontend backend mobile qa devops"`
+}
+
+type ImportMediaRequest struct {
+	URL string `json:"url" validate:"required,url,max=2000"`
+}
+
+type ListMediaQuery struct {
+	Page     int    `form:"page" validate:"omitempty,min=1"`
+	PerPage  int    `form:"per_page" validate:"omitempty,min=1,max=100"`
+	MimeType string `form:"mime_type" validate:"omitempty,max=100"`
+}
+
+type ListMissingTranslationsQuery struct {
+	Page    int    `form:"page" validate:"omitempty,min=1"`
+	PerPage int    `form:"per_page" validate:"omitempty,min=1,max=100"`
+	Type    string `form:"type" validate:"omitempty,oneof=web mobile bot staff"`
+	Locale  string `form:"locale" validate:"omitempty,min=2,max=10"`
+}
+
+// GetByKeyRequest :id публичного GET: числовой id или slug
+type GetByKeyRequest struct {
+	Key string `uri:"id" validate:"required,max=150"`
+}
@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"sync"
+	"time"
+
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/pkg/logger"
+)
+
+// sendTimeout ограничение на одну попытку отправки
+const sendTimeout = 30 * time.Second
+
+// Message письмо команде; тело в HTML
+type Message struct {
+	To      []string
+	Subject string
+	HTML    string
+}
+
+// Sender доставляет письмо; SMTPSender в проде, фейк в тестах
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Project опубликованный проект любого типа
+type Project struct {
+	Type        string
+	ID          int
+	Name        string
+	Slug        string
+	Description string
+	Price       float64
+}
+
+// Notifier рассылает уведомления о событиях сайта на адреса команды.
+// Отправка фоновая и не задерживает ответ API; неудачная попытка повторяется с экспоненциальной паузой.
+// nil Notifier ничего не отправляет
+type Notifier struct {
+	sender     Sender
+	recipients []string
+	retries    int
+	backoff    time.Duration
+	logger     *logger.Logger
+	pending    sync.WaitGroup
+}
+
+// New retries - сколько раз повторить отправку после первой неудачи, backoff - пауза перед первым повтором
+func New(sender Sender, recipients []string, retries int, backoff time.Duration, logger *logger.Logger) *Notifier {
+	return &Notifier{
+		sender:     sender,
+		recipients: recipients,
+		retries:    retries,
+		backoff:    backoff,
+		logger:     logger,
+	}
+}
+
+// LeadCreated новая заявка с формы сайта
+func (n *Notifier) LeadCreated(lead models.Lead) {
+	n.notify("lead_created", "Новая заявка: "+lead.Name, lead)
+}
+
+// ProjectPublished проект опубликован вручную или по расписанию
+func (n *Notifier) ProjectPublished(project Project) {
+	n.notify("project_published", "Проект опубликован: "+project.Name, project)
+}
+
+// Wait дожидается отправки уже поставленных писем, включая ожидающие повтора
+func (n *Notifier) Wait() {
+	if n == nil {
+		return
+	}
+	n.pending.Wait()
+}
+
+// Shutdown то же, что Wait, но не дольше ctx; вызывается при остановке сервиса, когда новых событий уже нет
+func (n *Notifier) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		n.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *Notifier) notify(event, subject string, data interface{}) {
+	if n == nil || len(n.recipients) == 0 {
+		return
+	}
+
+	html, err := render(event, data)
+	if err != nil {
+		n.logger.Error("Failed to render notification", map[string]interface{}{
+			"event": event,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	n.pending.Add(1)
+	go func() {
+		defer n.pending.Done()
+
+		msg := Message{To: n.recipients, Subject: subject, HTML: html}
+		if err := n.Deliver(context.Background(), msg); err != nil {
+			n.logger.Error("Failed to send notification", map[string]interface{}{
+				"event": event,
+				"error": err.Error(),
+			})
+		}
+	}()
+}
+
+// Deliver отправляет письмо с повторами: пауза backoff, 2*backoff, 4*backoff...
+// Постоянный отказ сервера (SMTP 5xx) не повторяется
+func (n *Notifier) Deliver(ctx context.Context, msg Message) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+		err = n.sender.Send(sendCtx, msg)
+		cancel()
+
+		if err == nil || attempt >= n.retries || permanent(err) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(n.backoff << attempt):
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("send %q: %w", msg.Subject, err)
+	}
+	return nil
+}
+
+// permanent ответ SMTP 5xx: адрес или письмо отклонены, повтор не поможет
+func permanent(err error) bool {
+	var smtpErr *textproto.Error
+	return errors.As(err, &smtpErr) && smtpErr.Code >= 500
+}
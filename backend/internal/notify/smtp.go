@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPSender отправляет письма через SMTP-сервер. STARTTLS включается, если сервер его предлагает;
+// без логина письмо отправляется без авторизации (локальный релей, MailHog)
+type SMTPSender struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPSender(host, port, username, password, from string) *SMTPSender {
+	return &SMTPSender{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.host, s.port))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(address(s.from)); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		if err := client.Rcpt(address(to)); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(s.compose(msg)); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// compose письмо в формате RFC 5322: заголовки с кодированием UTF-8, тело в quoted-printable
+func (s *SMTPSender) compose(msg Message) []byte {
+	var buf bytes.Buffer
+	headers := [][2]string{
+		{"From", s.from},
+		{"To", strings.Join(msg.To, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", msg.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", "<" + messageID() + "@" + domain(s.from) + ">"},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/html; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, header := range headers {
+		buf.WriteString(header[0] + ": " + header[1] + "\r\n")
+	}
+	buf.WriteString("\r\n")
+
+	body := quotedprintable.NewWriter(&buf)
+	body.Write([]byte(msg.HTML))
+	body.Close()
+	return buf.Bytes()
+}
+
+// address адрес для конверта: из "ASMO <noreply@example.com>" только noreply@example.com
+func address(value string) string {
+	if parsed, err := mail.ParseAddress(value); err == nil {
+		return parsed.Address
+	}
+	return value
+}
+
+func domain(value string) string {
+	if _, host, ok := strings.Cut(address(value), "@"); ok {
+		return host
+	}
+	return "localhost"
+}
+
+func messageID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+)
+
+//go:embed templates/*.html
+var templateFiles embed.FS
+
+// templates по событию: общий макет layout.html и содержимое письма из <событие>.html
+var templates = map[string]*template.Template{
+	"lead_created":      parse("lead_created.html"),
+	"project_published": parse("project_published.html"),
+}
+
+var templateFuncs = template.FuncMap{
+	"amount":      amount,
+	"projectType": projectType,
+}
+
+func parse(name string) *template.Template {
+	return template.Must(template.New("layout.html").Funcs(templateFuncs).
+		ParseFS(templateFiles, "templates/layout.html", "templates/"+name))
+}
+
+func render(event string, data interface{}) (string, error) {
+	tmpl, ok := templates[event]
+	if !ok {
+		return "", fmt.Errorf("unknown notification event %q", event)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// amount сумма без копеек с разделением разрядов: 1 500 000
+func amount(value interface{}) string {
+	var v float64
+	switch n := value.(type) {
+	case float64:
+		v = n
+	case *float64:
+		if n == nil {
+			return ""
+		}
+		v = *n
+	}
+
+	digits := fmt.Sprintf("%.0f", v)
+	var out []byte
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 && digits[i-1] != '-' {
+			out = append(out, ' ')
+		}
+		out = append(out, digits[i])
+	}
+	return string(out)
+}
+
+func projectType(kind string) string {
+	switch kind {
+	case "web":
+		return "Веб-приложение"
+	case "mobile":
+		return "Мобильное приложение"
+	case "bot":
+		return "Бот"
+	}
+	return kind
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// AdminUsersRepository администраторы и их refresh-токены
+type AdminUsersRepository struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+func NewAdminUsersRepository(db *sql.DB, cache cache.Cache) *AdminUsersRepository {
+	return &AdminUsersRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (r *AdminUsersRepository) FindByUsername(ctx context.Context, username string) (models.AdminUser, error) {
+	return r.findOne(ctx, "username = $1", username)
+}
+
+func (r *AdminUsersRepository) FindByID(ctx context.Context, id int) (models.AdminUser, error) {
+	return r.findOne(ctx, "id = $1", id)
+}
+
+// adminUserColumns колонки пользователя вместе с именами его ролей
+const adminUserColumns = `id, username, password_hash,
+	COALESCE((
+		SELECT array_agg(r.name ORDER BY r.name)
+		FROM admin_user_roles ur JOIN roles r ON r.id = ur.role_id
+		WHERE ur.user_id = admin_users.id
+	), '{}'),
+	created_at, update_at`
+
+func scanAdminUser(row interface{ Scan(...interface{}) error }) (models.AdminUser, error) {
+	var user models.AdminUser
+	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, pq.Array(&user.Roles), &user.CreatedAt, &user.UpdateAt)
+	return user, err
+}
+
+func (r *AdminUsersRepository) findOne(ctx context.Context, condition string, arg interface{}) (models.AdminUser, error) {
+	start := time.Now()
+	user, err := scanAdminUser(r.db.QueryRowContext(ctx, `
+		SELECT `+adminUserColumns+`
+		FROM admin_users WHERE `+condition, arg))
+
+	metrics.RecordDatabaseQuery("select", "admin_users", time.Since(start))
+
+	if err == sql.ErrNoRows {
+		return user, ErrNotFound
+	}
+	return user, err
+}
+
+func (r *AdminUsersRepository) List(ctx context.Context) ([]models.AdminUser, error) {
+	start := time.Now()
+	rows, err := r.db.QueryContext(ctx, `SELECT `+adminUserColumns+` FROM admin_users ORDER BY username`)
+
+	metrics.RecordDatabaseQuery("select", "admin_users", time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []models.AdminUser{}
+	for rows.Next() {
+		user, err := scanAdminUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// Delete удаляет пользователя и его права из кэша: с еще не истекшим токеном он сразу получит 403
+func (r *AdminUsersRepository) Delete(ctx context.Context, id int) error {
+	start := time.Now()
+	result, err := r.db.ExecContext(ctx, `DELETE FROM admin_users WHERE id = $1`, id)
+
+	metrics.RecordDatabaseQuery("delete", "admin_users", time.Since(start))
+
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNotFound
+	}
+
+	r.cache.Delete(permissionsCacheKey(id))
+	return nil
+}
+
+func (r *AdminUsersRepository) Count(ctx context.Context) (int, error) {
+	start := time.Now()
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM admin_users`).Scan(&count)
+	metrics.RecordDatabaseQuery("select", "admin_users", time.Since(start))
+	return count, err
+}
+
+// Create добавляет пользователя вместе с ролями в одной транзакции: при неизвестной роли
+// (ErrUnknownRole) пользователь не создается
+func (r *AdminUsersRepository) Create(ctx context.Context, username, passwordHash string, roles []string) (models.AdminUser, error) {
+	start := time.Now()
+	var user models.AdminUser
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return user, err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO admin_users (username, password_hash, created_at, update_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING id, username, password_hash, created_at, update_at
+	`, username, passwordHash).Scan(
+		&user.ID, &user.Username, &user.PasswordHash, &user.CreatedAt, &user.UpdateAt,
+	)
+	if err == nil {
+		err = insertUserRoles(ctx, tx, user.ID, roles)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	metrics.RecordDatabaseQuery("insert", "admin_users", time.Since(start))
+	if isUniqueViolation(err) {
+		return user, ErrAlreadyExists
+	} else if err != nil {
+		return user, err
+	}
+
+	user.Roles = uniqueStrings(roles)
+	slices.Sort(user.Roles)
+	return user, nil
+}
+
+func (r *AdminUsersRepository) SaveRefreshToken(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error {
+	start := time.Now()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+	`, userID, tokenHash, expiresAt)
+	metrics.RecordDatabaseQuery("insert", "refresh_tokens", time.Since(start))
+	return err
+}
+
+// ConsumeRefreshToken отзывает действующий токен и возвращает его владельца;
+// повторное использование того же токена вернет ErrNotFound
+func (r *AdminUsersRepository) ConsumeRefreshToken(ctx context.Context, tokenHash string) (int, error) {
+	start := time.Now()
+	var userID int
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE token_hash = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING user_id
+	`, tokenHash).Scan(&userID)
+
+	metrics.RecordDatabaseQuery("update", "refresh_tokens", time.Since(start))
+
+	if err == sql.ErrNoRows {
+		return 0, ErrNotFound
+	}
+	return userID, err
+}
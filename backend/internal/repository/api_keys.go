@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// APIKeysRepository ключи для машинных клиентов; в БД хранится только sha256 ключа
+type APIKeysRepository struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+func NewAPIKeysRepository(db *sql.DB, cache cache.Cache) *APIKeysRepository {
+	return &APIKeysRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func apiKeyCacheKey(hash string) string {
+	return "api_key:" + hash
+}
+
+const apiKeyColumns = `id, name, prefix, scopes, rate_limit, created_by, created_at, expires_at, last_used_at, revoked_at`
+
+func scanAPIKey(row interface{ Scan(...interface{}) error }) (models.APIKey, error) {
+	var key models.APIKey
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, pq.Array(&key.Scopes), &key.RateLimit,
+		&key.CreatedBy, &key.CreatedAt, &key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt)
+	return key, err
+}
+
+func (r *APIKeysRepository) Create(ctx context.Context, key models.APIKey, hash string) (models.APIKey, error) {
+	start := time.Now()
+	created, err := scanAPIKey(r.db.QueryRowContext(ctx, `
+		INSERT INTO api_keys (name, prefix, key_hash, scopes, rate_limit, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+apiKeyColumns,
+		key.Name, key.Prefix, hash, pq.Array(key.Scopes), key.RateLimit, key.CreatedBy, key.ExpiresAt))
+
+	metrics.RecordDatabaseQuery("insert", "api_keys", time.Since(start))
+
+	return created, err
+}
+
+func (r *APIKeysRepository) List(ctx context.Context) ([]models.APIKey, error) {
+	start := time.Now()
+	rows, err := r.db.QueryContext(ctx, `SELECT `+apiKeyColumns+` FROM api_keys ORDER BY created_at DESC, id DESC`)
+
+	metrics.RecordDatabaseQuery("select", "api_keys", time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// FindActiveByHash ищет неотозванный и не истекший ключ; результат кэшируется на минуту,
+// отзыв сбрасывает кэш сразу
+func (r *APIKeysRepository) FindActiveByHash(ctx context.Context, hash string) (models.APIKey, error) {
+	start := time.Now()
+	cacheKey := apiKeyCacheKey(hash)
+
+	var key models.APIKey
+	if err := r.cache.Get(cacheKey, &key); err == nil {
+		metrics.RecordDatabaseQuery("cache_hit", "api_keys", time.Since(start))
+		if key.ExpiresAt != nil && key.ExpiresAt.Before(time.Now()) {
+			return models.APIKey{}, ErrNotFound
+		}
+		return key, nil
+	}
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, `
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		WHERE key_hash = $1 AND revoked_at IS NULL
+			AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+	`, hash))
+
+	metrics.RecordDatabaseQuery("select", "api_keys", time.Since(start))
+
+	if err == sql.ErrNoRows {
+		return key, ErrNotFound
+	} else if err != nil {
+		return key, err
+	}
+
+	r.cache.Set(cacheKey, key, time.Minute)
+	return key, nil
+}
+
+// TouchLastUsed обновляет last_used_at не чаще раза в минуту, чтобы не писать в БД на каждый запрос
+func (r *APIKeysRepository) TouchLastUsed(ctx context.Context, id int) error {
+	start := time.Now()
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE api_keys SET last_used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute')
+	`, id)
+
+	metrics.RecordDatabaseQuery("update", "api_keys", time.Since(start))
+
+	return err
+}
+
+func (r *APIKeysRepository) Revoke(ctx context.Context, id int) error {
+	start := time.Now()
+	var hash string
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND revoked_at IS NULL
+		RETURNING key_hash
+	`, id).Scan(&hash)
+
+	metrics.RecordDatabaseQuery("update", "api_keys", time.Since(start))
+
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	r.cache.Delete(apiKeyCacheKey(hash))
+	return nil
+}
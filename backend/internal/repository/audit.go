@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+)
+
+// Действия в журнале изменений
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+)
+
+// auditIgnored поля, которые меняются при каждой записи и только засоряют diff
+var auditIgnored = map[string]bool{"update_at": true}
+
+// Actor кто выполняет изменение; передается через context от хэндлера до транзакции
+type Actor struct {
+	UserID    int
+	APIKeyID  int
+	Name      string
+	IP        string
+	RequestID string
+}
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func actorFrom(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
+
+// AuditDiff сравнивает JSON-представления записей и возвращает только отличающиеся поля.
+// Для создания before = nil, для удаления after = nil
+func AuditDiff(before, after interface{}) (map[string]interface{}, map[string]interface{}, error) {
+	beforeFields, err := jsonFields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterFields, err := jsonFields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if beforeFields == nil || afterFields == nil {
+		return beforeFields, afterFields, nil
+	}
+
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+	for name, value := range afterFields {
+		if !reflect.DeepEqual(beforeFields[name], value) {
+			changedBefore[name] = beforeFields[name]
+			changedAfter[name] = value
+		}
+	}
+	return changedBefore, changedAfter, nil
+}
+
+func jsonFields(value interface{}) (map[string]interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name := range auditIgnored {
+		delete(fields, name)
+	}
+	return fields, nil
+}
+
+// recordAudit пишет запись журнала в той же транзакции, что и само изменение
+func recordAudit(ctx context.Context, tx *sql.Tx, action, resourceType string, resourceID int, before, after interface{}) error {
+	start := time.Now()
+	changedBefore, changedAfter, err := AuditDiff(before, after)
+	if err != nil {
+		return err
+	}
+
+	beforeJSON, err := nullableJSON(changedBefore)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := nullableJSON(changedAfter)
+	if err != nil {
+		return err
+	}
+
+	actor := actorFrom(ctx)
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO audit_log (actor_user_id, actor_api_key_id, actor_name, action, resource_type, resource_id, before, after, ip, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, nullableID(actor.UserID), nullableID(actor.APIKeyID), actor.Name, action, resourceType, resourceID,
+		beforeJSON, afterJSON, actor.IP, actor.RequestID)
+
+	metrics.RecordDatabaseQuery("insert", "audit_log", time.Since(start))
+
+	return err
+}
+
+func nullableJSON(fields map[string]interface{}) (interface{}, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func nullableID(id int) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// AuditRepository чтение журнала изменений; не кэшируется, чтобы записи были видны сразу
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{
+		db: db,
+	}
+}
+
+func (r *AuditRepository) List(ctx context.Context, query ListQuery) (Page[models.AuditEntry], error) {
+	start := time.Now()
+	query = query.normalized(nil)
+
+	where, args := whereClause(query.Filters)
+
+	page := Page[models.AuditEntry]{Items: []models.AuditEntry{}, Page: query.Page, PerPage: query.PerPage}
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_log `+where, args...).Scan(&page.Total); err != nil {
+		metrics.RecordDatabaseQuery("select", "audit_log", time.Since(start))
+		return page, err
+	}
+
+	order := strings.ToUpper(query.Order)
+	limit := " LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
+	args = append(args, query.PerPage, (query.Page-1)*query.PerPage)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, actor_user_id, actor_api_key_id, actor_name, action, resource_type, resource_id,
+			before, after, ip, request_id, created_at
+		FROM audit_log
+		`+where+`
+		ORDER BY created_at `+order+`, id `+order+limit, args...)
+
+	metrics.RecordDatabaseQuery("select", "audit_log", time.Since(start))
+
+	if err != nil {
+		return page, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry models.AuditEntry
+		var before, after []byte
+		if err := rows.Scan(&entry.ID, &entry.ActorUserID, &entry.ActorAPIKeyID, &entry.ActorName, &entry.Action,
+			&entry.ResourceType, &entry.ResourceID, &before, &after, &entry.IP, &entry.RequestID, &entry.CreatedAt); err != nil {
+			return page, err
+		}
+		entry.Before = nullableRaw(before)
+		entry.After = nullableRaw(after)
+		page.Items = append(page.Items, entry)
+	}
+	return page, rows.Err()
+}
+
+func nullableRaw(data []byte) json.RawMessage {
+	if data == nil {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(data)
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ErrInvalidGalleryOrder новый порядок должен содержать каждый элемент галереи ровно один раз
+var ErrInvalidGalleryOrder = errors.New("invalid gallery order")
+
+// queryer общее у *sql.DB и *sql.Tx для чтения галереи внутри и вне транзакции
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+const galleryColumns = `id, kind, url, media_id, images, COALESCE(provider, ''), COALESCE(embed_url, ''), caption, position, created_at`
+
+func (s Schema[T]) galleryKey(id int) string {
+	return s.ItemKey + strconv.Itoa(id) + ":gallery"
+}
+
+// Gallery элементы галереи по порядку; кэшируется отдельно от записи
+func (r *PostgresRepository[T]) Gallery(ctx context.Context, id int) ([]models.ProjectMedia, error) {
+	if r.schema.Gallery == "" {
+		return []models.ProjectMedia{}, nil
+	}
+
+	start := time.Now()
+	cacheKey := r.schema.galleryKey(id)
+
+	var gallery []models.ProjectMedia
+	if err := r.cache.Get(cacheKey, &gallery); err == nil {
+		metrics.RecordDatabaseQuery("cache_hit", r.schema.Gallery, time.Since(start))
+		return gallery, nil
+	}
+
+	gallery, err := r.gallery(ctx, r.db, id)
+
+	metrics.RecordDatabaseQuery("select", r.schema.Gallery, time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(cacheKey, gallery, 10*time.Minute)
+	return gallery, nil
+}
+
+// AddGalleryItem добавляет элемент в конец галереи. Для картинки url и images берутся из медиатеки
+func (r *PostgresRepository[T]) AddGalleryItem(ctx context.Context, id int, item models.ProjectMedia) (models.ProjectMedia, error) {
+	start := time.Now()
+	var created models.ProjectMedia
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := r.lockGallery(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		var images interface{}
+		if item.MediaID != nil {
+			var raw string
+			err := tx.QueryRowContext(ctx, `SELECT url, images FROM media WHERE id = $1`, *item.MediaID).Scan(&item.URL, &raw)
+			if err == sql.ErrNoRows {
+				return ErrMediaNotFound
+			} else if err != nil {
+				return err
+			}
+			images = raw
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO `+r.schema.Gallery+` (record_id, kind, media_id, url, images, provider, embed_url, caption, position)
+			VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), $8,
+				(SELECT COALESCE(MAX(position), 0) + 1 FROM `+r.schema.Gallery+` WHERE record_id = $1))
+			RETURNING `+galleryColumns,
+			id, item.Kind, item.MediaID, item.URL, images, item.Provider, item.EmbedURL, item.Caption,
+		).Scan(galleryFields(&created)...)
+
+		metrics.RecordDatabaseQuery("insert", r.schema.Gallery, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+		return r.recordGallery(ctx, tx, id, before)
+	})
+	if err != nil {
+		return created, err
+	}
+
+	r.cache.Delete(r.schema.galleryKey(id))
+
+	return created, nil
+}
+
+// RemoveGalleryItem удаляет элемент и сдвигает позиции следующих
+func (r *PostgresRepository[T]) RemoveGalleryItem(ctx context.Context, id, itemID int) error {
+	start := time.Now()
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := r.lockGallery(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM `+r.schema.Gallery+` WHERE id = $1 AND record_id = $2`, itemID, id)
+		if err == nil {
+			if affected, _ := result.RowsAffected(); affected == 0 {
+				err = ErrNotFound
+			}
+		}
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE `+r.schema.Gallery+` AS g SET position = n.position
+				FROM (
+					SELECT id, ROW_NUMBER() OVER (ORDER BY position, id) AS position
+					FROM `+r.schema.Gallery+` WHERE record_id = $1
+				) AS n
+				WHERE g.id = n.id
+			`, id)
+		}
+
+		metrics.RecordDatabaseQuery("delete", r.schema.Gallery, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+		return r.recordGallery(ctx, tx, id, before)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.cache.Delete(r.schema.galleryKey(id))
+
+	return nil
+}
+
+// ReorderGallery расставляет элементы в порядке itemIDs
+func (r *PostgresRepository[T]) ReorderGallery(ctx context.Context, id int, itemIDs []int) ([]models.ProjectMedia, error) {
+	start := time.Now()
+	var gallery []models.ProjectMedia
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := r.lockGallery(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		current := make([]int, len(before))
+		for i, item := range before {
+			current[i] = item.ID
+		}
+		ordered := slices.Clone(itemIDs)
+		slices.Sort(current)
+		slices.Sort(ordered)
+		if !slices.Equal(current, ordered) {
+			return ErrInvalidGalleryOrder
+		}
+
+		ids := make([]int64, len(itemIDs))
+		for i, itemID := range itemIDs {
+			ids[i] = int64(itemID)
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE `+r.schema.Gallery+` AS g SET position = o.position
+			FROM unnest($2::int[]) WITH ORDINALITY AS o(id, position)
+			WHERE g.id = o.id AND g.record_id = $1
+		`, id, pq.Array(ids))
+
+		metrics.RecordDatabaseQuery("update", r.schema.Gallery, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+		if gallery, err = r.gallery(ctx, tx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditUpdate, r.schema.Name, id,
+			map[string]interface{}{"gallery": before}, map[string]interface{}{"gallery": gallery})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Delete(r.schema.galleryKey(id))
+
+	return gallery, nil
+}
+
+// lockGallery блокирует запись (у удаленных в корзину галерея не меняется) и возвращает
+// текущую галерею для журнала
+func (r *PostgresRepository[T]) lockGallery(ctx context.Context, tx *sql.Tx, id int) ([]models.ProjectMedia, error) {
+	if r.schema.Gallery == "" {
+		return nil, ErrNotFound
+	}
+
+	var exists int
+	err := tx.QueryRowContext(ctx, `
+		SELECT 1 FROM `+r.schema.Table+` WHERE id = $1`+r.schema.andLive()+` FOR UPDATE
+	`, id).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return r.gallery(ctx, tx, id)
+}
+
+// recordGallery пишет в журнал галерею до и после изменения
+func (r *PostgresRepository[T]) recordGallery(ctx context.Context, tx *sql.Tx, id int, before []models.ProjectMedia) error {
+	after, err := r.gallery(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	return recordAudit(ctx, tx, AuditUpdate, r.schema.Name, id,
+		map[string]interface{}{"gallery": before}, map[string]interface{}{"gallery": after})
+}
+
+func (r *PostgresRepository[T]) gallery(ctx context.Context, db queryer, id int) ([]models.ProjectMedia, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+galleryColumns+`
+		FROM `+r.schema.Gallery+`
+		WHERE record_id = $1
+		ORDER BY position, id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	gallery := []models.ProjectMedia{}
+	for rows.Next() {
+		var item models.ProjectMedia
+		if err := rows.Scan(galleryFields(&item)...); err != nil {
+			return nil, err
+		}
+		gallery = append(gallery, item)
+	}
+	return gallery, rows.Err()
+}
+
+func galleryFields(item *models.ProjectMedia) []interface{} {
+	return []interface{}{&item.ID, &item.Kind, &item.URL, &item.MediaID, jsonValue{&item.Images},
+		&item.Provider, &item.EmbedURL, &item.Caption, &item.Position, &item.CreatedAt}
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+)
+
+var (
+	// ErrProjectNotFound заявка ссылается на несуществующий или неопубликованный проект
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrAdminUserNotFound заявка назначается несуществующему администратору
+	ErrAdminUserNotFound = errors.New("admin user not found")
+)
+
+// leadProjectTables таблица проекта для project_type заявки
+var leadProjectTables = map[string]string{
+	"web":    "web_projects",
+	"mobile": "mobile_projects",
+	"bot":    "bots_projects",
+}
+
+const leadColumns = `l.id, l.name, l.contact, l.message, l.budget, l.project_type, l.project_id, l.status,
+	l.assigned_to, COALESCE(u.username, ''), l.created_at, l.update_at`
+
+const leadSource = `leads AS l LEFT JOIN admin_users AS u ON u.id = l.assigned_to`
+
+// LeadRepository заявки с формы сайта; не кэшируется, чтобы новые заявки были видны сразу
+type LeadRepository struct {
+	db *sql.DB
+}
+
+func NewLeadRepository(db *sql.DB) *LeadRepository {
+	return &LeadRepository{
+		db: db,
+	}
+}
+
+func scanLead(row interface{ Scan(...interface{}) error }) (models.Lead, error) {
+	var lead models.Lead
+	err := row.Scan(&lead.ID, &lead.Name, &lead.Contact, &lead.Message, &lead.Budget, &lead.ProjectType, &lead.ProjectID,
+		&lead.Status, &lead.AssignedTo, &lead.AssigneeName, &lead.CreatedAt, &lead.UpdateAt)
+	return lead, err
+}
+
+// Create сохраняет заявку; проект, с которого она отправлена, должен быть опубликован
+func (r *LeadRepository) Create(ctx context.Context, lead models.Lead) (models.Lead, error) {
+	start := time.Now()
+
+	if lead.ProjectID != nil {
+		table, ok := leadProjectTables[lead.ProjectType]
+		if !ok {
+			return lead, ErrProjectNotFound
+		}
+		var exists int
+		err := r.db.QueryRowContext(ctx, `
+			SELECT 1 FROM `+table+` WHERE id = $1 AND deleted_at IS NULL AND status = 'published'
+		`, *lead.ProjectID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return lead, ErrProjectNotFound
+		} else if err != nil {
+			return lead, err
+		}
+	}
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO leads (name, contact, message, budget, project_type, project_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, status, created_at, update_at
+	`, lead.Name, lead.Contact, lead.Message, lead.Budget, lead.ProjectType, lead.ProjectID).
+		Scan(&lead.ID, &lead.Status, &lead.CreatedAt, &lead.UpdateAt)
+
+	metrics.RecordDatabaseQuery("insert", "leads", time.Since(start))
+
+	return lead, err
+}
+
+func (r *LeadRepository) Get(ctx context.Context, id int) (models.Lead, error) {
+	start := time.Now()
+	lead, err := scanLead(r.db.QueryRowContext(ctx, `SELECT `+leadColumns+` FROM `+leadSource+` WHERE l.id = $1`, id))
+
+	metrics.RecordDatabaseQuery("select", "leads", time.Since(start))
+
+	if err == sql.ErrNoRows {
+		return lead, ErrNotFound
+	}
+	return lead, err
+}
+
+// List заявки, новые первыми; колонки в фильтрах указываются с префиксом l.
+func (r *LeadRepository) List(ctx context.Context, query ListQuery) (Page[models.Lead], error) {
+	start := time.Now()
+	query = query.normalized(nil)
+
+	where, args := whereClause(query.Filters)
+
+	page := Page[models.Lead]{Items: []models.Lead{}, Page: query.Page, PerPage: query.PerPage}
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+leadSource+` `+where, args...).Scan(&page.Total); err != nil {
+		metrics.RecordDatabaseQuery("select", "leads", time.Since(start))
+		return page, err
+	}
+
+	order := strings.ToUpper(query.Order)
+	limit := " LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
+	args = append(args, query.PerPage, (query.Page-1)*query.PerPage)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+leadColumns+`
+		FROM `+leadSource+`
+		`+where+`
+		ORDER BY l.created_at `+order+`, l.id `+order+limit, args...)
+
+	metrics.RecordDatabaseQuery("select", "leads", time.Since(start))
+
+	if err != nil {
+		return page, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		lead, err := scanLead(rows)
+		if err != nil {
+			return page, err
+		}
+		page.Items = append(page.Items, lead)
+	}
+	return page, rows.Err()
+}
+
+// Assign назначает ответственного администратора; nil снимает назначение
+func (r *LeadRepository) Assign(ctx context.Context, id int, userID *int) (models.Lead, error) {
+	return r.update(ctx, id, "assigned_to", userID, func(tx *sql.Tx) error {
+		if userID == nil {
+			return nil
+		}
+		var exists int
+		err := tx.QueryRowContext(ctx, `SELECT 1 FROM admin_users WHERE id = $1`, *userID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return ErrAdminUserNotFound
+		}
+		return err
+	})
+}
+
+func (r *LeadRepository) SetStatus(ctx context.Context, id int, status string) (models.Lead, error) {
+	return r.update(ctx, id, "status", status, nil)
+}
+
+// update меняет одну колонку заявки и пишет изменение в журнал; check выполняется после блокировки строки
+func (r *LeadRepository) update(ctx context.Context, id int, column string, value interface{}, check func(tx *sql.Tx) error) (models.Lead, error) {
+	start := time.Now()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Lead{}, err
+	}
+	defer tx.Rollback()
+
+	before, err := scanLead(tx.QueryRowContext(ctx, `
+		SELECT `+leadColumns+` FROM `+leadSource+` WHERE l.id = $1 FOR UPDATE OF l
+	`, id))
+	if err == sql.ErrNoRows {
+		return before, ErrNotFound
+	} else if err != nil {
+		return before, err
+	}
+
+	if check != nil {
+		if err := check(tx); err != nil {
+			return before, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE leads SET `+column+` = $1, update_at = CURRENT_TIMESTAMP WHERE id = $2
+	`, value, id)
+
+	metrics.RecordDatabaseQuery("update", "leads", time.Since(start))
+
+	if err != nil {
+		return before, err
+	}
+
+	lead, err := scanLead(tx.QueryRowContext(ctx, `SELECT `+leadColumns+` FROM `+leadSource+` WHERE l.id = $1`, id))
+	if err != nil {
+		return lead, err
+	}
+	if err := recordAudit(ctx, tx, AuditUpdate, "leads", id, before, lead); err != nil {
+		return lead, err
+	}
+	return lead, tx.Commit()
+}
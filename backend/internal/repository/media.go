@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ErrMediaNotFound media_id в запросе ссылается на несуществующий файл
+var ErrMediaNotFound = errors.New("media not found")
+
+// MediaInUseError файл еще служит картинкой записей; удалить его можно после замены картинки
+type MediaInUseError struct {
+	UsedBy []models.MediaUsage
+}
+
+func (e *MediaInUseError) Error() string {
+	return fmt.Sprintf("media is used by %d records", len(e.UsedBy))
+}
+
+// mediaUsers таблицы, чьи img/images/media_id берутся из медиатеки
+var mediaUsers = []string{"web_projects", "mobile_projects", "bots_projects", "staff"}
+
+// withMedia подставляет в img и images адрес и копии файла из медиатеки, если в fields передан media_id.
+// Картинка по внешнему img без media_id отвязывается от медиатеки, images обнуляется
+func (r *PostgresRepository[T]) withMedia(ctx context.Context, tx *sql.Tx, fields Fields) (Fields, error) {
+	var id int
+	switch value := fields["media_id"].(type) {
+	case *int:
+		if value == nil {
+			return externalImage(fields), nil
+		}
+		id = *value
+	case int:
+		id = value
+	case json.Number:
+		// Значение из ревизии при откате
+		n, err := strconv.Atoi(value.String())
+		if err != nil {
+			return nil, ErrMediaNotFound
+		}
+		id = n
+	default:
+		return externalImage(fields), nil
+	}
+
+	start := time.Now()
+	var url string
+	var images []byte
+	err := tx.QueryRowContext(ctx, `SELECT url, images FROM media WHERE id = $1`, id).Scan(&url, &images)
+
+	metrics.RecordDatabaseQuery("select", "media", time.Since(start))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrMediaNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	withMedia := make(Fields, len(fields)+2)
+	for column, value := range fields {
+		withMedia[column] = value
+	}
+	withMedia["media_id"] = id
+	withMedia["img"] = url
+	withMedia["images"] = string(images)
+	return withMedia, nil
+}
+
+// ImageImporter кладет картинку по внешнему адресу в медиатеку и возвращает id файла
+type ImageImporter func(ctx context.Context, url string) (int, error)
+
+// ImportExternalImages fn вызывается в Create и Update для внешнего img без media_id, чтобы и у такой записи
+// были копии в images. Если импорт не удался, запись сохраняется с внешним img и images = null
+func (r *PostgresRepository[T]) ImportExternalImages(fn ImageImporter) *PostgresRepository[T] {
+	r.importImage = fn
+	return r
+}
+
+// importExternalImage вызывается до транзакции: скачивание не должно держать блокировки
+func (r *PostgresRepository[T]) importExternalImage(ctx context.Context, fields Fields) Fields {
+	if r.importImage == nil || hasMediaID(fields) {
+		return fields
+	}
+	img, _ := fields["img"].(string)
+	if img == "" {
+		return fields
+	}
+
+	id, err := r.importImage(ctx, img)
+	if err != nil {
+		return fields
+	}
+
+	imported := make(Fields, len(fields)+1)
+	for column, value := range fields {
+		imported[column] = value
+	}
+	imported["media_id"] = id
+	return imported
+}
+
+// hasMediaID те же случаи, в которых withMedia берет картинку из медиатеки
+func hasMediaID(fields Fields) bool {
+	switch value := fields["media_id"].(type) {
+	case *int:
+		return value != nil
+	case int, json.Number:
+		return true
+	}
+	return false
+}
+
+// externalImage для fields с img, но без media_id
+func externalImage(fields Fields) Fields {
+	if _, ok := fields["img"]; !ok {
+		return fields
+	}
+
+	external := make(Fields, len(fields)+2)
+	for column, value := range fields {
+		external[column] = value
+	}
+	external["media_id"] = nil
+	external["images"] = nil
+	return external
+}
+
+// jsonValue сканирует JSONB-колонку в поле модели; NULL дает нулевое значение
+type jsonValue struct {
+	dest interface{}
+}
+
+func (v jsonValue) Scan(src interface{}) error {
+	switch data := src.(type) {
+	case nil:
+		return json.Unmarshal([]byte("null"), v.dest)
+	case []byte:
+		return json.Unmarshal(data, v.dest)
+	case string:
+		return json.Unmarshal([]byte(data), v.dest)
+	}
+	return fmt.Errorf("unsupported JSON column type %T", src)
+}
+
+// MediaRepository записи о загруженных файлах; сами файлы лежат в storage.Storage
+type MediaRepository struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+func NewMediaRepository(db *sql.DB, cache cache.Cache) *MediaRepository {
+	return &MediaRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+const mediaColumns = `id, storage_key, url, original_name, mime_type, size, images, variant_keys, source_url, uploaded_by, created_at`
+
+func scanMedia(row interface{ Scan(...interface{}) error }) (models.Media, error) {
+	var media models.Media
+	err := row.Scan(&media.ID, &media.Key, &media.URL, &media.OriginalName, &media.MimeType,
+		&media.Size, jsonValue{&media.Images}, pq.Array(&media.VariantKeys), &media.SourceURL, &media.UploadedBy, &media.CreatedAt)
+	return media, err
+}
+
+func (r *MediaRepository) Create(ctx context.Context, media models.Media) (models.Media, error) {
+	start := time.Now()
+	images, err := json.Marshal(media.Images)
+	if err != nil {
+		return media, err
+	}
+
+	created, err := scanMedia(r.db.QueryRowContext(ctx, `
+		INSERT INTO media (storage_key, url, original_name, mime_type, size, images, variant_keys, source_url, uploaded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+mediaColumns,
+		media.Key, media.URL, media.OriginalName, media.MimeType, media.Size, string(images), pq.Array(media.VariantKeys), media.SourceURL, media.UploadedBy))
+
+	metrics.RecordDatabaseQuery("insert", "media", time.Since(start))
+
+	return created, err
+}
+
+// FindByURL файл медиатеки с этим адресом или скачанный с него; при нескольких импортах - последний
+func (r *MediaRepository) FindByURL(ctx context.Context, url string) (models.Media, error) {
+	start := time.Now()
+	media, err := scanMedia(r.db.QueryRowContext(ctx, `
+		SELECT `+mediaColumns+`
+		FROM media WHERE url = $1 OR source_url = $1
+		ORDER BY id DESC LIMIT 1
+	`, url))
+
+	metrics.RecordDatabaseQuery("select", "media", time.Since(start))
+
+	if err == sql.ErrNoRows {
+		return media, ErrNotFound
+	}
+	return media, err
+}
+
+func (r *MediaRepository) List(ctx context.Context, query ListQuery) (Page[models.Media], error) {
+	start := time.Now()
+	query = query.normalized(nil)
+	page := Page[models.Media]{Items: []models.Media{}, Page: query.Page, PerPage: query.PerPage}
+
+	where, args := whereClause(query.Filters)
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM media `+where, args...).Scan(&page.Total); err != nil {
+		metrics.RecordDatabaseQuery("select", "media", time.Since(start))
+		return page, err
+	}
+
+	limit := " LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
+	args = append(args, query.PerPage, (query.Page-1)*query.PerPage)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+mediaColumns+`
+		FROM media
+		`+where+`
+		ORDER BY created_at DESC, id DESC`+limit, args...)
+
+	metrics.RecordDatabaseQuery("select", "media", time.Since(start))
+
+	if err != nil {
+		return page, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		media, err := scanMedia(rows)
+		if err != nil {
+			return page, err
+		}
+		page.Items = append(page.Items, media)
+	}
+	return page, rows.Err()
+}
+
+// Delete удаляет запись и возвращает ее, чтобы вызывающий удалил файл из хранилища.
+// Пока файл указан картинкой проекта или сотрудника (по media_id или тому же img, в том числе в корзине),
+// удаление отклоняется с *MediaInUseError: иначе публичные ответы отдавали бы адреса удаленных файлов
+func (r *MediaRepository) Delete(ctx context.Context, id int) (models.Media, error) {
+	start := time.Now()
+	var media models.Media
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return media, err
+	}
+	defer tx.Rollback()
+
+	media, err = scanMedia(tx.QueryRowContext(ctx, `SELECT `+mediaColumns+` FROM media WHERE id = $1 FOR UPDATE`, id))
+	if err == sql.ErrNoRows {
+		return media, ErrNotFound
+	} else if err != nil {
+		return media, err
+	}
+
+	usedBy, err := mediaUsages(ctx, tx, media)
+	if err != nil {
+		return media, err
+	}
+	if len(usedBy) > 0 {
+		return media, &MediaInUseError{UsedBy: usedBy}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM media WHERE id = $1`, id); err != nil {
+		return media, err
+	}
+	err = tx.Commit()
+
+	metrics.RecordDatabaseQuery("delete", "media", time.Since(start))
+
+	if err != nil {
+		return media, err
+	}
+
+	// Картинки файла удалились из галерей каскадом
+	r.cache.DeletePattern("*:gallery")
+	return media, nil
+}
+
+// mediaUsages записи, у которых файл стоит картинкой
+func mediaUsages(ctx context.Context, tx *sql.Tx, media models.Media) ([]models.MediaUsage, error) {
+	queries := make([]string, len(mediaUsers))
+	for i, table := range mediaUsers {
+		queries[i] = `SELECT '` + table + `', id FROM ` + table + ` WHERE media_id = $1 OR img = $2`
+	}
+
+	rows, err := tx.QueryContext(ctx, strings.Join(queries, " UNION ALL ")+` ORDER BY 1, 2`, media.ID, media.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var usages []models.MediaUsage
+	for rows.Next() {
+		var usage models.MediaUsage
+		if err := rows.Scan(&usage.Type, &usage.ID); err != nil {
+			return nil, err
+		}
+		usages = append(usages, usage)
+	}
+	return usages, rows.Err()
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
+
+	"ASMO-site-backend/internal/metrics"
+
+	"github.com/lib/pq"
+)
+
+// ErrInvalidOrder новый порядок должен содержать каждую запись (кроме удаленных в корзину) ровно один раз
+var ErrInvalidOrder = errors.New("invalid display order")
+
+// Reorder нумерует записи с 1 в порядке ids; в журнал попадают только записи, чья позиция изменилась
+func (r *PostgresRepository[T]) Reorder(ctx context.Context, ids []int) error {
+	if !r.schema.Ordered {
+		return ErrInvalidOrder
+	}
+
+	start := time.Now()
+	var changed []int
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		where, _ := whereClause(nil, r.schema.live()...)
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id, display_order FROM `+r.schema.Table+` `+where+` ORDER BY id FOR UPDATE
+		`)
+		if err != nil {
+			return err
+		}
+		before := map[int]int{}
+		current := []int{}
+		for rows.Next() {
+			var id, position int
+			if err := rows.Scan(&id, &position); err != nil {
+				rows.Close()
+				return err
+			}
+			before[id] = position
+			current = append(current, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		ordered := slices.Clone(ids)
+		slices.Sort(ordered)
+		if !slices.Equal(current, ordered) {
+			return ErrInvalidOrder
+		}
+
+		list := make([]int64, len(ids))
+		for i, id := range ids {
+			list[i] = int64(id)
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE `+r.schema.Table+` AS t SET display_order = o.position, update_at = CURRENT_TIMESTAMP
+			FROM unnest($1::int[]) WITH ORDINALITY AS o(id, position)
+			WHERE t.id = o.id AND t.display_order <> o.position
+		`, pq.Array(list))
+
+		metrics.RecordDatabaseQuery("update", r.schema.Name, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+
+		for i, id := range ids {
+			if before[id] == i+1 {
+				continue
+			}
+			changed = append(changed, id)
+			err := recordAudit(ctx, tx, AuditUpdate, r.schema.Name, id,
+				map[string]interface{}{"display_order": before[id]}, map[string]interface{}{"display_order": i + 1})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(changed) > 0 {
+		r.invalidate(0)
+		for _, id := range changed {
+			r.invalidateItem(id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,629 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// Schema описывает, как сущность T хранится в Postgres и в кэше
+type Schema[T any] struct {
+	// Name метка таблицы в метриках
+	Name string
+	// Table таблица или подзапрос, из которого читаются записи
+	Table string
+	// Columns колонки SELECT/RETURNING в том же порядке, что и Fields
+	Columns []string
+	// Fields возвращает указатели на поля T для Scan
+	Fields func(*T) []interface{}
+	// Sortable поля, по которым разрешена сортировка списка
+	Sortable []string
+	// OrderSuffix дополнительные ключи сортировки, например ", type"
+	OrderSuffix string
+	// ListKey префикс ключей кэша для списков, например "web_projects:all"
+	ListKey string
+	// ItemKey префикс ключа кэша одной записи, например "web_project:"
+	ItemKey string
+	// Invalidates дополнительные шаблоны ключей, которые сбрасываются при записи
+	Invalidates []string
+	// SoftDelete Delete только проставляет deleted_at, такие записи скрыты из List/Get
+	SoftDelete bool
+	// Scheduled в таблице есть publish_at/unpublish_at, их обрабатывает ApplySchedule
+	Scheduled bool
+	// Revisions таблица снимков, которые сохраняются перед каждым Update
+	Revisions string
+	// Editable колонки, которые возвращаются при откате к ревизии
+	Editable []string
+	// Redirects таблица старых slug; если задана, slug генерируется из name при записи
+	Redirects string
+	// Translations таблица переводов name/description
+	Translations string
+	// Localized источник чтения для локали из WithLocale; nil - переводов нет
+	Localized func(locale string) string
+	// Gallery таблица элементов галереи (картинки и видео)
+	Gallery string
+	// Tags таблица связей с тегами; у каталога - объединение связей всех типов с колонкой type
+	Tags string
+	// TagsByType связи сопоставляются по (type, id): в каталоге id разных таблиц пересекаются
+	TagsByType bool
+	// Team таблица участников проекта с их ролями
+	Team string
+	// Memberships запись - сотрудник, его проекты собираются из таблиц команд всех типов
+	Memberships bool
+	// JSON колонки JSONB, значения для них сериализуются в JSON
+	JSON []string
+	// Ordered в таблице есть display_order, порядок задается Reorder
+	Ordered bool
+}
+
+// live условия, отсекающие удаленные в корзину записи
+func (s Schema[T]) live() []string {
+	if s.SoftDelete {
+		return []string{"deleted_at IS NULL"}
+	}
+	return nil
+}
+
+// andLive то же для запросов по id
+func (s Schema[T]) andLive() string {
+	if s.SoftDelete {
+		return " AND deleted_at IS NULL"
+	}
+	return ""
+}
+
+// id первичный ключ записи; по соглашению это первая колонка Columns
+func (s Schema[T]) id(item *T) int {
+	if id, ok := s.Fields(item)[0].(*int); ok {
+		return *id
+	}
+	return 0
+}
+
+// PostgresRepository реализация Repository поверх *sql.DB с read-through кэшем
+type PostgresRepository[T any] struct {
+	db          *sql.DB
+	cache       cache.Cache
+	schema      Schema[T]
+	published   func(ctx context.Context, item T)
+	importImage ImageImporter
+}
+
+func NewPostgresRepository[T any](db *sql.DB, cache cache.Cache, schema Schema[T]) *PostgresRepository[T] {
+	return &PostgresRepository[T]{
+		db:     db,
+		cache:  cache,
+		schema: schema,
+	}
+}
+
+// OnPublish fn вызывается после коммита каждой публикации: перехода в published через Transition
+// и публикации по расписанию в ApplySchedule
+func (r *PostgresRepository[T]) OnPublish(fn func(ctx context.Context, item T)) *PostgresRepository[T] {
+	r.published = fn
+	return r
+}
+
+func (r *PostgresRepository[T]) List(ctx context.Context, query ListQuery) (Page[T], bool, error) {
+	start := time.Now()
+	query = query.normalized(r.schema.Sortable)
+	cacheKey := query.cacheKey(r.schema.ListKey)
+	if locale := localeFrom(ctx); locale != "" && r.schema.Localized != nil {
+		cacheKey += "&lang=" + locale
+	}
+	table := r.schema.source(ctx)
+
+	// Пробуем получить из кэша
+	var page Page[T]
+	if err := r.cache.Get(cacheKey, &page); err == nil {
+		metrics.RecordDatabaseQuery("cache_hit", r.schema.Name, time.Since(start))
+		return page, true, nil
+	}
+
+	// Если нет в кэше, получаем из БД
+	filters := query.Filters
+	if len(query.Tags) > 0 && r.schema.Tags != "" {
+		filters = append(slices.Clone(filters), r.schema.tagsFilter(query.Tags))
+	}
+	where, args := whereClause(filters, r.schema.live()...)
+
+	page = Page[T]{Items: []T{}, Page: query.Page, PerPage: query.PerPage}
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table+` `+where, args...).Scan(&page.Total)
+	if err != nil {
+		metrics.RecordDatabaseQuery("select", r.schema.Name, time.Since(start))
+		return page, false, err
+	}
+
+	// id как второй ключ, чтобы порядок страниц был стабильным
+	order := strings.ToUpper(query.Order)
+	orderBy := "ORDER BY " + query.Sort + " " + order + ", id " + order + r.schema.OrderSuffix
+	limit := fmt.Sprintf("LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, query.PerPage, (query.Page-1)*query.PerPage)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+strings.Join(r.schema.Columns, ", ")+`
+		FROM `+table+`
+		`+where+`
+		`+orderBy+`
+		`+limit, args...)
+
+	metrics.RecordDatabaseQuery("select", r.schema.Name, time.Since(start))
+
+	if err != nil {
+		return page, false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item T
+		if err := rows.Scan(r.schema.Fields(&item)...); err != nil {
+			return page, false, err
+		}
+		page.Items = append(page.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return page, false, err
+	}
+
+	// Сохраняем в кэш на 5 минут
+	r.cache.Set(cacheKey, page, 5*time.Minute)
+
+	return page, false, nil
+}
+
+func (r *PostgresRepository[T]) Get(ctx context.Context, id int) (T, bool, error) {
+	start := time.Now()
+	cacheKey := r.schema.itemKey(ctx, id)
+
+	// Пробуем получить из кэша
+	var item T
+	if err := r.cache.Get(cacheKey, &item); err == nil {
+		metrics.RecordDatabaseQuery("cache_hit", r.schema.Name, time.Since(start))
+		return item, true, nil
+	}
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT `+strings.Join(r.schema.Columns, ", ")+`
+		FROM `+r.schema.source(ctx)+` WHERE id = $1`+r.schema.andLive()+`
+	`, id).Scan(r.schema.Fields(&item)...)
+
+	metrics.RecordDatabaseQuery("select", r.schema.Name, time.Since(start))
+
+	if err == sql.ErrNoRows {
+		return item, false, ErrNotFound
+	} else if err != nil {
+		return item, false, err
+	}
+
+	// Сохраняем в кэш на 10 минут
+	r.cache.Set(cacheKey, item, 10*time.Minute)
+
+	return item, false, nil
+}
+
+func (r *PostgresRepository[T]) Create(ctx context.Context, fields Fields) (T, error) {
+	fields = r.importExternalImage(ctx, fields)
+	start := time.Now()
+
+	var item T
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		fields, _, err := r.withSlug(ctx, tx, fields, 0)
+		if err != nil {
+			return err
+		}
+		fields, err = r.withMedia(ctx, tx, fields)
+		if err != nil {
+			return err
+		}
+
+		columns := fields.columns()
+		placeholders := make([]string, len(columns))
+		args := make([]interface{}, len(columns))
+		for i, column := range columns {
+			placeholders[i] = "$" + strconv.Itoa(i+1)
+			args[i] = r.schema.arg(fields, column)
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO `+r.schema.Table+` (`+strings.Join(columns, ", ")+`, created_at, update_at)
+			VALUES (`+strings.Join(placeholders, ", ")+`, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+			RETURNING `+strings.Join(r.schema.Columns, ", "),
+			args...).Scan(r.schema.Fields(&item)...)
+
+		metrics.RecordDatabaseQuery("insert", r.schema.Name, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditCreate, r.schema.Name, r.schema.id(&item), nil, item)
+	})
+	if err != nil {
+		return item, err
+	}
+
+	// Инвалидируем кэш списков при создании новой записи
+	r.invalidate(0)
+
+	return item, nil
+}
+
+func (r *PostgresRepository[T]) Update(ctx context.Context, id int, fields Fields) (T, error) {
+	fields = r.importExternalImage(ctx, fields)
+	start := time.Now()
+
+	var item T
+	renamed := false
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		// Блокируем строку, чтобы before в журнале соответствовал тому, что перезаписываем
+		var before T
+		err := tx.QueryRowContext(ctx, `
+			SELECT `+strings.Join(r.schema.Columns, ", ")+`
+			FROM `+r.schema.Table+` WHERE id = $1`+r.schema.andLive()+` FOR UPDATE
+		`, id).Scan(r.schema.Fields(&before)...)
+		if err != nil {
+			return err
+		}
+
+		fields, renamed, err = r.withSlug(ctx, tx, fields, id)
+		if err != nil {
+			return err
+		}
+		fields, err = r.withMedia(ctx, tx, fields)
+		if err != nil {
+			return err
+		}
+
+		columns := fields.columns()
+		assignments := make([]string, len(columns))
+		args := make([]interface{}, 0, len(columns)+1)
+		for i, column := range columns {
+			assignments[i] = column + " = $" + strconv.Itoa(i+1)
+			args = append(args, r.schema.arg(fields, column))
+		}
+		args = append(args, id)
+
+		err = tx.QueryRowContext(ctx, `
+			UPDATE `+r.schema.Table+`
+			SET `+strings.Join(append(assignments, "update_at = CURRENT_TIMESTAMP"), ", ")+`
+			WHERE id = $`+strconv.Itoa(len(args))+`
+			RETURNING `+strings.Join(r.schema.Columns, ", "),
+			args...).Scan(r.schema.Fields(&item)...)
+
+		metrics.RecordDatabaseQuery("update", r.schema.Name, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+		if err := r.saveRevision(ctx, tx, id, before); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditUpdate, r.schema.Name, id, before, item)
+	})
+	if err == sql.ErrNoRows {
+		return item, ErrNotFound
+	} else if err != nil {
+		return item, err
+	}
+
+	// Инвалидируем кэш списков и самой записи
+	r.invalidate(id)
+	if renamed {
+		r.invalidateSlugs()
+	}
+
+	return item, nil
+}
+
+func (r *PostgresRepository[T]) Delete(ctx context.Context, id int) error {
+	start := time.Now()
+	statement := `DELETE FROM ` + r.schema.Table + ` WHERE id = $1`
+	if r.schema.SoftDelete {
+		statement = `UPDATE ` + r.schema.Table + ` SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL`
+	}
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var before T
+		err := tx.QueryRowContext(ctx, statement+`
+			RETURNING `+strings.Join(r.schema.Columns, ", "),
+			id).Scan(r.schema.Fields(&before)...)
+
+		metrics.RecordDatabaseQuery("delete", r.schema.Name, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditDelete, r.schema.Name, id, before, nil)
+	})
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	// Инвалидируем кэш списков и самой записи
+	r.invalidate(id)
+
+	return nil
+}
+
+// Transition меняет status, если текущий входит в from; fields записываются вместе со статусом
+func (r *PostgresRepository[T]) Transition(ctx context.Context, id int, to string, from []string, fields Fields) (T, error) {
+	start := time.Now()
+	columns := fields.columns()
+	assignments := []string{"status = $1"}
+	args := []interface{}{to}
+	for _, column := range columns {
+		args = append(args, r.schema.arg(fields, column))
+		assignments = append(assignments, column+" = $"+strconv.Itoa(len(args)))
+	}
+	args = append(args, id, pq.Array(from))
+
+	var item T
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var before T
+		err := tx.QueryRowContext(ctx, `
+			SELECT `+strings.Join(r.schema.Columns, ", ")+`
+			FROM `+r.schema.Table+` WHERE id = $1`+r.schema.andLive()+` FOR UPDATE
+		`, id).Scan(r.schema.Fields(&before)...)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			UPDATE `+r.schema.Table+`
+			SET `+strings.Join(append(assignments, "update_at = CURRENT_TIMESTAMP"), ", ")+`
+			WHERE id = $`+strconv.Itoa(len(args)-1)+` AND status = ANY($`+strconv.Itoa(len(args))+`)
+			RETURNING `+strings.Join(r.schema.Columns, ", "),
+			args...).Scan(r.schema.Fields(&item)...)
+
+		metrics.RecordDatabaseQuery("update", r.schema.Name, time.Since(start))
+
+		// Строка точно существует (заблокирована выше), значит не подошел текущий статус
+		if err == sql.ErrNoRows {
+			return ErrInvalidTransition
+		} else if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditUpdate, r.schema.Name, id, before, item)
+	})
+	if err == sql.ErrNoRows {
+		return item, ErrNotFound
+	} else if err != nil {
+		return item, err
+	}
+
+	r.invalidate(id)
+
+	if to == models.StatusPublished && r.published != nil {
+		r.published(ctx, item)
+	}
+
+	return item, nil
+}
+
+// ApplySchedule публикует записи с наступившим publish_at и архивирует с наступившим unpublish_at.
+// Сработавшее время обнуляется, чтобы повторное открытие черновика не публиковало его снова
+func (r *PostgresRepository[T]) ApplySchedule(ctx context.Context, now time.Time) (int, error) {
+	if !r.schema.Scheduled {
+		return 0, nil
+	}
+
+	start := time.Now()
+	type change struct {
+		id   int
+		from string
+		to   string
+		item T
+	}
+	var changes []change
+	columns := make([]string, len(r.schema.Columns))
+	for i, column := range r.schema.Columns {
+		columns[i] = "t." + column
+	}
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		steps := []struct {
+			to, column, condition string
+		}{
+			{models.StatusPublished, "publish_at", "status IN ('draft', 'in_review')"},
+			{models.StatusArchived, "unpublish_at", "status = 'published'"},
+		}
+
+		for _, step := range steps {
+			rows, err := tx.QueryContext(ctx, `
+				UPDATE `+r.schema.Table+` AS t
+				SET status = $1, `+step.column+` = NULL, update_at = CURRENT_TIMESTAMP
+				FROM (
+					SELECT id, status FROM `+r.schema.Table+`
+					WHERE `+step.condition+` AND `+step.column+` <= $2`+r.schema.andLive()+`
+					FOR UPDATE
+				) AS old
+				WHERE t.id = old.id
+				RETURNING old.status, `+strings.Join(columns, ", ")+`
+			`, step.to, now)
+			if err != nil {
+				return err
+			}
+
+			for rows.Next() {
+				c := change{to: step.to}
+				if err := rows.Scan(append([]interface{}{&c.from}, r.schema.Fields(&c.item)...)...); err != nil {
+					rows.Close()
+					return err
+				}
+				c.id = r.schema.id(&c.item)
+				changes = append(changes, c)
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+		}
+
+		for _, c := range changes {
+			before := map[string]string{"status": c.from}
+			after := map[string]string{"status": c.to}
+			if err := recordAudit(ctx, tx, AuditUpdate, r.schema.Name, c.id, before, after); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	metrics.RecordDatabaseQuery("update", r.schema.Name, time.Since(start))
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, c := range changes {
+		r.invalidate(c.id)
+	}
+	if r.published != nil {
+		// Запись, у которой за один проход наступили и publish_at, и unpublish_at, уже в архиве
+		archived := map[int]bool{}
+		for _, c := range changes {
+			archived[c.id] = archived[c.id] || c.to == models.StatusArchived
+		}
+		for _, c := range changes {
+			if c.to == models.StatusPublished && !archived[c.id] {
+				r.published(ctx, c.item)
+			}
+		}
+	}
+	return len(changes), nil
+}
+
+// Restore возвращает запись из корзины
+func (r *PostgresRepository[T]) Restore(ctx context.Context, id int) (T, error) {
+	start := time.Now()
+	var item T
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			UPDATE `+r.schema.Table+` SET deleted_at = NULL
+			WHERE id = $1 AND deleted_at IS NOT NULL
+			RETURNING `+strings.Join(r.schema.Columns, ", "),
+			id).Scan(r.schema.Fields(&item)...)
+
+		metrics.RecordDatabaseQuery("update", r.schema.Name, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditRestore, r.schema.Name, id, nil, item)
+	})
+	if err == sql.ErrNoRows {
+		return item, ErrNotFound
+	} else if err != nil {
+		return item, err
+	}
+
+	r.invalidate(id)
+
+	return item, nil
+}
+
+// Purge окончательно удаляет записи, лежащие в корзине дольше retention
+func (r *PostgresRepository[T]) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	start := time.Now()
+	var ids []int
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `
+			DELETE FROM `+r.schema.Table+`
+			WHERE deleted_at IS NOT NULL AND deleted_at < $1
+			RETURNING id
+		`, deletedBefore)
+
+		metrics.RecordDatabaseQuery("delete", r.schema.Name, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := recordAudit(ctx, tx, AuditPurge, r.schema.Name, id, nil, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	// Те же ключи, что и при Delete: списки, зависимые кэши и кэш каждой удаленной записи
+	r.invalidate(0)
+	for _, id := range ids {
+		r.invalidateItem(id)
+	}
+
+	return len(ids), nil
+}
+
+// inTx выполняет изменение и запись в журнал одной транзакцией
+func (r *PostgresRepository[T]) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// invalidate сбрасывает списки, зависимые ключи и, если id задан, кэш самой записи
+func (r *PostgresRepository[T]) invalidate(id int) {
+	r.cache.DeletePattern(r.schema.ListKey + "*")
+	for _, pattern := range r.schema.Invalidates {
+		r.cache.DeletePattern(pattern)
+	}
+	if id > 0 {
+		r.invalidateItem(id)
+	}
+}
+
+// invalidateItem ключи одной записи: сама запись, ее переводы и вложенные списки
+func (r *PostgresRepository[T]) invalidateItem(id int) {
+	r.cache.Delete(r.schema.ItemKey + strconv.Itoa(id))
+	if r.schema.Localized != nil {
+		r.cache.DeletePattern(r.schema.ItemKey + strconv.Itoa(id) + "@*")
+	}
+	if r.schema.Gallery != "" {
+		r.cache.Delete(r.schema.galleryKey(id))
+	}
+	if r.schema.Tags != "" {
+		r.cache.Delete(r.schema.tagsKey(id))
+	}
+	if r.schema.Team != "" {
+		r.cache.Delete(r.schema.teamKey(id))
+	}
+	if r.schema.Memberships {
+		r.cache.Delete(memberProjectsKey(id))
+	}
+}
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ASMO-site-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotFound      = errors.New("record not found")
+	ErrAlreadyExists = errors.New("record already exists")
+	// ErrInvalidTransition текущий статус записи не допускает запрошенный переход
+	ErrInvalidTransition = errors.New("invalid status transition")
+)
+
+const (
+	defaultPage    = 1
+	defaultPerPage = 20
+)
+
+// Роли PostgresRepository: хэндлеры и их общие хелперы зависят только от нужной роли,
+// а не от всего набора методов
+
+// Lister постраничный список с фильтрами
+type Lister[T any] interface {
+	List(ctx context.Context, query ListQuery) (Page[T], bool, error)
+}
+
+// CRUD чтение и изменение одной записи, включая корзину и поиск по slug
+type CRUD[T any] interface {
+	Lister[T]
+	Get(ctx context.Context, id int) (T, bool, error)
+	ResolveSlug(ctx context.Context, slug string) (int, string, error)
+	Create(ctx context.Context, fields Fields) (T, error)
+	Update(ctx context.Context, id int, fields Fields) (T, error)
+	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (T, error)
+}
+
+// Workflow переходы между статусами публикации
+type Workflow[T any] interface {
+	Transition(ctx context.Context, id int, to string, from []string, fields Fields) (T, error)
+}
+
+// Revisions история изменений записи и откат к ревизии
+type Revisions[T any] interface {
+	Revisions(ctx context.Context, id int) ([]models.Revision, error)
+	Revision(ctx context.Context, id, rev int) (models.Revision, error)
+	RestoreRevision(ctx context.Context, id, rev int) (T, error)
+}
+
+// Translations переводы переводимых полей записи
+type Translations interface {
+	Translations(ctx context.Context, id int) ([]models.Translation, error)
+	SetTranslation(ctx context.Context, id int, locale string, fields Fields) (models.Translation, error)
+	DeleteTranslation(ctx context.Context, id int, locale string) error
+}
+
+// Gallery галерея проекта
+type Gallery interface {
+	Gallery(ctx context.Context, id int) ([]models.ProjectMedia, error)
+	AddGalleryItem(ctx context.Context, id int, item models.ProjectMedia) (models.ProjectMedia, error)
+	RemoveGalleryItem(ctx context.Context, id, itemID int) error
+	ReorderGallery(ctx context.Context, id int, itemIDs []int) ([]models.ProjectMedia, error)
+}
+
+// Tags теги проекта
+type Tags interface {
+	Tags(ctx context.Context, id int) ([]models.Tag, error)
+	SetTags(ctx context.Context, id int, slugs []string) ([]models.Tag, error)
+}
+
+// Team команда проекта
+type Team interface {
+	Team(ctx context.Context, id int) ([]models.TeamMember, error)
+	AssignTeamMember(ctx context.Context, id, staffID int, role string) (models.TeamMember, error)
+	UnassignTeamMember(ctx context.Context, id, staffID int) error
+}
+
+// Roster порядок вывода сотрудников и их проекты
+type Roster interface {
+	MemberProjects(ctx context.Context, id int) ([]models.StaffProject, error)
+	Reorder(ctx context.Context, ids []int) error
+}
+
+// Projects роли, которые нужны хэндлеру проектов одного типа
+type Projects[T any] interface {
+	CRUD[T]
+	Workflow[T]
+	Revisions[T]
+	Translations
+	Gallery
+	Tags
+	Team
+}
+
+// StaffDirectory роли, которые нужны хэндлеру сотрудников
+type StaffDirectory interface {
+	CRUD[models.Staff]
+	Workflow[models.Staff]
+	Revisions[models.Staff]
+	Translations
+	Roster
+}
+
+// Fields значения колонок для INSERT/UPDATE
+type Fields map[string]interface{}
+
+// Optional добавляет значение только если оно задано (используется для PATCH)
+func Optional[V any](fields Fields, column string, value *V) {
+	if value != nil {
+		fields[column] = *value
+	}
+}
+
+// arg значение колонки для запроса: списки строк передаются массивом Postgres.
+// []interface{} приходит из снимка ревизии при откате
+func (f Fields) arg(column string) interface{} {
+	switch value := f[column].(type) {
+	case []string:
+		if value == nil {
+			value = []string{}
+		}
+		return pq.Array(value)
+	case []interface{}:
+		return pq.Array(value)
+	}
+	return f[column]
+}
+
+// arg значение колонки с учетом JSONB-колонок схемы: и срез из запроса, и []interface{}
+// из снимка ревизии записываются JSON-текстом
+func (s Schema[T]) arg(fields Fields, column string) interface{} {
+	if !slices.Contains(s.JSON, column) {
+		return fields.arg(column)
+	}
+	data, err := json.Marshal(fields[column])
+	if err != nil {
+		return fields[column]
+	}
+	return string(data)
+}
+
+// stringList сканирует TEXT[] в срез; пустой массив дает [], а не null в JSON
+type stringList struct {
+	dest *[]string
+}
+
+func (l stringList) Scan(src interface{}) error {
+	if err := pq.Array(l.dest).Scan(src); err != nil {
+		return err
+	}
+	if *l.dest == nil {
+		*l.dest = []string{}
+	}
+	return nil
+}
+
+// columns возвращает колонки в стабильном порядке, чтобы SQL не менялся от запуска к запуску
+func (f Fields) columns() []string {
+	columns := make([]string, 0, len(f))
+	for column := range f {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// Filter условие WHERE; $? в Condition заменяется номером аргумента
+type Filter struct {
+	Name      string
+	Condition string
+	Value     interface{}
+}
+
+// whereClause собирает WHERE из фильтров и аргументы для него; fixed - условия без аргументов
+func whereClause(filters []Filter, fixed ...string) (string, []interface{}) {
+	conditions := append([]string{}, fixed...)
+	var args []interface{}
+	for _, filter := range filters {
+		args = append(args, filter.Value)
+		conditions = append(conditions, strings.Replace(filter.Condition, "$?", "$"+strconv.Itoa(len(args)), 1))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+type ListQuery struct {
+	Page    int
+	PerPage int
+	Sort    string
+	Order   string
+	Filters []Filter
+	// Tags slug тегов без повторов: запись должна иметь их все
+	Tags []string
+}
+
+// Page страница списка вместе с общим количеством записей, в таком виде кладется в кэш
+type Page[T any] struct {
+	Items   []T `json:"items"`
+	Total   int `json:"total"`
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+// normalized подставляет значения по умолчанию и отбрасывает неизвестные поля сортировки
+func (q ListQuery) normalized(sortable []string) ListQuery {
+	if q.Page < 1 {
+		q.Page = defaultPage
+	}
+	if q.PerPage < 1 {
+		q.PerPage = defaultPerPage
+	}
+	if !slices.Contains(sortable, q.Sort) {
+		q.Sort = "created_at"
+	}
+	if q.Order != "asc" {
+		q.Order = "desc"
+	}
+	return q
+}
+
+// cacheKey ключ страницы, например "web_projects:all?order=desc&page=2&per_page=20&sort=created_at".
+// url.Values.Encode сортирует параметры, поэтому одинаковые запросы дают одинаковый ключ.
+func (q ListQuery) cacheKey(prefix string) string {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(q.Page))
+	values.Set("per_page", strconv.Itoa(q.PerPage))
+	values.Set("sort", q.Sort)
+	values.Set("order", q.Order)
+	for _, filter := range q.Filters {
+		values.Set(filter.Name, toString(filter.Value))
+	}
+	if len(q.Tags) > 0 {
+		values.Set("tags", strings.Join(q.Tags, ","))
+	}
+	return prefix + "?" + values.Encode()
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// isUniqueViolation проверяет нарушение UNIQUE-ограничения в Postgres (код 23505)
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
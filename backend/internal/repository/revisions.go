@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+)
+
+// saveRevision сохраняет версию записи до изменения; вызывается внутри транзакции Update,
+// строка уже заблокирована, поэтому номер ревизии не может задвоиться
+func (r *PostgresRepository[T]) saveRevision(ctx context.Context, tx *sql.Tx, id int, before T) error {
+	if r.schema.Revisions == "" {
+		return nil
+	}
+
+	start := time.Now()
+	snapshot, err := json.Marshal(before)
+	if err != nil {
+		return err
+	}
+
+	actor := actorFrom(ctx)
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO `+r.schema.Revisions+` (record_id, revision, snapshot, created_by, created_by_name)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3, $4
+		FROM `+r.schema.Revisions+` WHERE record_id = $1
+	`, id, string(snapshot), nullableID(actor.UserID), actor.Name)
+
+	metrics.RecordDatabaseQuery("insert", r.schema.Revisions, time.Since(start))
+
+	return err
+}
+
+// Revisions список версий записи без снимков, новые первыми
+func (r *PostgresRepository[T]) Revisions(ctx context.Context, id int) ([]models.Revision, error) {
+	start := time.Now()
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT revision, created_by, created_by_name, created_at
+		FROM `+r.schema.Revisions+`
+		WHERE record_id = $1
+		ORDER BY revision DESC
+	`, id)
+
+	metrics.RecordDatabaseQuery("select", r.schema.Revisions, time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []models.Revision{}
+	for rows.Next() {
+		var revision models.Revision
+		if err := rows.Scan(&revision.Revision, &revision.CreatedBy, &revision.CreatedByName, &revision.CreatedAt); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
+
+func (r *PostgresRepository[T]) Revision(ctx context.Context, id, rev int) (models.Revision, error) {
+	start := time.Now()
+	var revision models.Revision
+	var snapshot []byte
+	err := r.db.QueryRowContext(ctx, `
+		SELECT revision, created_by, created_by_name, created_at, snapshot
+		FROM `+r.schema.Revisions+`
+		WHERE record_id = $1 AND revision = $2
+	`, id, rev).Scan(&revision.Revision, &revision.CreatedBy, &revision.CreatedByName, &revision.CreatedAt, &snapshot)
+
+	metrics.RecordDatabaseQuery("select", r.schema.Revisions, time.Since(start))
+
+	if err == sql.ErrNoRows {
+		return revision, ErrNotFound
+	} else if err != nil {
+		return revision, err
+	}
+
+	revision.Snapshot = json.RawMessage(snapshot)
+	return revision, nil
+}
+
+// RestoreRevision возвращает редактируемые поля из снимка; статус, публикация и даты не меняются.
+// Откат - обычное изменение, поэтому текущая версия тоже попадает в историю
+func (r *PostgresRepository[T]) RestoreRevision(ctx context.Context, id, rev int) (T, error) {
+	revision, err := r.Revision(ctx, id, rev)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	var snapshot map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(revision.Snapshot))
+	decoder.UseNumber()
+	if err := decoder.Decode(&snapshot); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	fields := Fields{}
+	for _, column := range r.schema.Editable {
+		if value, ok := snapshot[column]; ok {
+			fields[column] = value
+		}
+	}
+	return r.Update(ctx, id, fields)
+}
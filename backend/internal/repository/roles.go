@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var ErrUnknownRole = errors.New("unknown role")
+
+// RolesRepository роли, права и их назначение администраторам
+type RolesRepository struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+func NewRolesRepository(db *sql.DB, cache cache.Cache) *RolesRepository {
+	return &RolesRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func permissionsCacheKey(userID int) string {
+	return "admin_permissions:" + strconv.Itoa(userID)
+}
+
+// Permissions все права пользователя через его роли; результат кэшируется,
+// потому что вызывается на каждый изменяющий запрос
+func (r *RolesRepository) Permissions(ctx context.Context, userID int) ([]string, error) {
+	start := time.Now()
+	cacheKey := permissionsCacheKey(userID)
+
+	var permissions []string
+	if err := r.cache.Get(cacheKey, &permissions); err == nil {
+		metrics.RecordDatabaseQuery("cache_hit", "permissions", time.Since(start))
+		return permissions, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT DISTINCT p.name
+		FROM admin_user_roles ur
+		JOIN role_permissions rp ON rp.role_id = ur.role_id
+		JOIN permissions p ON p.id = rp.permission_id
+		WHERE ur.user_id = $1
+		ORDER BY p.name
+	`, userID)
+
+	metrics.RecordDatabaseQuery("select", "permissions", time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions = []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Сохраняем в кэш на 5 минут
+	r.cache.Set(cacheKey, permissions, 5*time.Minute)
+
+	return permissions, nil
+}
+
+func (r *RolesRepository) List(ctx context.Context) ([]models.Role, error) {
+	start := time.Now()
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT r.id, r.name, r.description,
+			COALESCE(array_agg(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL), '{}')
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		LEFT JOIN permissions p ON p.id = rp.permission_id
+		GROUP BY r.id
+		ORDER BY r.name
+	`)
+
+	metrics.RecordDatabaseQuery("select", "roles", time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []models.Role{}
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role.ID, &role.Name, &role.Description, pq.Array(&role.Permissions)); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+// SetUserRoles заменяет роли пользователя; неизвестное имя роли откатывает всю операцию
+func (r *RolesRepository) SetUserRoles(ctx context.Context, userID int, roles []string) error {
+	start := time.Now()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM admin_user_roles WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	err = insertUserRoles(ctx, tx, userID, roles)
+
+	metrics.RecordDatabaseQuery("update", "admin_user_roles", time.Since(start))
+
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	r.cache.Delete(permissionsCacheKey(userID))
+	return nil
+}
+
+// insertUserRoles назначает роли по именам внутри транзакции; неизвестное имя - ErrUnknownRole
+func insertUserRoles(ctx context.Context, tx *sql.Tx, userID int, roles []string) error {
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO admin_user_roles (user_id, role_id)
+		SELECT $1, id FROM roles WHERE name = ANY($2)
+	`, userID, pq.Array(roles))
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if int(affected) != len(uniqueStrings(roles)) {
+		return ErrUnknownRole
+	}
+	return nil
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
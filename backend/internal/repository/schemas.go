@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/models"
+)
+
+var (
+	projectColumns  = []string{"id", "name", "slug", "description", "img", "media_id", "images", "price", "time_develop", "status", "approved_by", "approved_at", "publish_at", "unpublish_at", "created_at", "update_at"}
+	projectSortable = []string{"name", "price", "time_develop", "created_at"}
+	projectEditable = []string{"name", "description", "img", "media_id", "price", "time_develop"}
+	staffColumns    = []string{"id", "name", "slug", "description", "img", "media_id", "images", "role", "department", "skills", "email", "show_email", "github_url", "linkedin_url", "telegram_url", "display_order", "status", "approved_by", "approved_at", "created_at", "update_at"}
+
+	// Поля своего типа проекта идут после общих; каталог читает только общие
+	webColumns     = append(slices.Clone(projectColumns), "live_url", "stack")
+	webEditable    = append(slices.Clone(projectEditable), "live_url", "stack")
+	mobileColumns  = append(slices.Clone(projectColumns), "app_store_url", "google_play_url", "platforms")
+	mobileEditable = append(slices.Clone(projectEditable), "app_store_url", "google_play_url", "platforms")
+	botColumns     = append(slices.Clone(projectColumns), "platform", "handle")
+	botEditable    = append(slices.Clone(projectEditable), "platform", "handle")
+)
+
+// projectInvalidates ключи, зависящие от любой таблицы проектов
+// (проекты сотрудников показывают имя и статус проекта)
+var projectInvalidates = []string{"projects:all*", "search:*", "tags:cloud*", "staff:*:projects"}
+
+func NewWebProjectsRepository(db *sql.DB, cache cache.Cache) *PostgresRepository[models.WebProjects] {
+	return NewPostgresRepository(db, cache, Schema[models.WebProjects]{
+		Name:    "web_projects",
+		Table:   "web_projects",
+		Columns: webColumns,
+		Fields: func(p *models.WebProjects) []interface{} {
+			return []interface{}{&p.ID, &p.Name, &p.Slug, &p.Description, &p.Img, &p.MediaID, jsonValue{&p.Images}, &p.Price, &p.TimeDevelop, &p.Status, &p.ApprovedBy, &p.ApprovedAt, &p.PublishAt, &p.UnpublishAt, &p.CreatedAt, &p.UpdateAt, &p.LiveURL, stringList{&p.Stack}}
+		},
+		Sortable:     projectSortable,
+		ListKey:      "web_projects:all",
+		ItemKey:      "web_project:",
+		Invalidates:  projectInvalidates,
+		SoftDelete:   true,
+		Scheduled:    true,
+		Revisions:    "web_projects_revisions",
+		Redirects:    "web_projects_slug_redirects",
+		Translations: "web_projects_translations",
+		Gallery:      "web_projects_media",
+		Tags:         "web_projects_tags",
+		Team:         "web_projects_team",
+		Localized:    localizedTable("web_projects", webColumns),
+		Editable:     webEditable,
+	})
+}
+
+func NewMobileProjectsRepository(db *sql.DB, cache cache.Cache) *PostgresRepository[models.MobileProjects] {
+	return NewPostgresRepository(db, cache, Schema[models.MobileProjects]{
+		Name:    "mobile_projects",
+		Table:   "mobile_projects",
+		Columns: mobileColumns,
+		Fields: func(p *models.MobileProjects) []interface{} {
+			return []interface{}{&p.ID, &p.Name, &p.Slug, &p.Description, &p.Img, &p.MediaID, jsonValue{&p.Images}, &p.Price, &p.TimeDevelop, &p.Status, &p.ApprovedBy, &p.ApprovedAt, &p.PublishAt, &p.UnpublishAt, &p.CreatedAt, &p.UpdateAt, &p.AppStoreURL, &p.GooglePlayURL, stringList{&p.Platforms}}
+		},
+		Sortable:     projectSortable,
+		ListKey:      "mobile_projects:all",
+		ItemKey:      "mobile_project:",
+		Invalidates:  projectInvalidates,
+		SoftDelete:   true,
+		Scheduled:    true,
+		Revisions:    "mobile_projects_revisions",
+		Redirects:    "mobile_projects_slug_redirects",
+		Translations: "mobile_projects_translations",
+		Gallery:      "mobile_projects_media",
+		Tags:         "mobile_projects_tags",
+		Team:         "mobile_projects_team",
+		Localized:    localizedTable("mobile_projects", mobileColumns),
+		Editable:     mobileEditable,
+	})
+}
+
+func NewBotProjectsRepository(db *sql.DB, cache cache.Cache) *PostgresRepository[models.BotsProjects] {
+	return NewPostgresRepository(db, cache, Schema[models.BotsProjects]{
+		Name:    "bots_projects",
+		Table:   "bots_projects",
+		Columns: botColumns,
+		Fields: func(p *models.BotsProjects) []interface{} {
+			return []interface{}{&p.ID, &p.Name, &p.Slug, &p.Description, &p.Img, &p.MediaID, jsonValue{&p.Images}, &p.Price, &p.TimeDevelop, &p.Status, &p.ApprovedBy, &p.ApprovedAt, &p.PublishAt, &p.UnpublishAt, &p.CreatedAt, &p.UpdateAt, &p.Platform, &p.Handle}
+		},
+		Sortable:     projectSortable,
+		ListKey:      "bot_projects:all",
+		ItemKey:      "bot_project:",
+		Invalidates:  projectInvalidates,
+		SoftDelete:   true,
+		Scheduled:    true,
+		Revisions:    "bots_projects_revisions",
+		Redirects:    "bots_projects_slug_redirects",
+		Translations: "bots_projects_translations",
+		Gallery:      "bots_projects_media",
+		Tags:         "bots_projects_tags",
+		Team:         "bots_projects_team",
+		Localized:    localizedTable("bots_projects", botColumns),
+		Editable:     botEditable,
+	})
+}
+
+func NewStaffRepository(db *sql.DB, cache cache.Cache) *PostgresRepository[models.Staff] {
+	return NewPostgresRepository(db, cache, Schema[models.Staff]{
+		Name:    "staff",
+		Table:   "staff",
+		Columns: staffColumns,
+		Fields: func(m *models.Staff) []interface{} {
+			return []interface{}{&m.ID, &m.Name, &m.Slug, &m.Description, &m.Img, &m.MediaID, jsonValue{&m.Images}, &m.Role, &m.Department, jsonValue{&m.Skills}, &m.Email, &m.ShowEmail, &m.GitHubURL, &m.LinkedInURL, &m.TelegramURL, &m.DisplayOrder, &m.Status, &m.ApprovedBy, &m.ApprovedAt, &m.CreatedAt, &m.UpdateAt}
+		},
+		Sortable:     []string{"name", "role", "department", "display_order", "created_at"},
+		ListKey:      "staff:all",
+		ItemKey:      "staff:",
+		Invalidates:  []string{"search:*", "*:team"},
+		SoftDelete:   true,
+		Revisions:    "staff_revisions",
+		Redirects:    "staff_slug_redirects",
+		Translations: "staff_translations",
+		Memberships:  true,
+		JSON:         []string{"skills"},
+		Ordered:      true,
+		Localized:    localizedTable("staff", staffColumns),
+		Editable:     []string{"name", "description", "img", "media_id", "role", "department", "skills", "email", "show_email", "github_url", "linkedin_url", "telegram_url"},
+	})
+}
+
+// catalogSource объединение трех таблиц проектов с меткой типа; каталог публичный, поэтому только published.
+// from подставляет таблицу или ее подзапрос с переводом
+func catalogSource(from func(table string) string) string {
+	branches := make([]string, 0, 3)
+	for _, t := range []struct{ typ, table string }{{"web", "web_projects"}, {"mobile", "mobile_projects"}, {"bot", "bots_projects"}} {
+		branches = append(branches, `SELECT '`+t.typ+`' AS type, id, name, slug, description, img, media_id, images, price, time_develop, status, approved_by, approved_at, publish_at, unpublish_at, created_at, update_at
+	FROM `+from(t.table)+` WHERE deleted_at IS NULL AND status = 'published'`)
+	}
+	return "(\n\t" + strings.Join(branches, "\n\tUNION ALL\n\t") + "\n) AS projects"
+}
+
+// NewProjectsCatalogRepository только для чтения списка: id в разных таблицах пересекаются,
+// поэтому Get/Update/Delete по каталогу не имеют смысла
+func NewProjectsCatalogRepository(db *sql.DB, cache cache.Cache) *PostgresRepository[models.Project] {
+	return NewPostgresRepository(db, cache, Schema[models.Project]{
+		Name:    "projects",
+		Table:   catalogSource(func(table string) string { return table }),
+		Columns: append([]string{"type"}, projectColumns...),
+		Fields: func(p *models.Project) []interface{} {
+			return []interface{}{&p.Type, &p.ID, &p.Name, &p.Slug, &p.Description, &p.Img, &p.MediaID, jsonValue{&p.Images}, &p.Price, &p.TimeDevelop, &p.Status, &p.ApprovedBy, &p.ApprovedAt, &p.PublishAt, &p.UnpublishAt, &p.CreatedAt, &p.UpdateAt}
+		},
+		Sortable:    projectSortable,
+		OrderSuffix: ", type",
+		ListKey:     "projects:all",
+		Tags:        catalogTags,
+		TagsByType:  true,
+		Localized: func(locale string) string {
+			return catalogSource(func(table string) string { return localizedTable(table, projectColumns)(locale) })
+		},
+	})
+}
+
+var (
+	_ Projects[models.WebProjects]    = (*PostgresRepository[models.WebProjects])(nil)
+	_ Projects[models.MobileProjects] = (*PostgresRepository[models.MobileProjects])(nil)
+	_ Projects[models.BotsProjects]   = (*PostgresRepository[models.BotsProjects])(nil)
+	_ StaffDirectory                  = (*PostgresRepository[models.Staff])(nil)
+	_ Lister[models.Project]          = (*PostgresRepository[models.Project])(nil)
+)
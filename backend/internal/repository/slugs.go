@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/slug"
+)
+
+// slugTarget запись, на которую указывает slug, и ее текущий slug
+type slugTarget struct {
+	ID   int    `json:"id"`
+	Slug string `json:"slug"`
+}
+
+// withSlug добавляет в fields slug из name. Для существующей записи (id > 0) старый slug
+// уходит в таблицу редиректов; renamed сообщает, что кэш slug нужно сбросить
+func (r *PostgresRepository[T]) withSlug(ctx context.Context, tx *sql.Tx, fields Fields, id int) (Fields, bool, error) {
+	name, ok := fields["name"].(string)
+	if r.schema.Redirects == "" || !ok {
+		return fields, false, nil
+	}
+
+	next, err := r.uniqueSlug(ctx, tx, slug.Make(name), id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if id > 0 {
+		var current string
+		err := tx.QueryRowContext(ctx, `SELECT slug FROM `+r.schema.Table+` WHERE id = $1`, id).Scan(&current)
+		if err != nil {
+			return nil, false, err
+		}
+		if current == next {
+			return fields, false, nil
+		}
+
+		start := time.Now()
+		// Slug, к которому вернулись, больше не редирект
+		_, err = tx.ExecContext(ctx, `DELETE FROM `+r.schema.Redirects+` WHERE old_slug = $1`, next)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO `+r.schema.Redirects+` (old_slug, record_id) VALUES ($1, $2)
+				ON CONFLICT (old_slug) DO UPDATE SET record_id = EXCLUDED.record_id, created_at = CURRENT_TIMESTAMP
+			`, current, id)
+		}
+
+		metrics.RecordDatabaseQuery("insert", r.schema.Redirects, time.Since(start))
+
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	withSlug := make(Fields, len(fields)+1)
+	for column, value := range fields {
+		withSlug[column] = value
+	}
+	withSlug["slug"] = next
+	return withSlug, id > 0, nil
+}
+
+// uniqueSlug добавляет -2, -3... пока slug занят другой записью, в том числе как старый.
+// Подбор идет под advisory-блокировкой таблицы до конца транзакции: иначе два одновременных
+// создания с одинаковым названием выбрали бы один slug и второе упало бы на уникальном индексе
+func (r *PostgresRepository[T]) uniqueSlug(ctx context.Context, tx *sql.Tx, base string, id int) (string, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDatabaseQuery("select", r.schema.Redirects, time.Since(start))
+	}()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "slugs:"+r.schema.Table); err != nil {
+		return "", err
+	}
+
+	candidate := base
+	for n := 2; ; n++ {
+		var taken bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM `+r.schema.Table+` WHERE slug = $1 AND id <> $2)
+				OR EXISTS (SELECT 1 FROM `+r.schema.Redirects+` WHERE old_slug = $1 AND record_id <> $2)
+		`, candidate, id).Scan(&taken)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = base + "-" + strconv.Itoa(n)
+	}
+}
+
+// ResolveSlug возвращает id записи и ее текущий slug; для старого slug они отличаются от запрошенного
+func (r *PostgresRepository[T]) ResolveSlug(ctx context.Context, s string) (int, string, error) {
+	if r.schema.Redirects == "" {
+		return 0, "", ErrNotFound
+	}
+
+	start := time.Now()
+	cacheKey := r.schema.ItemKey + "slug:" + s
+
+	// Пробуем получить из кэша
+	var target slugTarget
+	if err := r.cache.Get(cacheKey, &target); err == nil {
+		metrics.RecordDatabaseQuery("cache_hit", r.schema.Name, time.Since(start))
+		return target.ID, target.Slug, nil
+	}
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, slug FROM `+r.schema.Table+` WHERE slug = $1
+		UNION ALL
+		SELECT t.id, t.slug FROM `+r.schema.Redirects+` AS old
+		JOIN `+r.schema.Table+` AS t ON t.id = old.record_id
+		WHERE old.old_slug = $1
+		LIMIT 1
+	`, s).Scan(&target.ID, &target.Slug)
+
+	metrics.RecordDatabaseQuery("select", r.schema.Name, time.Since(start))
+
+	if err == sql.ErrNoRows {
+		return 0, "", ErrNotFound
+	} else if err != nil {
+		return 0, "", err
+	}
+
+	// Сохраняем в кэш на 10 минут
+	r.cache.Set(cacheKey, target, 10*time.Minute)
+
+	return target.ID, target.Slug, nil
+}
+
+// invalidateSlugs сбрасывает все найденные slug: старые могли указывать на переименованный
+func (r *PostgresRepository[T]) invalidateSlugs() {
+	r.cache.DeletePattern(r.schema.ItemKey + "slug:*")
+}
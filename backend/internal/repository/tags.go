@@ -0,0 +1,376 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"ASMO-site-backend/internal/cache"
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ErrTagNotFound в списке тегов проекта есть slug, которого нет в таблице tags
+var ErrTagNotFound = errors.New("tag not found")
+
+// catalogTags связи всех трех таблиц проектов с меткой type, как в catalogSource
+const catalogTags = `(SELECT 'web' AS type, record_id, tag_id FROM web_projects_tags
+	UNION ALL SELECT 'mobile', record_id, tag_id FROM mobile_projects_tags
+	UNION ALL SELECT 'bot', record_id, tag_id FROM bots_projects_tags)`
+
+// publishedTags связи только опубликованных проектов вне корзины - из них строится облако тегов
+const publishedTags = `(SELECT l.tag_id FROM web_projects_tags AS l JOIN web_projects AS p ON p.id = l.record_id
+		WHERE p.deleted_at IS NULL AND p.status = 'published'
+	UNION ALL SELECT l.tag_id FROM mobile_projects_tags AS l JOIN mobile_projects AS p ON p.id = l.record_id
+		WHERE p.deleted_at IS NULL AND p.status = 'published'
+	UNION ALL SELECT l.tag_id FROM bots_projects_tags AS l JOIN bots_projects AS p ON p.id = l.record_id
+		WHERE p.deleted_at IS NULL AND p.status = 'published')`
+
+const tagColumns = `t.id, t.name, t.slug, t.kind, t.created_at, t.update_at`
+
+// tagInvalidates изменение тега видно в списках тегов, облаке, тегах проектов и в выборках по ?tags
+var tagInvalidates = []string{"tags:*", "*:tags", "web_projects:all*", "mobile_projects:all*", "bot_projects:all*", "projects:all*"}
+
+func tagFields(tag *models.Tag) []interface{} {
+	return []interface{}{&tag.ID, &tag.Name, &tag.Slug, &tag.Kind, &tag.CreatedAt, &tag.UpdateAt}
+}
+
+// tagsFilter условие "есть все перечисленные теги"; slugs уже без повторов
+func (s Schema[T]) tagsFilter(slugs []string) Filter {
+	key, link := "id", "l.record_id"
+	if s.TagsByType {
+		key, link = "(type, id)", "l.type, l.record_id"
+	}
+	return Filter{
+		Name: "tags",
+		Condition: key + ` IN (
+			SELECT ` + link + ` FROM ` + s.Tags + ` AS l JOIN tags AS t ON t.id = l.tag_id
+			WHERE t.slug = ANY($?)
+			GROUP BY ` + link + ` HAVING COUNT(*) = ` + strconv.Itoa(len(slugs)) + `)`,
+		Value: pq.Array(slugs),
+	}
+}
+
+func (s Schema[T]) tagsKey(id int) string {
+	return s.ItemKey + strconv.Itoa(id) + ":tags"
+}
+
+// Tags теги записи по имени; кэшируются отдельно от записи
+func (r *PostgresRepository[T]) Tags(ctx context.Context, id int) ([]models.Tag, error) {
+	if r.schema.Tags == "" || r.schema.TagsByType {
+		return []models.Tag{}, nil
+	}
+
+	start := time.Now()
+	cacheKey := r.schema.tagsKey(id)
+
+	var tags []models.Tag
+	if err := r.cache.Get(cacheKey, &tags); err == nil {
+		metrics.RecordDatabaseQuery("cache_hit", r.schema.Tags, time.Since(start))
+		return tags, nil
+	}
+
+	tags, err := r.tags(ctx, r.db, id)
+
+	metrics.RecordDatabaseQuery("select", r.schema.Tags, time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(cacheKey, tags, 10*time.Minute)
+	return tags, nil
+}
+
+// SetTags заменяет теги записи списком slug; неизвестный slug - ErrTagNotFound
+func (r *PostgresRepository[T]) SetTags(ctx context.Context, id int, slugs []string) ([]models.Tag, error) {
+	if r.schema.Tags == "" || r.schema.TagsByType {
+		return nil, ErrNotFound
+	}
+
+	start := time.Now()
+	var tags []models.Tag
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var exists int
+		err := tx.QueryRowContext(ctx, `
+			SELECT 1 FROM `+r.schema.Table+` WHERE id = $1`+r.schema.andLive()+` FOR UPDATE
+		`, id).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		} else if err != nil {
+			return err
+		}
+
+		before, err := r.tags(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		ids := []int64{}
+		rows, err := tx.QueryContext(ctx, `SELECT id FROM tags WHERE slug = ANY($1)`, pq.Array(slugs))
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var tagID int64
+			if err := rows.Scan(&tagID); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, tagID)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if len(ids) != len(slugs) {
+			return ErrTagNotFound
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM `+r.schema.Tags+` WHERE record_id = $1 AND NOT tag_id = ANY($2)`, id, pq.Array(ids))
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO `+r.schema.Tags+` (record_id, tag_id)
+				SELECT $1, unnest($2::int[])
+				ON CONFLICT DO NOTHING
+			`, id, pq.Array(ids))
+		}
+
+		metrics.RecordDatabaseQuery("update", r.schema.Tags, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+		if tags, err = r.tags(ctx, tx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditUpdate, r.schema.Name, id,
+			map[string]interface{}{"tags": tagSlugs(before)}, map[string]interface{}{"tags": tagSlugs(tags)})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Поменялись выборки по ?tags и облако тегов
+	r.invalidate(id)
+
+	return tags, nil
+}
+
+func (r *PostgresRepository[T]) tags(ctx context.Context, db queryer, id int) ([]models.Tag, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+tagColumns+`
+		FROM `+r.schema.Tags+` AS l JOIN tags AS t ON t.id = l.tag_id
+		WHERE l.record_id = $1
+		ORDER BY t.name
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	return scanTags(rows)
+}
+
+func scanTags(rows *sql.Rows) ([]models.Tag, error) {
+	defer rows.Close()
+
+	tags := []models.Tag{}
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(tagFields(&tag)...); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+func tagSlugs(tags []models.Tag) []string {
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		slugs[i] = tag.Slug
+	}
+	return slugs
+}
+
+// TagRepository справочник тегов и облако тегов
+type TagRepository struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+func NewTagRepository(db *sql.DB, cache cache.Cache) *TagRepository {
+	return &TagRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+// List все теги по имени; kind ограничивает выборку одним видом
+func (r *TagRepository) List(ctx context.Context, kind string) ([]models.Tag, bool, error) {
+	start := time.Now()
+	cacheKey := "tags:all?kind=" + kind
+
+	var tags []models.Tag
+	if err := r.cache.Get(cacheKey, &tags); err == nil {
+		metrics.RecordDatabaseQuery("cache_hit", "tags", time.Since(start))
+		return tags, true, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+tagColumns+`
+		FROM tags AS t
+		WHERE $1 = '' OR t.kind = $1
+		ORDER BY t.name, t.id
+	`, kind)
+
+	metrics.RecordDatabaseQuery("select", "tags", time.Since(start))
+
+	if err != nil {
+		return nil, false, err
+	}
+	if tags, err = scanTags(rows); err != nil {
+		return nil, false, err
+	}
+
+	r.cache.Set(cacheKey, tags, 10*time.Minute)
+	return tags, false, nil
+}
+
+// Cloud теги с числом опубликованных проектов, самые частые первыми; теги без проектов не попадают
+func (r *TagRepository) Cloud(ctx context.Context, kind string) ([]models.TagCount, bool, error) {
+	start := time.Now()
+	cacheKey := "tags:cloud?kind=" + kind
+
+	var cloud []models.TagCount
+	if err := r.cache.Get(cacheKey, &cloud); err == nil {
+		metrics.RecordDatabaseQuery("cache_hit", "tags", time.Since(start))
+		return cloud, true, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+tagColumns+`, COUNT(*)
+		FROM tags AS t JOIN `+publishedTags+` AS l ON l.tag_id = t.id
+		WHERE $1 = '' OR t.kind = $1
+		GROUP BY t.id
+		ORDER BY COUNT(*) DESC, t.name
+	`, kind)
+
+	metrics.RecordDatabaseQuery("select", "tags", time.Since(start))
+
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	cloud = []models.TagCount{}
+	for rows.Next() {
+		var item models.TagCount
+		if err := rows.Scan(append(tagFields(&item.Tag), &item.Count)...); err != nil {
+			return nil, false, err
+		}
+		cloud = append(cloud, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	r.cache.Set(cacheKey, cloud, 10*time.Minute)
+	return cloud, false, nil
+}
+
+func (r *TagRepository) Get(ctx context.Context, id int) (models.Tag, error) {
+	start := time.Now()
+	var tag models.Tag
+	err := r.db.QueryRowContext(ctx, `SELECT `+tagColumns+` FROM tags AS t WHERE t.id = $1`, id).Scan(tagFields(&tag)...)
+
+	metrics.RecordDatabaseQuery("select", "tags", time.Since(start))
+
+	if err == sql.ErrNoRows {
+		return tag, ErrNotFound
+	}
+	return tag, err
+}
+
+func (r *TagRepository) Create(ctx context.Context, tag models.Tag) (models.Tag, error) {
+	start := time.Now()
+	var created models.Tag
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO tags AS t (name, slug, kind, created_at, update_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING `+tagColumns,
+		tag.Name, tag.Slug, tag.Kind).Scan(tagFields(&created)...)
+
+	metrics.RecordDatabaseQuery("insert", "tags", time.Since(start))
+
+	if isUniqueViolation(err) {
+		return created, ErrAlreadyExists
+	} else if err != nil {
+		return created, err
+	}
+
+	r.invalidate()
+	return created, nil
+}
+
+// Update меняет переданные поля; новый slug сразу действует в фильтрах ?tags
+func (r *TagRepository) Update(ctx context.Context, id int, fields Fields) (models.Tag, error) {
+	start := time.Now()
+	columns := fields.columns()
+	assignments := make([]string, len(columns))
+	args := make([]interface{}, 0, len(columns)+1)
+	for i, column := range columns {
+		assignments[i] = column + " = $" + strconv.Itoa(i+1)
+		args = append(args, fields.arg(column))
+	}
+	args = append(args, id)
+
+	var tag models.Tag
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE tags AS t
+		SET `+strings.Join(append(assignments, "update_at = CURRENT_TIMESTAMP"), ", ")+`
+		WHERE t.id = $`+strconv.Itoa(len(args))+`
+		RETURNING `+tagColumns,
+		args...).Scan(tagFields(&tag)...)
+
+	metrics.RecordDatabaseQuery("update", "tags", time.Since(start))
+
+	if err == sql.ErrNoRows {
+		return tag, ErrNotFound
+	} else if isUniqueViolation(err) {
+		return tag, ErrAlreadyExists
+	} else if err != nil {
+		return tag, err
+	}
+
+	r.invalidate()
+	return tag, nil
+}
+
+// Delete удаляет тег; связи с проектами удаляются каскадом
+func (r *TagRepository) Delete(ctx context.Context, id int) error {
+	start := time.Now()
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tags WHERE id = $1`, id)
+
+	metrics.RecordDatabaseQuery("delete", "tags", time.Since(start))
+
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNotFound
+	}
+
+	r.invalidate()
+	return nil
+}
+
+func (r *TagRepository) invalidate() {
+	for _, pattern := range tagInvalidates {
+		r.cache.DeletePattern(pattern)
+	}
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+)
+
+// ErrStaffNotFound в команду назначается несуществующий или удаленный в корзину сотрудник
+var ErrStaffNotFound = errors.New("staff member not found")
+
+const teamColumns = `s.id, s.name, s.slug, s.img, s.images, s.role, l.role, s.status`
+
+// memberProjects проекты всех типов, в командах которых состоит сотрудник $1
+const memberProjects = `
+	SELECT 'web', p.id, p.name, p.slug, p.img, p.images, l.role, p.status
+	FROM web_projects_team AS l JOIN web_projects AS p ON p.id = l.record_id
+	WHERE l.staff_id = $1 AND p.deleted_at IS NULL
+	UNION ALL
+	SELECT 'mobile', p.id, p.name, p.slug, p.img, p.images, l.role, p.status
+	FROM mobile_projects_team AS l JOIN mobile_projects AS p ON p.id = l.record_id
+	WHERE l.staff_id = $1 AND p.deleted_at IS NULL
+	UNION ALL
+	SELECT 'bot', p.id, p.name, p.slug, p.img, p.images, l.role, p.status
+	FROM bots_projects_team AS l JOIN bots_projects AS p ON p.id = l.record_id
+	WHERE l.staff_id = $1 AND p.deleted_at IS NULL
+	ORDER BY 3, 1, 2`
+
+func (s Schema[T]) teamKey(id int) string {
+	return s.ItemKey + strconv.Itoa(id) + ":team"
+}
+
+// memberProjectsKey ключ проектов сотрудника; его сбрасывает любое изменение проектов по шаблону "staff:*:projects"
+func memberProjectsKey(staffID int) string {
+	return "staff:" + strconv.Itoa(staffID) + ":projects"
+}
+
+// Team участники проекта в порядке назначения, без удаленных в корзину; кэшируется отдельно от записи
+func (r *PostgresRepository[T]) Team(ctx context.Context, id int) ([]models.TeamMember, error) {
+	if r.schema.Team == "" {
+		return []models.TeamMember{}, nil
+	}
+
+	start := time.Now()
+	cacheKey := r.schema.teamKey(id)
+
+	var team []models.TeamMember
+	if err := r.cache.Get(cacheKey, &team); err == nil {
+		metrics.RecordDatabaseQuery("cache_hit", r.schema.Team, time.Since(start))
+		return team, nil
+	}
+
+	team, err := r.team(ctx, r.db, id)
+
+	metrics.RecordDatabaseQuery("select", r.schema.Team, time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(cacheKey, team, 10*time.Minute)
+	return team, nil
+}
+
+// AssignTeamMember добавляет сотрудника в команду или меняет его роль
+func (r *PostgresRepository[T]) AssignTeamMember(ctx context.Context, id, staffID int, role string) (models.TeamMember, error) {
+	start := time.Now()
+	var member models.TeamMember
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := r.lockTeam(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		var exists int
+		err = tx.QueryRowContext(ctx, `SELECT 1 FROM staff WHERE id = $1 AND deleted_at IS NULL`, staffID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return ErrStaffNotFound
+		} else if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO `+r.schema.Team+` (record_id, staff_id, role)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (record_id, staff_id) DO UPDATE SET role = EXCLUDED.role
+		`, id, staffID, role)
+
+		metrics.RecordDatabaseQuery("insert", r.schema.Team, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+
+		after, err := r.team(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		for _, m := range after {
+			if m.StaffID == staffID {
+				member = m
+			}
+		}
+		return recordAudit(ctx, tx, AuditUpdate, r.schema.Name, id,
+			map[string]interface{}{"team": before}, map[string]interface{}{"team": after})
+	})
+	if err != nil {
+		return member, err
+	}
+
+	r.invalidateTeam(id, staffID)
+
+	return member, nil
+}
+
+func (r *PostgresRepository[T]) UnassignTeamMember(ctx context.Context, id, staffID int) error {
+	start := time.Now()
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := r.lockTeam(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM `+r.schema.Team+` WHERE record_id = $1 AND staff_id = $2`, id, staffID)
+
+		metrics.RecordDatabaseQuery("delete", r.schema.Team, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return ErrNotFound
+		}
+
+		after, err := r.team(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditUpdate, r.schema.Name, id,
+			map[string]interface{}{"team": before}, map[string]interface{}{"team": after})
+	})
+	if err != nil {
+		return err
+	}
+
+	r.invalidateTeam(id, staffID)
+
+	return nil
+}
+
+// MemberProjects проекты сотрудника по имени; только у схемы с Memberships
+func (r *PostgresRepository[T]) MemberProjects(ctx context.Context, id int) ([]models.StaffProject, error) {
+	if !r.schema.Memberships {
+		return []models.StaffProject{}, nil
+	}
+
+	start := time.Now()
+	cacheKey := memberProjectsKey(id)
+
+	var projects []models.StaffProject
+	if err := r.cache.Get(cacheKey, &projects); err == nil {
+		metrics.RecordDatabaseQuery("cache_hit", r.schema.Name, time.Since(start))
+		return projects, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, memberProjects, id)
+
+	metrics.RecordDatabaseQuery("select", r.schema.Name, time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects = []models.StaffProject{}
+	for rows.Next() {
+		var p models.StaffProject
+		if err := rows.Scan(&p.Type, &p.ID, &p.Name, &p.Slug, &p.Img, jsonValue{&p.Images}, &p.Role, &p.Status); err != nil {
+			return nil, err
+		}
+		projects = append(projects, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(cacheKey, projects, 10*time.Minute)
+	return projects, nil
+}
+
+// lockTeam блокирует проект (у удаленных в корзину команда не меняется) и возвращает команду для журнала
+func (r *PostgresRepository[T]) lockTeam(ctx context.Context, tx *sql.Tx, id int) ([]models.TeamMember, error) {
+	if r.schema.Team == "" {
+		return nil, ErrNotFound
+	}
+
+	var exists int
+	err := tx.QueryRowContext(ctx, `
+		SELECT 1 FROM `+r.schema.Table+` WHERE id = $1`+r.schema.andLive()+` FOR UPDATE
+	`, id).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return r.team(ctx, tx, id)
+}
+
+func (r *PostgresRepository[T]) team(ctx context.Context, db queryer, id int) ([]models.TeamMember, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+teamColumns+`
+		FROM `+r.schema.Team+` AS l JOIN staff AS s ON s.id = l.staff_id
+		WHERE l.record_id = $1 AND s.deleted_at IS NULL
+		ORDER BY l.created_at, s.id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	team := []models.TeamMember{}
+	for rows.Next() {
+		var m models.TeamMember
+		if err := rows.Scan(&m.StaffID, &m.Name, &m.Slug, &m.Img, jsonValue{&m.Images}, &m.Title, &m.Role, &m.Status); err != nil {
+			return nil, err
+		}
+		team = append(team, m)
+	}
+	return team, rows.Err()
+}
+
+// invalidateTeam команда видна с двух сторон: в проекте и в профиле сотрудника
+func (r *PostgresRepository[T]) invalidateTeam(id, staffID int) {
+	r.cache.Delete(r.schema.teamKey(id))
+	r.cache.Delete(memberProjectsKey(staffID))
+}
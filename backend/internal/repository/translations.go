@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type localeKey struct{}
+
+// WithLocale просит List/Get подставить перевод; пустая локаль - основной язык контента
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+func localeFrom(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+// localizedTable подзапрос с теми же колонками, где name/description взяты из перевода, если он есть.
+// Локаль подставляется литералом: ее значение уже сверено со списком поддерживаемых
+func localizedTable(table string, columns []string) func(locale string) string {
+	return func(locale string) string {
+		selected := make([]string, 0, len(columns)+1)
+		for _, column := range columns {
+			if column == "name" || column == "description" {
+				selected = append(selected, "COALESCE(tr."+column+", t."+column+") AS "+column)
+			} else {
+				selected = append(selected, "t."+column)
+			}
+		}
+		selected = append(selected, "t.deleted_at")
+
+		return `(SELECT ` + strings.Join(selected, ", ") + `
+			FROM ` + table + ` AS t
+			LEFT JOIN ` + table + `_translations AS tr ON tr.record_id = t.id AND tr.locale = ` + pq.QuoteLiteral(locale) + `
+		) AS ` + table
+	}
+}
+
+// source таблица для чтения с учетом локали из контекста
+func (s Schema[T]) source(ctx context.Context) string {
+	if locale := localeFrom(ctx); locale != "" && s.Localized != nil {
+		return s.Localized(locale)
+	}
+	return s.Table
+}
+
+// itemKey ключ кэша записи; переводы кэшируются отдельно от основного языка
+func (s Schema[T]) itemKey(ctx context.Context, id int) string {
+	key := s.ItemKey + strconv.Itoa(id)
+	if locale := localeFrom(ctx); locale != "" && s.Localized != nil {
+		key += "@" + locale
+	}
+	return key
+}
+
+func (r *PostgresRepository[T]) Translations(ctx context.Context, id int) ([]models.Translation, error) {
+	start := time.Now()
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT locale, name, description, update_at
+		FROM `+r.schema.Translations+`
+		WHERE record_id = $1
+		ORDER BY locale
+	`, id)
+
+	metrics.RecordDatabaseQuery("select", r.schema.Translations, time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := []models.Translation{}
+	for rows.Next() {
+		var translation models.Translation
+		if err := rows.Scan(&translation.Locale, &translation.Name, &translation.Description, &translation.UpdateAt); err != nil {
+			return nil, err
+		}
+		translations = append(translations, translation)
+	}
+	return translations, rows.Err()
+}
+
+// SetTranslation создает или заменяет перевод записи; изменение попадает в журнал аудита
+func (r *PostgresRepository[T]) SetTranslation(ctx context.Context, id int, locale string, fields Fields) (models.Translation, error) {
+	start := time.Now()
+	var translation models.Translation
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := r.lockTranslation(ctx, tx, id, locale)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO `+r.schema.Translations+` (record_id, locale, name, description, update_at)
+			VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+			ON CONFLICT (record_id, locale) DO UPDATE
+			SET name = EXCLUDED.name, description = EXCLUDED.description, update_at = CURRENT_TIMESTAMP
+			RETURNING locale, name, description, update_at
+		`, id, locale, fields["name"], fields["description"]).Scan(&translation.Locale, &translation.Name, &translation.Description, &translation.UpdateAt)
+
+		metrics.RecordDatabaseQuery("insert", r.schema.Translations, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+		after := map[string]interface{}{"translation_" + locale: translation}
+		return recordAudit(ctx, tx, AuditUpdate, r.schema.Name, id, before, after)
+	})
+	if err != nil {
+		return translation, err
+	}
+
+	r.invalidate(id)
+
+	return translation, nil
+}
+
+func (r *PostgresRepository[T]) DeleteTranslation(ctx context.Context, id int, locale string) error {
+	start := time.Now()
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := r.lockTranslation(ctx, tx, id, locale)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			DELETE FROM `+r.schema.Translations+` WHERE record_id = $1 AND locale = $2
+		`, id, locale)
+
+		metrics.RecordDatabaseQuery("delete", r.schema.Translations, time.Since(start))
+
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return ErrNotFound
+		}
+		after := map[string]interface{}{"translation_" + locale: nil}
+		return recordAudit(ctx, tx, AuditUpdate, r.schema.Name, id, before, after)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.invalidate(id)
+
+	return nil
+}
+
+// lockTranslation блокирует запись (переводы удаленных в корзину не меняются) и возвращает
+// текущий перевод в виде before для журнала
+func (r *PostgresRepository[T]) lockTranslation(ctx context.Context, tx *sql.Tx, id int, locale string) (map[string]interface{}, error) {
+	var exists int
+	err := tx.QueryRowContext(ctx, `
+		SELECT 1 FROM `+r.schema.Table+` WHERE id = $1`+r.schema.andLive()+` FOR UPDATE
+	`, id).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	var current models.Translation
+	err = tx.QueryRowContext(ctx, `
+		SELECT locale, name, description, update_at
+		FROM `+r.schema.Translations+` WHERE record_id = $1 AND locale = $2
+	`, id, locale).Scan(&current.Locale, &current.Name, &current.Description, &current.UpdateAt)
+	if err == sql.ErrNoRows {
+		return map[string]interface{}{"translation_" + locale: nil}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"translation_" + locale: current}, nil
+}
+
+// translationTypes таблицы с переводами и их метки type в отчете
+var translationTypes = []struct {
+	Type  string
+	Table string
+}{
+	{"web", "web_projects"},
+	{"mobile", "mobile_projects"},
+	{"bot", "bots_projects"},
+	{"staff", "staff"},
+}
+
+// TranslationsRepository отчет о непереведенных записях для админки; не кэшируется
+type TranslationsRepository struct {
+	db *sql.DB
+}
+
+func NewTranslationsRepository(db *sql.DB) *TranslationsRepository {
+	return &TranslationsRepository{
+		db: db,
+	}
+}
+
+// Missing записи из корзины не учитываются; каждая пара запись-локаль - отдельная строка отчета
+func (r *TranslationsRepository) Missing(ctx context.Context, locales []string, query ListQuery) (Page[models.MissingTranslation], error) {
+	start := time.Now()
+	query = query.normalized(nil)
+	page := Page[models.MissingTranslation]{Items: []models.MissingTranslation{}, Page: query.Page, PerPage: query.PerPage}
+	if len(locales) == 0 {
+		return page, nil
+	}
+
+	quoted := make([]string, len(locales))
+	for i, locale := range locales {
+		quoted[i] = pq.QuoteLiteral(locale)
+	}
+	branches := make([]string, len(translationTypes))
+	for i, t := range translationTypes {
+		branches[i] = `SELECT '` + t.Type + `' AS type, t.id, t.name, t.status, l.locale
+			FROM ` + t.Table + ` AS t CROSS JOIN unnest(ARRAY[` + strings.Join(quoted, ", ") + `]) AS l(locale)
+			WHERE t.deleted_at IS NULL AND NOT EXISTS (
+				SELECT 1 FROM ` + t.Table + `_translations AS tr WHERE tr.record_id = t.id AND tr.locale = l.locale
+			)`
+	}
+	source := "(" + strings.Join(branches, " UNION ALL ") + ") AS missing"
+
+	where, args := whereClause(query.Filters)
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+source+` `+where, args...).Scan(&page.Total); err != nil {
+		metrics.RecordDatabaseQuery("select", "translations", time.Since(start))
+		return page, err
+	}
+
+	limit := " LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
+	args = append(args, query.PerPage, (query.Page-1)*query.PerPage)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT type, id, name, status, locale
+		FROM `+source+`
+		`+where+`
+		ORDER BY locale, type, id DESC`+limit, args...)
+
+	metrics.RecordDatabaseQuery("select", "translations", time.Since(start))
+
+	if err != nil {
+		return page, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.MissingTranslation
+		if err := rows.Scan(&item.Type, &item.ID, &item.Name, &item.Status, &item.Locale); err != nil {
+			return page, err
+		}
+		page.Items = append(page.Items, item)
+	}
+	return page, rows.Err()
+}
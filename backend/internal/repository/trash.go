@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"ASMO-site-backend/internal/metrics"
+	"ASMO-site-backend/internal/models"
+)
+
+// trashSource удаленные записи всех таблиц с мягким удалением
+const trashSource = `(
+	SELECT 'web' AS type, id, name, deleted_at FROM web_projects WHERE deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'mobile' AS type, id, name, deleted_at FROM mobile_projects WHERE deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'bot' AS type, id, name, deleted_at FROM bots_projects WHERE deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'staff' AS type, id, name, deleted_at FROM staff WHERE deleted_at IS NOT NULL
+) AS trash`
+
+// TrashRepository корзина для админки; не кэшируется
+type TrashRepository struct {
+	db *sql.DB
+}
+
+func NewTrashRepository(db *sql.DB) *TrashRepository {
+	return &TrashRepository{
+		db: db,
+	}
+}
+
+func (r *TrashRepository) List(ctx context.Context, query ListQuery) (Page[models.TrashItem], error) {
+	start := time.Now()
+	query = query.normalized(nil)
+	where, args := whereClause(query.Filters)
+
+	page := Page[models.TrashItem]{Items: []models.TrashItem{}, Page: query.Page, PerPage: query.PerPage}
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+trashSource+` `+where, args...).Scan(&page.Total); err != nil {
+		metrics.RecordDatabaseQuery("select", "trash", time.Since(start))
+		return page, err
+	}
+
+	limit := " LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
+	args = append(args, query.PerPage, (query.Page-1)*query.PerPage)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT type, id, name, deleted_at
+		FROM `+trashSource+`
+		`+where+`
+		ORDER BY deleted_at DESC, id DESC, type`+limit, args...)
+
+	metrics.RecordDatabaseQuery("select", "trash", time.Since(start))
+
+	if err != nil {
+		return page, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.TrashItem
+		if err := rows.Scan(&item.Type, &item.ID, &item.Name, &item.DeletedAt); err != nil {
+			return page, err
+		}
+		page.Items = append(page.Items, item)
+	}
+	return page, rows.Err()
+}
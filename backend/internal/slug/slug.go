@@ -0,0 +1,72 @@
+package slug
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MaxLength ограничение длины slug без суффикса уникальности
+const MaxLength = 100
+
+// cyrillic транслитерация строчных русских букв; ъ и ь опускаются.
+// Таблица совпадает с функцией в миграции 014, которой заполнены существующие записи
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+var (
+	pattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	numeric = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// Make строит slug из названия: латиница в нижнем регистре, цифры и дефисы.
+// Чисто числовой slug получает префикс, чтобы его нельзя было спутать с id
+func Make(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		var part string
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			part = string(r)
+		default:
+			if latin, ok := cyrillic[r]; ok {
+				part = latin
+			}
+		}
+
+		if part == "" {
+			// ъ и ь не разрывают слово
+			if r != 'ъ' && r != 'ь' {
+				dash = true
+			}
+			continue
+		}
+		if dash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		dash = false
+		b.WriteString(part)
+	}
+
+	s := b.String()
+	if len(s) > MaxLength {
+		s = strings.TrimRight(s[:MaxLength], "-")
+	}
+	if s == "" {
+		return "item"
+	}
+	if numeric.MatchString(s) {
+		return "item-" + s
+	}
+	return s
+}
+
+// Valid проверяет формат slug из URL до похода в базу
+func Valid(s string) bool {
+	return len(s) <= MaxLength+10 && pattern.MatchString(s)
+}
@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Storage хранилище загруженных файлов; ключ - относительный путь вида 2024/05/<hex>.jpg
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL публичный адрес файла, который попадает в img записей
+	URL(key string) string
+}
+
+// NewKey случайный ключ с разбивкой по месяцам, чтобы каталоги не разрастались
+func NewKey(extension string) (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return time.Now().UTC().Format("2006/01/") + hex.EncodeToString(raw) + extension, nil
+}
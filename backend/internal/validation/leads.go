@@ -0,0 +1,35 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	contactEmail = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[A-Za-z]{2,}$`)
+	// Телефон в свободной записи: +7 (999) 123-45-67; цифр считаем отдельно
+	contactPhone = regexp.MustCompile(`^\+?[0-9][0-9 ()-]{5,24}$`)
+	// Имя пользователя Telegram с ведущим @
+	contactTelegram = regexp.MustCompile(`^@[A-Za-z][A-Za-z0-9_]{4,31}$`)
+)
+
+// validateContact способ связи из формы заявки: email, телефон (10-15 цифр) или @username в Telegram
+func validateContact(fl validator.FieldLevel) bool {
+	contact := strings.TrimSpace(fl.Field().String())
+
+	switch {
+	case contactEmail.MatchString(contact), contactTelegram.MatchString(contact):
+		return true
+	case contactPhone.MatchString(contact):
+		digits := 0
+		for _, r := range contact {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		return digits >= 10 && digits <= 15
+	}
+	return false
+}
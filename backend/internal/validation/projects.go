@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	// https://apps.apple.com/ru/app/name/id123456789, регион и имя приложения необязательны
+	appStoreURL = regexp.MustCompile(`^https://apps\.apple\.com/([a-z]{2}/)?app/([^/?#\s]+/)?id\d+/?(\?\S*)?$`)
+	// https://play.google.com/store/apps/details?id=com.example.app, id - имя пакета Android
+	googlePlayURL = regexp.MustCompile(`^https://play\.google\.com/store/apps/details\?id=[A-Za-z][A-Za-z0-9_]*(\.[A-Za-z][A-Za-z0-9_]*)+(&\S*)?$`)
+
+	// botHandles правила имени бота для каждой платформы; без платформы подходит любое разумное имя
+	botHandles = map[string]*regexp.Regexp{
+		// 5-32 символа, имя бота в Telegram обязано заканчиваться на bot
+		"telegram": regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{1,28}[Bb][Oo][Tt]$`),
+		// Новые имена Discord или старые с дискриминатором Name#1234
+		"discord": regexp.MustCompile(`^[A-Za-z0-9_.]{2,32}(#\d{4})?$`),
+		// Короткое имя сообщества VK
+		"vk": regexp.MustCompile(`^[A-Za-z0-9_.]{5,32}$`),
+		"":   regexp.MustCompile(`^[A-Za-z0-9_.]{2,64}$`),
+	}
+)
+
+func validateAppStoreURL(fl validator.FieldLevel) bool {
+	return appStoreURL.MatchString(fl.Field().String())
+}
+
+func validateGooglePlayURL(fl validator.FieldLevel) bool {
+	return googlePlayURL.MatchString(fl.Field().String())
+}
+
+// validateBotHandle проверяет имя бота по правилам платформы из соседнего поля Platform.
+// В PATCH платформа может не прийти, тогда проверяется только общий формат
+func validateBotHandle(fl validator.FieldLevel) bool {
+	handle := strings.TrimPrefix(fl.Field().String(), "@")
+
+	platform := ""
+	if field := reflect.Indirect(fl.Parent()).FieldByName("Platform"); field.IsValid() {
+		if field.Kind() == reflect.Ptr && !field.IsNil() {
+			field = field.Elem()
+		}
+		if field.Kind() == reflect.String {
+			platform = field.String()
+		}
+	}
+
+	pattern, ok := botHandles[platform]
+	if !ok {
+		pattern = botHandles[""]
+	}
+	return pattern.MatchString(handle)
+}
@@ -0,0 +1,22 @@
+package validation
+
+import (
+	"regexp"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// socialURLs ссылки на профиль для каждой платформы; платформа - параметр тега: social_url=github
+var socialURLs = map[string]*regexp.Regexp{
+	// https://github.com/login, логин до 39 символов из букв, цифр и дефисов
+	"github": regexp.MustCompile(`^https://(www\.)?github\.com/[A-Za-z0-9](-?[A-Za-z0-9]){0,38}/?$`),
+	// Личная страница /in/ или страница компании /company/, в том числе с региональным поддоменом
+	"linkedin": regexp.MustCompile(`^https://([a-z]{2,3}\.)?linkedin\.com/(in|company)/[A-Za-z0-9_%-]{3,100}/?$`),
+	// https://t.me/username, имя пользователя Telegram - 5-32 символа
+	"telegram": regexp.MustCompile(`^https://(t\.me|telegram\.me)/[A-Za-z][A-Za-z0-9_]{4,31}$`),
+}
+
+func validateSocialURL(fl validator.FieldLevel) bool {
+	pattern, ok := socialURLs[fl.Param()]
+	return ok && pattern.MatchString(fl.Field().String())
+}
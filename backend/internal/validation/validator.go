@@ -4,6 +4,8 @@ import (
 	"regexp"
 	"strings"
 
+	"ASMO-site-backend/internal/slug"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -18,6 +20,15 @@ func Init() {
 		matched, _ := regexp.MatchString(`^(http|https)://[a-zA-Z0-9\-\.]+\.[a-zA-Z]{2,}(/\S*)?$`, url)
 		return matched
 	})
+
+	validate.RegisterValidation("app_store_url", validateAppStoreURL)
+	validate.RegisterValidation("google_play_url", validateGooglePlayURL)
+	validate.RegisterValidation("bot_handle", validateBotHandle)
+	validate.RegisterValidation("social_url", validateSocialURL)
+	validate.RegisterValidation("contact", validateContact)
+	validate.RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+		return slug.Valid(fl.Field().String())
+	})
 }
 
 type ValidationError struct {
@@ -46,7 +57,7 @@ func ValidateStruct(s interface{}) []ValidationError {
 
 func getErrorMessage(err validator.FieldError) string {
 	switch err.Tag() {
-	case "required":
+	case "required", "required_without", "required_if", "required_with":
 		return "This field is required"
 	case "min":
 		return "Value is too short"
@@ -54,6 +65,24 @@ func getErrorMessage(err validator.FieldError) string {
 		return "Value is too long"
 	case "url":
 		return "Invalid URL format"
+	case "email":
+		return "Invalid email address"
+	case "oneof":
+		return "Value is not allowed"
+	case "unique":
+		return "Values must be unique"
+	case "app_store_url":
+		return "Invalid App Store URL"
+	case "google_play_url":
+		return "Invalid Google Play URL"
+	case "bot_handle":
+		return "Invalid bot handle for this platform"
+	case "social_url":
+		return "Invalid profile link for this platform"
+	case "contact":
+		return "Use an email, a phone number or a Telegram @username"
+	case "slug":
+		return "Use lowercase latin letters, digits and hyphens"
 	default:
 		return "Invalid value"
 	}
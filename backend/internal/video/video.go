@@ -0,0 +1,75 @@
+package video
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Провайдеры видео в галерее проекта
+const (
+	ProviderYouTube = "youtube"
+	ProviderVimeo   = "vimeo"
+	// ProviderFile собственный файл, отдается как есть в <video>
+	ProviderFile = "file"
+)
+
+var ErrUnsupported = errors.New("unsupported video URL")
+
+var (
+	youtubeID = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoID   = regexp.MustCompile(`^[0-9]+$`)
+	// fileExtensions форматы, которые браузеры проигрывают без плеера провайдера
+	fileExtensions = []string{".mp4", ".webm", ".ogv", ".mov"}
+)
+
+// Embed видео, готовое для вставки: для YouTube и Vimeo EmbedURL - адрес для iframe
+type Embed struct {
+	Provider string
+	EmbedURL string
+}
+
+// Parse распознает ссылку на YouTube (watch, youtu.be, shorts, embed), Vimeo или видеофайл
+func Parse(raw string) (Embed, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Embed{}, ErrUnsupported
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	switch host {
+	case "youtube.com", "m.youtube.com", "youtube-nocookie.com":
+		id := u.Query().Get("v")
+		if len(segments) == 2 && (segments[0] == "embed" || segments[0] == "shorts") {
+			id = segments[1]
+		}
+		return youtube(id)
+	case "youtu.be":
+		return youtube(segments[0])
+	case "vimeo.com", "player.vimeo.com":
+		id := segments[len(segments)-1]
+		if !vimeoID.MatchString(id) {
+			return Embed{}, ErrUnsupported
+		}
+		return Embed{Provider: ProviderVimeo, EmbedURL: "https://player.vimeo.com/video/" + id}, nil
+	}
+
+	extension := strings.ToLower(path.Ext(u.Path))
+	for _, allowed := range fileExtensions {
+		if extension == allowed {
+			return Embed{Provider: ProviderFile, EmbedURL: u.String()}, nil
+		}
+	}
+	return Embed{}, ErrUnsupported
+}
+
+func youtube(id string) (Embed, error) {
+	if !youtubeID.MatchString(id) {
+		return Embed{}, ErrUnsupported
+	}
+	return Embed{Provider: ProviderYouTube, EmbedURL: "https://www.youtube.com/embed/" + id}, nil
+}
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_web_projects_search_vector;
+ALTER TABLE web_projects DROP COLUMN IF EXISTS search_vector;
+DROP INDEX IF EXISTS idx_mobile_projects_search_vector;
+ALTER TABLE mobile_projects DROP COLUMN IF EXISTS search_vector;
+DROP INDEX IF EXISTS idx_bots_projects_search_vector;
+ALTER TABLE bots_projects DROP COLUMN IF EXISTS search_vector;
+DROP INDEX IF EXISTS idx_staff_search_vector;
+ALTER TABLE staff DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,27 @@
+ALTER TABLE web_projects
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+    ) STORED;
+CREATE INDEX idx_web_projects_search_vector ON web_projects USING GIN (search_vector);
+
+ALTER TABLE mobile_projects
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+    ) STORED;
+CREATE INDEX idx_mobile_projects_search_vector ON mobile_projects USING GIN (search_vector);
+
+ALTER TABLE bots_projects
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+    ) STORED;
+CREATE INDEX idx_bots_projects_search_vector ON bots_projects USING GIN (search_vector);
+
+ALTER TABLE staff
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+    ) STORED;
+CREATE INDEX idx_staff_search_vector ON staff USING GIN (search_vector);
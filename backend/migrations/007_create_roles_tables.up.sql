@@ -0,0 +1,47 @@
+CREATE TABLE roles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE permissions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE role_permissions (
+    role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_id INTEGER NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE admin_user_roles (
+    user_id INTEGER NOT NULL REFERENCES admin_users(id) ON DELETE CASCADE,
+    role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, role_id)
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('superadmin', 'Полный доступ, управление пользователями'),
+    ('editor', 'Управление проектами'),
+    ('hr', 'Управление сотрудниками');
+
+INSERT INTO permissions (name, description) VALUES
+    ('projects.manage', 'Создание, изменение и удаление проектов'),
+    ('staff.manage', 'Создание, изменение и удаление сотрудников'),
+    ('users.manage', 'Управление администраторами и их ролями');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p WHERE r.name = 'superadmin';
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'projects.manage' WHERE r.name = 'editor';
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'staff.manage' WHERE r.name = 'hr';
+
+-- Уже существующие администраторы сохраняют полный доступ
+INSERT INTO admin_user_roles (user_id, role_id)
+SELECT u.id, r.id FROM admin_users u CROSS JOIN roles r WHERE r.name = 'superadmin';
@@ -0,0 +1,2 @@
+DELETE FROM permissions WHERE name = 'audit.view';
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,24 @@
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_user_id INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    actor_api_key_id INTEGER REFERENCES api_keys(id) ON DELETE SET NULL,
+    actor_name VARCHAR(100) NOT NULL DEFAULT '',
+    action VARCHAR(20) NOT NULL,
+    resource_type VARCHAR(50) NOT NULL,
+    resource_id INTEGER NOT NULL,
+    before JSONB,
+    after JSONB,
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_log_resource ON audit_log (resource_type, resource_id);
+CREATE INDEX idx_audit_log_actor ON audit_log (actor_user_id);
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at DESC);
+
+INSERT INTO permissions (name, description) VALUES
+    ('audit.view', 'Просмотр журнала изменений');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'audit.view' WHERE r.name = 'superadmin';
@@ -0,0 +1,4 @@
+ALTER TABLE staff DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE bots_projects DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE mobile_projects DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE web_projects DROP COLUMN IF EXISTS deleted_at;
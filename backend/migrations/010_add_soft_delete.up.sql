@@ -0,0 +1,10 @@
+ALTER TABLE web_projects ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE mobile_projects ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE bots_projects ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE staff ADD COLUMN deleted_at TIMESTAMP;
+
+-- Корзина и purge выбирают только удаленные записи
+CREATE INDEX idx_web_projects_deleted_at ON web_projects (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_mobile_projects_deleted_at ON mobile_projects (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_bots_projects_deleted_at ON bots_projects (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_staff_deleted_at ON staff (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,21 @@
+DELETE FROM permissions WHERE name = 'content.publish';
+
+ALTER TABLE staff
+    DROP COLUMN IF EXISTS approved_at,
+    DROP COLUMN IF EXISTS approved_by,
+    DROP COLUMN IF EXISTS status;
+
+ALTER TABLE bots_projects
+    DROP COLUMN IF EXISTS approved_at,
+    DROP COLUMN IF EXISTS approved_by,
+    DROP COLUMN IF EXISTS status;
+
+ALTER TABLE mobile_projects
+    DROP COLUMN IF EXISTS approved_at,
+    DROP COLUMN IF EXISTS approved_by,
+    DROP COLUMN IF EXISTS status;
+
+ALTER TABLE web_projects
+    DROP COLUMN IF EXISTS approved_at,
+    DROP COLUMN IF EXISTS approved_by,
+    DROP COLUMN IF EXISTS status;
\ No newline at end of file
@@ -0,0 +1,38 @@
+-- Уже опубликованные записи остаются видимыми, новые создаются черновиками
+ALTER TABLE web_projects
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'in_review', 'published', 'archived')),
+    ADD COLUMN approved_by INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    ADD COLUMN approved_at TIMESTAMP;
+ALTER TABLE web_projects ALTER COLUMN status SET DEFAULT 'draft';
+CREATE INDEX idx_web_projects_status ON web_projects (status);
+
+ALTER TABLE mobile_projects
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'in_review', 'published', 'archived')),
+    ADD COLUMN approved_by INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    ADD COLUMN approved_at TIMESTAMP;
+ALTER TABLE mobile_projects ALTER COLUMN status SET DEFAULT 'draft';
+CREATE INDEX idx_mobile_projects_status ON mobile_projects (status);
+
+ALTER TABLE bots_projects
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'in_review', 'published', 'archived')),
+    ADD COLUMN approved_by INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    ADD COLUMN approved_at TIMESTAMP;
+ALTER TABLE bots_projects ALTER COLUMN status SET DEFAULT 'draft';
+CREATE INDEX idx_bots_projects_status ON bots_projects (status);
+
+ALTER TABLE staff
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'in_review', 'published', 'archived')),
+    ADD COLUMN approved_by INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    ADD COLUMN approved_at TIMESTAMP;
+ALTER TABLE staff ALTER COLUMN status SET DEFAULT 'draft';
+CREATE INDEX idx_staff_status ON staff (status);
+
+INSERT INTO permissions (name, description) VALUES
+    ('content.publish', 'Одобрение публикации проектов и сотрудников');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'content.publish' WHERE r.name = 'superadmin';
\ No newline at end of file
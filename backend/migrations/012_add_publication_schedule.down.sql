@@ -0,0 +1,11 @@
+ALTER TABLE bots_projects
+    DROP COLUMN IF EXISTS unpublish_at,
+    DROP COLUMN IF EXISTS publish_at;
+
+ALTER TABLE mobile_projects
+    DROP COLUMN IF EXISTS unpublish_at,
+    DROP COLUMN IF EXISTS publish_at;
+
+ALTER TABLE web_projects
+    DROP COLUMN IF EXISTS unpublish_at,
+    DROP COLUMN IF EXISTS publish_at;
\ No newline at end of file
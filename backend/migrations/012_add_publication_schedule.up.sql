@@ -0,0 +1,17 @@
+ALTER TABLE web_projects
+    ADD COLUMN publish_at TIMESTAMP,
+    ADD COLUMN unpublish_at TIMESTAMP;
+CREATE INDEX idx_web_projects_publish_at ON web_projects (publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX idx_web_projects_unpublish_at ON web_projects (unpublish_at) WHERE unpublish_at IS NOT NULL;
+
+ALTER TABLE mobile_projects
+    ADD COLUMN publish_at TIMESTAMP,
+    ADD COLUMN unpublish_at TIMESTAMP;
+CREATE INDEX idx_mobile_projects_publish_at ON mobile_projects (publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX idx_mobile_projects_unpublish_at ON mobile_projects (unpublish_at) WHERE unpublish_at IS NOT NULL;
+
+ALTER TABLE bots_projects
+    ADD COLUMN publish_at TIMESTAMP,
+    ADD COLUMN unpublish_at TIMESTAMP;
+CREATE INDEX idx_bots_projects_publish_at ON bots_projects (publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX idx_bots_projects_unpublish_at ON bots_projects (unpublish_at) WHERE unpublish_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS staff_revisions;
+DROP TABLE IF EXISTS bots_projects_revisions;
+DROP TABLE IF EXISTS mobile_projects_revisions;
+DROP TABLE IF EXISTS web_projects_revisions;
\ No newline at end of file
@@ -0,0 +1,43 @@
+CREATE TABLE web_projects_revisions (
+    id SERIAL PRIMARY KEY,
+    record_id INTEGER NOT NULL REFERENCES web_projects(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    snapshot JSONB NOT NULL,
+    created_by INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    created_by_name VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (record_id, revision)
+);
+
+CREATE TABLE mobile_projects_revisions (
+    id SERIAL PRIMARY KEY,
+    record_id INTEGER NOT NULL REFERENCES mobile_projects(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    snapshot JSONB NOT NULL,
+    created_by INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    created_by_name VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (record_id, revision)
+);
+
+CREATE TABLE bots_projects_revisions (
+    id SERIAL PRIMARY KEY,
+    record_id INTEGER NOT NULL REFERENCES bots_projects(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    snapshot JSONB NOT NULL,
+    created_by INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    created_by_name VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (record_id, revision)
+);
+
+CREATE TABLE staff_revisions (
+    id SERIAL PRIMARY KEY,
+    record_id INTEGER NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    snapshot JSONB NOT NULL,
+    created_by INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    created_by_name VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (record_id, revision)
+);
\ No newline at end of file
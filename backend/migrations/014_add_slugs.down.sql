@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS staff_slug_redirects;
+DROP TABLE IF EXISTS bots_projects_slug_redirects;
+DROP TABLE IF EXISTS mobile_projects_slug_redirects;
+DROP TABLE IF EXISTS web_projects_slug_redirects;
+
+ALTER TABLE staff DROP COLUMN IF EXISTS slug;
+ALTER TABLE bots_projects DROP COLUMN IF EXISTS slug;
+ALTER TABLE mobile_projects DROP COLUMN IF EXISTS slug;
+ALTER TABLE web_projects DROP COLUMN IF EXISTS slug;
\ No newline at end of file
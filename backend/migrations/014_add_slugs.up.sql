@@ -0,0 +1,78 @@
+-- Транслитерация совпадает с internal/slug.Make; функция нужна только для заполнения существующих записей
+CREATE FUNCTION pg_temp.slugify(name TEXT) RETURNS TEXT AS $$
+    SELECT rtrim(left(trim(BOTH '-' FROM regexp_replace(
+        translate(
+            replace(replace(replace(replace(replace(replace(replace(replace(replace(lower(name),
+                'щ', 'shch'), 'ж', 'zh'), 'х', 'kh'), 'ц', 'ts'), 'ч', 'ch'), 'ш', 'sh'), 'ю', 'yu'), 'я', 'ya'), 'ё', 'e'),
+            'абвгдезийклмнопрстуфыэъь', 'abvgdeziyklmnoprstufye'),
+        '[^a-z0-9]+', '-', 'g')), 100), '-')
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE web_projects ADD COLUMN slug VARCHAR(150);
+ALTER TABLE mobile_projects ADD COLUMN slug VARCHAR(150);
+ALTER TABLE bots_projects ADD COLUMN slug VARCHAR(150);
+ALTER TABLE staff ADD COLUMN slug VARCHAR(150);
+
+UPDATE web_projects SET slug = pg_temp.slugify(name);
+UPDATE mobile_projects SET slug = pg_temp.slugify(name);
+UPDATE bots_projects SET slug = pg_temp.slugify(name);
+UPDATE staff SET slug = pg_temp.slugify(name);
+
+-- Пустые и чисто числовые slug путаются с id, совпадающие получают id в конце
+UPDATE web_projects SET slug = 'item' WHERE slug = '';
+UPDATE mobile_projects SET slug = 'item' WHERE slug = '';
+UPDATE bots_projects SET slug = 'item' WHERE slug = '';
+UPDATE staff SET slug = 'item' WHERE slug = '';
+
+UPDATE web_projects SET slug = 'item-' || slug WHERE slug ~ '^[0-9]+$';
+UPDATE mobile_projects SET slug = 'item-' || slug WHERE slug ~ '^[0-9]+$';
+UPDATE bots_projects SET slug = 'item-' || slug WHERE slug ~ '^[0-9]+$';
+UPDATE staff SET slug = 'item-' || slug WHERE slug ~ '^[0-9]+$';
+
+UPDATE web_projects t SET slug = t.slug || '-' || t.id
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY id) AS n FROM web_projects) d
+WHERE d.id = t.id AND d.n > 1;
+UPDATE mobile_projects t SET slug = t.slug || '-' || t.id
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY id) AS n FROM mobile_projects) d
+WHERE d.id = t.id AND d.n > 1;
+UPDATE bots_projects t SET slug = t.slug || '-' || t.id
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY id) AS n FROM bots_projects) d
+WHERE d.id = t.id AND d.n > 1;
+UPDATE staff t SET slug = t.slug || '-' || t.id
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY id) AS n FROM staff) d
+WHERE d.id = t.id AND d.n > 1;
+
+ALTER TABLE web_projects ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE mobile_projects ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE bots_projects ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE staff ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_web_projects_slug ON web_projects (slug);
+CREATE UNIQUE INDEX idx_mobile_projects_slug ON mobile_projects (slug);
+CREATE UNIQUE INDEX idx_bots_projects_slug ON bots_projects (slug);
+CREATE UNIQUE INDEX idx_staff_slug ON staff (slug);
+
+-- Старые slug после переименования отвечают 301 на текущий
+CREATE TABLE web_projects_slug_redirects (
+    old_slug VARCHAR(150) PRIMARY KEY,
+    record_id INTEGER NOT NULL REFERENCES web_projects(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE mobile_projects_slug_redirects (
+    old_slug VARCHAR(150) PRIMARY KEY,
+    record_id INTEGER NOT NULL REFERENCES mobile_projects(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE bots_projects_slug_redirects (
+    old_slug VARCHAR(150) PRIMARY KEY,
+    record_id INTEGER NOT NULL REFERENCES bots_projects(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE staff_slug_redirects (
+    old_slug VARCHAR(150) PRIMARY KEY,
+    record_id INTEGER NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS staff_translations;
+DROP TABLE IF EXISTS bots_projects_translations;
+DROP TABLE IF EXISTS mobile_projects_translations;
+DROP TABLE IF EXISTS web_projects_translations;
\ No newline at end of file
@@ -0,0 +1,8 @@
+DELETE FROM permissions WHERE name = 'media.manage';
+
+ALTER TABLE staff DROP COLUMN IF EXISTS media_id;
+ALTER TABLE bots_projects DROP COLUMN IF EXISTS media_id;
+ALTER TABLE mobile_projects DROP COLUMN IF EXISTS media_id;
+ALTER TABLE web_projects DROP COLUMN IF EXISTS media_id;
+
+DROP TABLE IF EXISTS media;
\ No newline at end of file
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS media (
+    id SERIAL PRIMARY KEY,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    url TEXT NOT NULL,
+    original_name VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    uploaded_by INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_created_at ON media (created_at DESC);
+
+-- img остается основным полем для чтения, media_id показывает, откуда он взят
+ALTER TABLE web_projects ADD COLUMN media_id INTEGER REFERENCES media(id) ON DELETE SET NULL;
+ALTER TABLE mobile_projects ADD COLUMN media_id INTEGER REFERENCES media(id) ON DELETE SET NULL;
+ALTER TABLE bots_projects ADD COLUMN media_id INTEGER REFERENCES media(id) ON DELETE SET NULL;
+ALTER TABLE staff ADD COLUMN media_id INTEGER REFERENCES media(id) ON DELETE SET NULL;
+
+INSERT INTO permissions (name, description) VALUES
+    ('media.manage', 'Загрузка и удаление файлов медиатеки');
+
+-- Картинки нужны всем, кто редактирует проекты и сотрудников
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'media.manage'
+WHERE r.name IN ('superadmin', 'editor', 'hr');
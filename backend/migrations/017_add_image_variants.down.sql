@@ -0,0 +1,8 @@
+ALTER TABLE staff DROP COLUMN IF EXISTS images;
+ALTER TABLE bots_projects DROP COLUMN IF EXISTS images;
+ALTER TABLE mobile_projects DROP COLUMN IF EXISTS images;
+ALTER TABLE web_projects DROP COLUMN IF EXISTS images;
+
+ALTER TABLE media
+    DROP COLUMN IF EXISTS variant_keys,
+    DROP COLUMN IF EXISTS images;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- У файлов, загруженных до появления копий, известен только оригинал
+ALTER TABLE media
+    ADD COLUMN images JSONB,
+    ADD COLUMN variant_keys TEXT[] NOT NULL DEFAULT '{}';
+UPDATE media SET images = jsonb_build_object(
+    'original', jsonb_build_object('url', url, 'width', 0, 'height', 0),
+    'srcset', url
+);
+ALTER TABLE media ALTER COLUMN images SET NOT NULL;
+
+-- Копия images из медиатеки, чтобы списки не делали JOIN; NULL у картинок по внешнему URL
+ALTER TABLE web_projects ADD COLUMN images JSONB;
+ALTER TABLE mobile_projects ADD COLUMN images JSONB;
+ALTER TABLE bots_projects ADD COLUMN images JSONB;
+ALTER TABLE staff ADD COLUMN images JSONB;
+
+UPDATE web_projects AS t SET images = m.images FROM media AS m WHERE t.media_id = m.id;
+UPDATE mobile_projects AS t SET images = m.images FROM media AS m WHERE t.media_id = m.id;
+UPDATE bots_projects AS t SET images = m.images FROM media AS m WHERE t.media_id = m.id;
+UPDATE staff AS t SET images = m.images FROM media AS m WHERE t.media_id = m.id;
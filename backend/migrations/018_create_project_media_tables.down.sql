@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bots_projects_media;
+DROP TABLE IF EXISTS mobile_projects_media;
+DROP TABLE IF EXISTS web_projects_media;
\ No newline at end of file
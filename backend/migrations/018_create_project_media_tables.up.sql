@@ -0,0 +1,48 @@
+-- Галереи проектов: картинки из медиатеки (удаляются вместе с файлом) и ссылки на видео
+CREATE TABLE IF NOT EXISTS web_projects_media (
+    id SERIAL PRIMARY KEY,
+    record_id INTEGER NOT NULL REFERENCES web_projects(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('image', 'video')),
+    media_id INTEGER REFERENCES media(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    images JSONB,
+    provider VARCHAR(20),
+    embed_url TEXT,
+    caption VARCHAR(300) NOT NULL DEFAULT '',
+    position INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_web_projects_media_record ON web_projects_media (record_id, position);
+
+CREATE TABLE IF NOT EXISTS mobile_projects_media (
+    id SERIAL PRIMARY KEY,
+    record_id INTEGER NOT NULL REFERENCES mobile_projects(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('image', 'video')),
+    media_id INTEGER REFERENCES media(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    images JSONB,
+    provider VARCHAR(20),
+    embed_url TEXT,
+    caption VARCHAR(300) NOT NULL DEFAULT '',
+    position INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_mobile_projects_media_record ON mobile_projects_media (record_id, position);
+
+CREATE TABLE IF NOT EXISTS bots_projects_media (
+    id SERIAL PRIMARY KEY,
+    record_id INTEGER NOT NULL REFERENCES bots_projects(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('image', 'video')),
+    media_id INTEGER REFERENCES media(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    images JSONB,
+    provider VARCHAR(20),
+    embed_url TEXT,
+    caption VARCHAR(300) NOT NULL DEFAULT '',
+    position INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_bots_projects_media_record ON bots_projects_media (record_id, position);
\ No newline at end of file
@@ -0,0 +1,13 @@
+ALTER TABLE bots_projects
+    DROP COLUMN IF EXISTS handle,
+    DROP COLUMN IF EXISTS platform;
+
+ALTER TABLE mobile_projects
+    DROP CONSTRAINT IF EXISTS mobile_projects_platforms_check,
+    DROP COLUMN IF EXISTS platforms,
+    DROP COLUMN IF EXISTS google_play_url,
+    DROP COLUMN IF EXISTS app_store_url;
+
+ALTER TABLE web_projects
+    DROP COLUMN IF EXISTS stack,
+    DROP COLUMN IF EXISTS live_url;
\ No newline at end of file
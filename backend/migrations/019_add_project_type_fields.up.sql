@@ -0,0 +1,14 @@
+-- Поля, которые есть только у своего типа проекта; пустая строка и пустой массив - "не указано"
+ALTER TABLE web_projects
+    ADD COLUMN live_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN stack TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE mobile_projects
+    ADD COLUMN app_store_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN google_play_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN platforms TEXT[] NOT NULL DEFAULT '{}',
+    ADD CONSTRAINT mobile_projects_platforms_check CHECK (platforms <@ ARRAY['ios', 'android']::TEXT[]);
+
+ALTER TABLE bots_projects
+    ADD COLUMN platform VARCHAR(20) NOT NULL DEFAULT '' CHECK (platform IN ('', 'telegram', 'discord', 'vk')),
+    ADD COLUMN handle VARCHAR(64) NOT NULL DEFAULT '';
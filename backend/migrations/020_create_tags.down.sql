@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS bots_projects_tags;
+DROP TABLE IF EXISTS mobile_projects_tags;
+DROP TABLE IF EXISTS web_projects_tags;
+DROP TABLE IF EXISTS tags;
\ No newline at end of file
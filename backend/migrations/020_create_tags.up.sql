@@ -0,0 +1,33 @@
+-- Теги проектов: технологии (go, react), категории (e-commerce) и свободные метки
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    slug VARCHAR(110) NOT NULL UNIQUE,
+    kind VARCHAR(20) NOT NULL DEFAULT 'tag' CHECK (kind IN ('tag', 'technology', 'category')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS web_projects_tags (
+    record_id INTEGER NOT NULL REFERENCES web_projects(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (record_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_web_projects_tags_tag ON web_projects_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS mobile_projects_tags (
+    record_id INTEGER NOT NULL REFERENCES mobile_projects(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (record_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mobile_projects_tags_tag ON mobile_projects_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS bots_projects_tags (
+    record_id INTEGER NOT NULL REFERENCES bots_projects(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (record_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bots_projects_tags_tag ON bots_projects_tags (tag_id);
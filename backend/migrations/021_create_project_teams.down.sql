@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bots_projects_team;
+DROP TABLE IF EXISTS mobile_projects_team;
+DROP TABLE IF EXISTS web_projects_team;
\ No newline at end of file
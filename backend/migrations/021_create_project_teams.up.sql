@@ -0,0 +1,30 @@
+-- Участники проектов: сотрудник и его роль в конкретном проекте (lead, designer, backend...)
+CREATE TABLE IF NOT EXISTS web_projects_team (
+    record_id INTEGER NOT NULL REFERENCES web_projects(id) ON DELETE CASCADE,
+    staff_id INTEGER NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    role VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (record_id, staff_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_web_projects_team_staff ON web_projects_team (staff_id);
+
+CREATE TABLE IF NOT EXISTS mobile_projects_team (
+    record_id INTEGER NOT NULL REFERENCES mobile_projects(id) ON DELETE CASCADE,
+    staff_id INTEGER NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    role VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (record_id, staff_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mobile_projects_team_staff ON mobile_projects_team (staff_id);
+
+CREATE TABLE IF NOT EXISTS bots_projects_team (
+    record_id INTEGER NOT NULL REFERENCES bots_projects(id) ON DELETE CASCADE,
+    staff_id INTEGER NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    role VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (record_id, staff_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bots_projects_team_staff ON bots_projects_team (staff_id);
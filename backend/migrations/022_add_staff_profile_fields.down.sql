@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_staff_department;
+DROP INDEX IF EXISTS idx_staff_display_order;
+
+ALTER TABLE staff
+    DROP CONSTRAINT IF EXISTS staff_skills_check,
+    DROP COLUMN IF EXISTS display_order,
+    DROP COLUMN IF EXISTS telegram_url,
+    DROP COLUMN IF EXISTS linkedin_url,
+    DROP COLUMN IF EXISTS github_url,
+    DROP COLUMN IF EXISTS show_email,
+    DROP COLUMN IF EXISTS email,
+    DROP COLUMN IF EXISTS skills,
+    DROP COLUMN IF EXISTS department;
+
+DROP SEQUENCE IF EXISTS staff_display_order_seq;
\ No newline at end of file
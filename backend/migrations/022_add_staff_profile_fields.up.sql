@@ -0,0 +1,32 @@
+-- Профиль сотрудника: отдел, навыки, контакты и ручной порядок на странице команды.
+-- Email показывается публично только при show_email
+ALTER TABLE staff
+    ADD COLUMN department VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN skills JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN email VARCHAR(254) NOT NULL DEFAULT '',
+    ADD COLUMN show_email BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN github_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN linkedin_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN telegram_url TEXT NOT NULL DEFAULT '',
+    ADD CONSTRAINT staff_skills_check CHECK (jsonb_typeof(skills) = 'array');
+
+-- Новые сотрудники встают в конец: последовательность всегда не меньше числа записей,
+-- а reorder нумерует с 1
+CREATE SEQUENCE staff_display_order_seq;
+
+ALTER TABLE staff ADD COLUMN display_order INTEGER;
+
+UPDATE staff SET display_order = o.position
+FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY created_at, id) AS position FROM staff) AS o
+WHERE staff.id = o.id;
+
+SELECT setval('staff_display_order_seq', GREATEST((SELECT COUNT(*) FROM staff), 1));
+
+ALTER TABLE staff
+    ALTER COLUMN display_order SET DEFAULT nextval('staff_display_order_seq'),
+    ALTER COLUMN display_order SET NOT NULL;
+
+ALTER SEQUENCE staff_display_order_seq OWNED BY staff.display_order;
+
+CREATE INDEX idx_staff_display_order ON staff(display_order, id);
+CREATE INDEX idx_staff_department ON staff(department);
@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name = 'leads.manage';
+
+DROP TABLE IF EXISTS leads;
\ No newline at end of file
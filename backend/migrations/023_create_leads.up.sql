@@ -0,0 +1,26 @@
+-- Заявки с формы "заказать проект"; project_type/project_id - страница, с которой отправлена форма
+CREATE TABLE IF NOT EXISTS leads (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    contact VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    budget DECIMAL(12,2) CHECK (budget >= 0),
+    project_type VARCHAR(20) NOT NULL DEFAULT '' CHECK (project_type IN ('', 'web', 'mobile', 'bot')),
+    project_id INTEGER,
+    status VARCHAR(20) NOT NULL DEFAULT 'new' CHECK (status IN ('new', 'contacted', 'won', 'lost')),
+    assigned_to INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (project_id IS NULL OR project_type <> '')
+);
+
+CREATE INDEX IF NOT EXISTS idx_leads_created_at ON leads (created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_leads_status ON leads (status);
+CREATE INDEX IF NOT EXISTS idx_leads_assigned_to ON leads (assigned_to);
+
+INSERT INTO permissions (name, description) VALUES
+    ('leads.manage', 'Просмотр и обработка заявок с сайта');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'leads.manage'
+WHERE r.name IN ('superadmin', 'editor');
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_media_url;
+DROP INDEX IF EXISTS idx_media_source_url;
+
+ALTER TABLE media DROP COLUMN IF EXISTS source_url;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- Адрес, с которого картинка скачана: импорт по URL или внешний img проекта и сотрудника.
+-- Повторное сохранение записи с тем же img берет уже скачанный файл
+ALTER TABLE media ADD COLUMN source_url TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_media_source_url ON media (source_url) WHERE source_url IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_media_url ON media (url);
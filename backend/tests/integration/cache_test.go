@@ -2,12 +2,16 @@ package integration
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+	testutils "ASMO-site-backend/tests/testutils"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -137,4 +141,47 @@ func TestCacheInvalidationOnCreate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, false, response["cached"])
 	assert.Greater(t, len(response["projects"].([]interface{})), 0)
-}
\ No newline at end of file
+}
+func TestCacheInvalidationOnPurge(t *testing.T) {
+	router := setupTestRouter()
+	db, err := testutils.SetupTestDB()
+	assert.NoError(t, err)
+	repo := repository.NewBotProjectsRepository(db, testutils.NewRedisMock())
+	ctx := context.Background()
+
+	body, _ := json.Marshal(models.CreateBotsProjectRequest{
+		Name:        "Purge Cache Test Bot",
+		Description: "Bot project purged from the trash to check cache invalidation.",
+		Img:         "https://example.com/purge.jpg",
+		Price:       500.00,
+		TimeDevelop: 5,
+	})
+	req := httptest.NewRequest("POST", "/api/Bots/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var created models.BotsProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.NoError(t, repo.Delete(ctx, created.ID))
+
+	// Список после удаления попадает в кэш
+	_, cached, err := repo.List(ctx, repository.ListQuery{})
+	assert.NoError(t, err)
+	assert.False(t, cached)
+	_, cached, err = repo.List(ctx, repository.ListQuery{})
+	assert.NoError(t, err)
+	assert.True(t, cached)
+
+	_, err = db.Exec(`UPDATE bots_projects SET deleted_at = $1 WHERE id = $2`, time.Now().Add(-48*time.Hour), created.ID)
+	assert.NoError(t, err)
+	purged, err := repo.Purge(ctx, time.Now().Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, purged, 1)
+
+	// Purge инвалидирует те же ключи, что и Delete
+	_, cached, err = repo.List(ctx, repository.ListQuery{})
+	assert.NoError(t, err)
+	assert.False(t, cached)
+}
@@ -2,13 +2,21 @@ package integration
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"ASMO-site-backend/internal/auth"
 	"ASMO-site-backend/internal/handlers"
+	"ASMO-site-backend/internal/middleware"
 	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
 	"ASMO-site-backend/pkg/logger"
 	testutils "ASMO-site-backend/tests/testutils"
 	"ASMO-site-backend/internal/cache"
@@ -17,6 +25,8 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var testTokenManager = auth.NewTokenManager("integration-test-secret-0123456789", time.Minute)
+
 func setupTestRouter() *gin.Engine {
 	// Используем testutils для настройки базы данных
 	db, err := testutils.SetupTestDB()
@@ -38,6 +48,14 @@ func setupTestRouter() *gin.Engine {
 	mobileHandler := handlers.NewMobileProjectsHandler(db, cacheInterface)  // ✅ ИНТЕРФЕЙС
 	botHandler := handlers.NewBotProjectsHandler(db, cacheInterface)        // ✅ ИНТЕРФЕЙС
 	staffHandler := handlers.NewStaffHandler(db, cacheInterface)
+	searchHandler := handlers.NewSearchHandler(db, cacheInterface)
+	projectsHandler := handlers.NewProjectsHandler(db, cacheInterface)
+	authHandler := handlers.NewAuthHandler(db, cacheInterface, testTokenManager, time.Hour)
+	auditHandler := handlers.NewAuditHandler(db)
+	trashHandler := handlers.NewTrashHandler(db)
+	translationsHandler := handlers.NewTranslationsHandler(db)
+	tagsHandler := handlers.NewTagsHandler(db, cacheInterface)
+	leadsHandler := handlers.NewLeadsHandler(db, nil, nil)
 
 	router := gin.Default()
 
@@ -56,16 +74,55 @@ func setupTestRouter() *gin.Engine {
 		c.Next()
 	})
 
+	router.Use(middleware.Locale([]string{"ru", "en"}, "ru"))
+
+	// Сводные списки админки фильтруются по правам клиента; в тестах у него все права на контент
+	contentAdmin := func(c *gin.Context) {
+		c.Set(middleware.ContextPermissions, []string{auth.PermissionManageProjects, auth.PermissionManageStaff})
+	}
+
 	// Test routes
 	api := router.Group("/api")
 	{
 		api.GET("/health", healthHandler.HealthCheck)
+		api.GET("/search", searchHandler.Search)
+		api.GET("/Projects", projectsHandler.GetProjects)
+		api.POST("/auth/login", authHandler.Login)
+		api.POST("/auth/refresh", authHandler.Refresh)
+		api.POST("/auth/logout", authHandler.Logout)
+		api.GET("/admin/audit", auditHandler.GetAuditLog)
+		api.GET("/admin/trash", contentAdmin, trashHandler.GetTrash)
+		api.GET("/admin/translations/missing", contentAdmin, translationsHandler.GetMissingTranslations)
+		api.POST("/leads", leadsHandler.CreateLead)
+		api.GET("/admin/leads", leadsHandler.GetLeads)
+		api.GET("/admin/leads/:id", leadsHandler.GetLead)
+		api.PUT("/admin/leads/:id/assignee", leadsHandler.AssignLead)
+		api.PUT("/admin/leads/:id/status", leadsHandler.SetLeadStatus)
 
 		web := api.Group("/WebApplications")
 		{
 			web.GET("/:id", webHandler.GetWebProject)
 			web.GET("/", webHandler.GetWebProjects)
 			web.POST("/", webHandler.CreateWebProject)
+			web.PUT("/:id", webHandler.UpdateWebProject)
+			web.PATCH("/:id", webHandler.PatchWebProject)
+			web.DELETE("/:id", webHandler.DeleteWebProject)
+			web.POST("/:id/restore", webHandler.RestoreWebProject)
+			web.POST("/:id/submit", webHandler.TransitionWebProject("submit"))
+			web.POST("/:id/publish", webHandler.TransitionWebProject("publish"))
+			web.PUT("/:id/schedule", webHandler.ScheduleWebProject)
+			web.GET("/:id/revisions/", webHandler.GetWebProjectRevisions)
+			web.GET("/:id/revisions/:rev", webHandler.GetWebProjectRevision)
+			web.POST("/:id/revisions/:rev/restore", webHandler.RestoreWebProjectRevision)
+			web.GET("/:id/translations/", webHandler.GetWebProjectTranslations)
+			web.PUT("/:id/translations/:locale", webHandler.SetWebProjectTranslation)
+			web.DELETE("/:id/translations/:locale", webHandler.DeleteWebProjectTranslation)
+			web.POST("/:id/media", webHandler.AddWebProjectMedia)
+			web.PUT("/:id/media/order", webHandler.ReorderWebProjectMedia)
+			web.DELETE("/:id/media/:item", webHandler.RemoveWebProjectMedia)
+			web.PUT("/:id/tags", webHandler.SetWebProjectTags)
+			web.PUT("/:id/team/:staff_id", webHandler.AssignWebProjectMember)
+			web.DELETE("/:id/team/:staff_id", webHandler.UnassignWebProjectMember)
 		}
 
 		mobile := api.Group("/MobileApplications")
@@ -73,6 +130,25 @@ func setupTestRouter() *gin.Engine {
 			mobile.GET("/:id", mobileHandler.GetMobileProject)
 			mobile.GET("/", mobileHandler.GetMobileProjects)
 			mobile.POST("/", mobileHandler.CreateMobileProject)
+			mobile.PUT("/:id", mobileHandler.UpdateMobileProject)
+			mobile.PATCH("/:id", mobileHandler.PatchMobileProject)
+			mobile.DELETE("/:id", mobileHandler.DeleteMobileProject)
+			mobile.POST("/:id/restore", mobileHandler.RestoreMobileProject)
+			mobile.POST("/:id/submit", mobileHandler.TransitionMobileProject("submit"))
+			mobile.POST("/:id/publish", mobileHandler.TransitionMobileProject("publish"))
+			mobile.PUT("/:id/tags", mobileHandler.SetMobileProjectTags)
+			mobile.PUT("/:id/team/:staff_id", mobileHandler.AssignMobileProjectMember)
+			mobile.DELETE("/:id/team/:staff_id", mobileHandler.UnassignMobileProjectMember)
+		}
+
+		tags := api.Group("/Tags")
+		{
+			tags.GET("/", tagsHandler.GetTags)
+			tags.GET("/cloud", tagsHandler.GetTagCloud)
+			tags.GET("/:id", tagsHandler.GetTag)
+			tags.POST("/", tagsHandler.CreateTag)
+			tags.PATCH("/:id", tagsHandler.PatchTag)
+			tags.DELETE("/:id", tagsHandler.DeleteTag)
 		}
 
 		bots := api.Group("/Bots")
@@ -80,6 +156,12 @@ func setupTestRouter() *gin.Engine {
 			bots.GET("/:id", botHandler.GetBotProject)
 			bots.GET("/", botHandler.GetBotProjects)
 			bots.POST("/", botHandler.CreateBotProject)
+			bots.PUT("/:id", botHandler.UpdateBotProject)
+			bots.PATCH("/:id", botHandler.PatchBotProject)
+			bots.DELETE("/:id", botHandler.DeleteBotProject)
+			bots.POST("/:id/restore", botHandler.RestoreBotProject)
+			bots.POST("/:id/submit", botHandler.TransitionBotProject("submit"))
+			bots.POST("/:id/publish", botHandler.TransitionBotProject("publish"))
 		}
 
 		staff := api.Group("/Staff")
@@ -87,12 +169,34 @@ func setupTestRouter() *gin.Engine {
 			staff.GET("/:id", staffHandler.GetStaffMember)
 			staff.GET("/", staffHandler.GetStaff)
 			staff.POST("/", staffHandler.CreateStaff)
+			staff.PUT("/order", staffHandler.ReorderStaff)
+			staff.PUT("/:id", staffHandler.UpdateStaff)
+			staff.PATCH("/:id", staffHandler.PatchStaff)
+			staff.DELETE("/:id", staffHandler.DeleteStaff)
+			staff.POST("/:id/restore", staffHandler.RestoreStaff)
+			staff.POST("/:id/submit", staffHandler.TransitionStaff("submit"))
+			staff.POST("/:id/publish", staffHandler.TransitionStaff("publish"))
 		}
 	}
 
 	return router
 }
 
+// publishCreated проводит только что созданную запись через submit и publish,
+// иначе черновик не виден в публичных списках
+func publishCreated(t *testing.T, router *gin.Engine, base string, created *httptest.ResponseRecorder) {
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(created.Body.Bytes(), &response))
+	id := int(response["id"].(float64))
+
+	for _, action := range []string{"submit", "publish"} {
+		req := httptest.NewRequest("POST", fmt.Sprintf("%s/%d/%s", base, id, action), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
+
 func TestHealthCheck(t *testing.T) {
 	router := setupTestRouter()
 
@@ -239,6 +343,7 @@ func TestGetStaff(t *testing.T) {
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
+	publishCreated(t, router, "/api/Staff", w)
 
 	// Now get all staff - первый запрос должен быть из БД
 	req = httptest.NewRequest("GET", "/api/Staff", nil)
@@ -321,4 +426,1377 @@ func TestHealthCheckDatabaseStatus(t *testing.T) {
 	assert.Contains(t, response.Timestamp, "server")
 	assert.Contains(t, response.Timestamp, "unix")
 	assert.Contains(t, response.Timestamp, "iso")
-}
\ No newline at end of file
+}
+func TestUpdatePatchDeleteWebProject(t *testing.T) {
+	router := setupTestRouter()
+
+	project := models.CreateWebProjectRequest{
+		Name:        "Web Project Before Update",
+		Description: "This is a test description for web project update and delete testing purposes.",
+		Img:         "https://example.com/update.jpg",
+		Price:       1000.00,
+		TimeDevelop: 20,
+	}
+
+	body, _ := json.Marshal(project)
+	req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var created map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	path := fmt.Sprintf("/api/WebApplications/%d", int(created["id"].(float64)))
+
+	// PUT - полная замена
+	project.Name = "Web Project After Full Update"
+	body, _ = json.Marshal(project)
+	req = httptest.NewRequest("PUT", path, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var updated models.WebProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, "Web Project After Full Update", updated.Name)
+
+	// PATCH - только цена
+	req = httptest.NewRequest("PATCH", path, bytes.NewBufferString(`{"price": 2500}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, "Web Project After Full Update", updated.Name)
+	assert.Equal(t, 2500.0, updated.Price)
+
+	// PATCH без полей
+	req = httptest.NewRequest("PATCH", path, bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// DELETE и повторный GET
+	req = httptest.NewRequest("DELETE", path, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", path, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestWebProjectsPagination(t *testing.T) {
+	router := setupTestRouter()
+
+	for i, price := range []float64{500, 1500, 2500} {
+		project := models.CreateWebProjectRequest{
+			Name:        fmt.Sprintf("Pagination Web Project %d", i),
+			Description: "This is a test description for web project pagination testing purposes.",
+			Img:         "https://example.com/page.jpg",
+			Price:       price,
+			TimeDevelop: 10 + i,
+		}
+
+		body, _ := json.Marshal(project)
+		req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		publishCreated(t, router, "/api/WebApplications", w)
+	}
+
+	req := httptest.NewRequest("GET", "/api/WebApplications/?per_page=1&sort=price&order=asc&price_min=1000", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), response["count"])
+	assert.GreaterOrEqual(t, response["total"].(float64), float64(2))
+
+	links := response["links"].(map[string]interface{})
+	assert.NotNil(t, links["next"])
+	assert.Nil(t, links["prev"])
+
+	projects := response["projects"].([]interface{})
+	first := projects[0].(map[string]interface{})
+	assert.GreaterOrEqual(t, first["price"].(float64), 1000.0)
+
+	// Недопустимое поле сортировки
+	req = httptest.NewRequest("GET", "/api/WebApplications/?sort=img", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSearch(t *testing.T) {
+	router := setupTestRouter()
+
+	project := models.CreateBotsProjectRequest{
+		Name:        "Searchable Telegram Assistant",
+		Description: "Бот для записи клиентов в салон красоты через Telegram с напоминаниями <script>alert(1)</script>.",
+		Img:         "https://example.com/search.jpg",
+		Price:       900.00,
+		TimeDevelop: 14,
+	}
+
+	body, _ := json.Marshal(project)
+	req := httptest.NewRequest("POST", "/api/Bots/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	publishCreated(t, router, "/api/Bots", w)
+
+	req = httptest.NewRequest("GET", "/api/search?q=напоминание", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, false, response["cached"])
+
+	results := response["results"].(map[string]interface{})
+	bots := results["bots_projects"].([]interface{})
+	assert.Greater(t, len(bots), 0)
+	snippet := bots[0].(map[string]interface{})["snippet"].(string)
+	assert.Contains(t, snippet, "<mark>")
+	// Разметка из описания экранирована, выделение совпадений - нет
+	assert.NotContains(t, snippet, "<script>")
+	assert.Contains(t, snippet, "&lt;script&gt;")
+
+	// Слишком короткий запрос
+	req = httptest.NewRequest("GET", "/api/search?q=a", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestProjectsCatalog(t *testing.T) {
+	router := setupTestRouter()
+
+	web := models.CreateWebProjectRequest{
+		Name:        "Catalog Web Project Example",
+		Description: "This is a test description for the unified projects catalog endpoint.",
+		Img:         "https://example.com/catalog-web.jpg",
+		Price:       1200.00,
+		TimeDevelop: 20,
+	}
+	body, _ := json.Marshal(web)
+	req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	publishCreated(t, router, "/api/WebApplications", w)
+
+	mobile := models.CreateMobileProjectRequest{
+		Name:        "Catalog Mobile Project Example",
+		Description: "This is a test description for the unified projects catalog endpoint.",
+		Img:         "https://example.com/catalog-mobile.jpg",
+		Price:       2200.00,
+		TimeDevelop: 40,
+	}
+	body, _ = json.Marshal(mobile)
+	req = httptest.NewRequest("POST", "/api/MobileApplications/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	publishCreated(t, router, "/api/MobileApplications", w)
+
+	req = httptest.NewRequest("GET", "/api/Projects?type=mobile", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	projects := response["projects"].([]interface{})
+	assert.Greater(t, len(projects), 0)
+	for _, p := range projects {
+		assert.Equal(t, "mobile", p.(map[string]interface{})["type"])
+	}
+
+	req = httptest.NewRequest("GET", "/api/Projects?type=desktop", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateAdminUserWithRoles(t *testing.T) {
+	db, err := testutils.SetupTestDB()
+	assert.NoError(t, err)
+	users := repository.NewAdminUsersRepository(db, testutils.NewRedisMock())
+
+	hash, err := auth.HashPassword("super-secret-password")
+	assert.NoError(t, err)
+
+	// Неизвестная роль откатывает и создание пользователя
+	username := fmt.Sprintf("roles_%d", time.Now().UnixNano())
+	_, err = users.Create(context.Background(), username, hash, []string{"editor", "no-such-role"})
+	assert.ErrorIs(t, err, repository.ErrUnknownRole)
+	_, err = users.FindByUsername(context.Background(), username)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	user, err := users.Create(context.Background(), username, hash, []string{"editor"})
+	assert.NoError(t, err)
+	stored, err := users.FindByID(context.Background(), user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"editor"}, stored.Roles)
+}
+
+func TestDeletedAdminLosesAccess(t *testing.T) {
+	db, err := testutils.SetupTestDB()
+	assert.NoError(t, err)
+
+	// Права и пользователи делят один кэш, как в main
+	redisMock := testutils.NewRedisMock()
+	users := repository.NewAdminUsersRepository(db, redisMock)
+	adminUsersHandler := handlers.NewAdminUsersHandler(db, redisMock)
+
+	router := gin.New()
+	admin := router.Group("/api/admin/users",
+		middleware.RequireAuth(testTokenManager),
+		middleware.RequirePermission(repository.NewRolesRepository(db, redisMock), auth.PermissionManageUsers),
+	)
+	admin.GET("/", adminUsersHandler.ListUsers)
+	admin.DELETE("/:id", adminUsersHandler.DeleteUser)
+
+	hash, err := auth.HashPassword("super-secret-password")
+	assert.NoError(t, err)
+	send := func(method, path string, user models.AdminUser) int {
+		token, _, err := testTokenManager.IssueAccessToken(user.ID, user.Username)
+		assert.NoError(t, err)
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	suffix := time.Now().UnixNano()
+	owner, err := users.Create(context.Background(), fmt.Sprintf("owner_%d", suffix), hash, []string{auth.RoleSuperadmin})
+	assert.NoError(t, err)
+	deleted, err := users.Create(context.Background(), fmt.Sprintf("deleted_%d", suffix), hash, []string{auth.RoleSuperadmin})
+	assert.NoError(t, err)
+
+	// Первый запрос кладет права пользователя в кэш
+	assert.Equal(t, http.StatusOK, send("GET", "/api/admin/users/", deleted))
+	assert.Equal(t, http.StatusOK, send("DELETE", fmt.Sprintf("/api/admin/users/%d", deleted.ID), owner))
+
+	// Токен еще действует, но прав из кэша уже нет
+	assert.Equal(t, http.StatusForbidden, send("GET", "/api/admin/users/", deleted))
+}
+
+func TestAdminLoginAndRefresh(t *testing.T) {
+	router := setupTestRouter()
+
+	db, err := testutils.SetupTestDB()
+	assert.NoError(t, err)
+
+	username := fmt.Sprintf("admin_%d", time.Now().UnixNano())
+	hash, err := auth.HashPassword("super-secret-password")
+	assert.NoError(t, err)
+	_, err = repository.NewAdminUsersRepository(db, testutils.NewRedisMock()).Create(context.Background(), username, hash, nil)
+	assert.NoError(t, err)
+
+	// Неверный пароль
+	body, _ := json.Marshal(models.LoginRequest{Username: username, Password: "wrong-password"})
+	req := httptest.NewRequest("POST", "/api/auth/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Верный пароль
+	body, _ = json.Marshal(models.LoginRequest{Username: username, Password: "super-secret-password"})
+	req = httptest.NewRequest("POST", "/api/auth/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var tokens models.TokenResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	assert.NotEmpty(t, tokens.AccessToken)
+
+	claims, err := testTokenManager.ParseAccessToken(tokens.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, username, claims.Username)
+
+	// Refresh выдает новую пару, старый refresh-токен больше не работает
+	body, _ = json.Marshal(models.RefreshTokenRequest{RefreshToken: tokens.RefreshToken})
+	req = httptest.NewRequest("POST", "/api/auth/refresh", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("POST", "/api/auth/refresh", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuditLog(t *testing.T) {
+	router := setupTestRouter()
+
+	member := models.CreateStaffRequest{
+		Name:        "Audit Test Member",
+		Description: "Staff member created to check that writes are recorded in the audit log.",
+		Img:         "https://example.com/audit.jpg",
+		Role:        "QA Engineer",
+	}
+
+	body, _ := json.Marshal(member)
+	req := httptest.NewRequest("POST", "/api/Staff/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var created map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	id := int(created["id"].(float64))
+
+	req = httptest.NewRequest("PATCH", fmt.Sprintf("/api/Staff/%d", id), bytes.NewBufferString(`{"role": "Lead QA Engineer"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("/api/admin/audit?resource_type=staff&resource_id=%d", id), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Entries []models.AuditEntry `json:"entries"`
+		Total   int                 `json:"total"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Total)
+	if assert.Len(t, response.Entries, 2) {
+		// Новые записи первыми
+		assert.Equal(t, "update", response.Entries[0].Action)
+		assert.JSONEq(t, `{"role": "QA Engineer"}`, string(response.Entries[0].Before))
+		assert.JSONEq(t, `{"role": "Lead QA Engineer"}`, string(response.Entries[0].After))
+		assert.Equal(t, "create", response.Entries[1].Action)
+		assert.JSONEq(t, "null", string(response.Entries[1].Before))
+	}
+}
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	router := setupTestRouter()
+
+	project := models.CreateBotsProjectRequest{
+		Name:        "Bot Project For Trash Test",
+		Description: "This bot project is deleted to the trash and then restored back.",
+		Img:         "https://example.com/trash.jpg",
+		Price:       700.00,
+		TimeDevelop: 10,
+	}
+
+	body, _ := json.Marshal(project)
+	req := httptest.NewRequest("POST", "/api/Bots/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	publishCreated(t, router, "/api/Bots", w)
+
+	var created map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	id := int(created["id"].(float64))
+	path := fmt.Sprintf("/api/Bots/%d", id)
+
+	req = httptest.NewRequest("DELETE", path, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Удаленный проект не виден, но лежит в корзине
+	req = httptest.NewRequest("GET", path, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/admin/trash?type=bot&per_page=100", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var trash struct {
+		Items []models.TrashItem `json:"items"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &trash))
+	found := false
+	for _, item := range trash.Items {
+		if item.ID == id && item.Type == "bot" {
+			found = true
+		}
+	}
+	assert.True(t, found, "deleted project should be in trash")
+
+	req = httptest.NewRequest("POST", path+"/restore", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", path, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Повторное восстановление - записи в корзине уже нет
+	req = httptest.NewRequest("POST", path+"/restore", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPublicationWorkflow(t *testing.T) {
+	router := setupTestRouter()
+
+	member := models.CreateStaffRequest{
+		Name:        "Workflow Test Member",
+		Description: "Staff member used to check the draft, review and publish workflow.",
+		Img:         "https://example.com/workflow.jpg",
+		Role:        "Designer",
+	}
+
+	body, _ := json.Marshal(member)
+	req := httptest.NewRequest("POST", "/api/Staff/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var created map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	path := fmt.Sprintf("/api/Staff/%d", int(created["id"].(float64)))
+
+	// Новый сотрудник - черновик и публично не виден
+	req = httptest.NewRequest("GET", path, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Публиковать можно только после отправки на ревью
+	req = httptest.NewRequest("POST", path+"/publish", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	for _, action := range []string{"submit", "publish"} {
+		req = httptest.NewRequest("POST", path+"/"+action, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	req = httptest.NewRequest("GET", path, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var published models.Staff
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &published))
+	assert.Equal(t, models.StatusPublished, published.Status)
+	assert.NotNil(t, published.ApprovedAt)
+}
+
+func TestScheduledPublishing(t *testing.T) {
+	router := setupTestRouter()
+
+	project := models.CreateWebProjectRequest{
+		Name:        "Scheduled Web Project Launch",
+		Description: "This web project goes live together with the press release.",
+		Img:         "https://example.com/scheduled.jpg",
+		Price:       3000.00,
+		TimeDevelop: 45,
+	}
+
+	body, _ := json.Marshal(project)
+	req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var created map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	path := fmt.Sprintf("/api/WebApplications/%d", int(created["id"].(float64)))
+
+	publishAt := time.Now().Add(-time.Minute).UTC()
+	body, _ = json.Marshal(models.ScheduleRequest{PublishAt: &publishAt})
+	req = httptest.NewRequest("PUT", path+"/schedule", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var scheduled models.WebProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &scheduled))
+	assert.Equal(t, models.StatusDraft, scheduled.Status)
+	assert.NotNil(t, scheduled.ApprovedAt)
+
+	// У роутера свой мок кэша, поэтому до этого момента проект не запрашиваем через GET
+	db, err := testutils.SetupTestDB()
+	assert.NoError(t, err)
+	changed, err := repository.NewWebProjectsRepository(db, testutils.NewRedisMock()).ApplySchedule(context.Background(), time.Now())
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, changed, 1)
+
+	req = httptest.NewRequest("GET", path, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var published models.WebProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &published))
+	assert.Equal(t, models.StatusPublished, published.Status)
+	assert.Nil(t, published.PublishAt)
+}
+
+func TestRevisionHistory(t *testing.T) {
+	router := setupTestRouter()
+
+	project := models.CreateWebProjectRequest{
+		Name:        "Web Project Original Name",
+		Description: "This web project is edited and then rolled back to the first revision.",
+		Img:         "https://example.com/revision.jpg",
+		Price:       1200.00,
+		TimeDevelop: 15,
+	}
+
+	body, _ := json.Marshal(project)
+	req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var created map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	path := fmt.Sprintf("/api/WebApplications/%d", int(created["id"].(float64)))
+
+	req = httptest.NewRequest("PATCH", path, bytes.NewBufferString(`{"name": "Web Project Renamed Later", "price": 1500}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Перед изменением сохранена исходная версия
+	req = httptest.NewRequest("GET", path+"/revisions/", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var list struct {
+		Data  []models.Revision `json:"data"`
+		Count int               `json:"count"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, 1, list.Count)
+
+	req = httptest.NewRequest("GET", path+"/revisions/1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var revision struct {
+		Diff map[string]map[string]interface{} `json:"diff"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &revision))
+	assert.Equal(t, "Web Project Original Name", revision.Diff["name"]["revision"])
+	assert.Equal(t, "Web Project Renamed Later", revision.Diff["name"]["current"])
+	assert.Contains(t, revision.Diff, "price")
+
+	// Откат возвращает поля и сам попадает в историю
+	req = httptest.NewRequest("POST", path+"/revisions/1/restore", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var restored models.WebProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &restored))
+	assert.Equal(t, "Web Project Original Name", restored.Name)
+	assert.Equal(t, 1200.0, restored.Price)
+
+	req = httptest.NewRequest("GET", path+"/revisions/", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, 2, list.Count)
+
+	req = httptest.NewRequest("GET", path+"/revisions/5", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSlugs(t *testing.T) {
+	router := setupTestRouter()
+
+	project := models.CreateWebProjectRequest{
+		Name:        "Лендинг для кофейни Ёжик",
+		Description: "Проект для проверки slug с транслитерацией кириллицы и редиректов.",
+		Img:         "https://example.com/slug.jpg",
+		Price:       900.00,
+		TimeDevelop: 7,
+	}
+
+	create := func() models.WebProjects {
+		body, _ := json.Marshal(project)
+		req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		publishCreated(t, router, "/api/WebApplications", w)
+
+		var created models.WebProjects
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		return created
+	}
+
+	first := create()
+	assert.True(t, strings.HasPrefix(first.Slug, "lending-dlya-kofeyni-ezhik"))
+
+	// Совпадающее название получает суффикс
+	second := create()
+	assert.NotEqual(t, first.Slug, second.Slug)
+	assert.True(t, strings.HasPrefix(second.Slug, first.Slug+"-"))
+
+	req := httptest.NewRequest("GET", "/api/WebApplications/"+first.Slug, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var fetched models.WebProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &fetched))
+	assert.Equal(t, first.ID, fetched.ID)
+
+	// Переименование меняет slug, старый отвечает 301
+	req = httptest.NewRequest("PATCH", fmt.Sprintf("/api/WebApplications/%d", first.ID), bytes.NewBufferString(`{"name": "Лендинг для кофейни Белка"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var renamed models.WebProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &renamed))
+	assert.True(t, strings.HasPrefix(renamed.Slug, "lending-dlya-kofeyni-belka"))
+
+	req = httptest.NewRequest("GET", "/api/WebApplications/"+first.Slug, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/api/WebApplications/"+renamed.Slug, w.Header().Get("Location"))
+
+	req = httptest.NewRequest("GET", "/api/WebApplications/no-such-project-slug", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestConcurrentSlugs(t *testing.T) {
+	router := setupTestRouter()
+
+	body, _ := json.Marshal(models.CreateWebProjectRequest{
+		Name:        "Одновременный slug проекта",
+		Description: "Проекты с одинаковым названием создаются одновременно и получают разные slug.",
+		Img:         "https://example.com/concurrent-slug.jpg",
+		Price:       900.00,
+		TimeDevelop: 7,
+	})
+
+	const creates = 5
+	var wg sync.WaitGroup
+	results := make([]*httptest.ResponseRecorder, creates)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			results[i] = httptest.NewRecorder()
+			router.ServeHTTP(results[i], req)
+		}(i)
+	}
+	wg.Wait()
+
+	slugs := map[string]bool{}
+	for _, w := range results {
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var created models.WebProjects
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		assert.False(t, slugs[created.Slug], "slug %q assigned twice", created.Slug)
+		slugs[created.Slug] = true
+	}
+}
+
+func TestTranslations(t *testing.T) {
+	router := setupTestRouter()
+
+	project := models.CreateWebProjectRequest{
+		Name:        "Лендинг для пекарни на углу",
+		Description: "Русское описание проекта для проверки переводов и запасной локали.",
+		Img:         "https://example.com/bakery.jpg",
+		Price:       800.00,
+		TimeDevelop: 9,
+	}
+
+	body, _ := json.Marshal(project)
+	req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	publishCreated(t, router, "/api/WebApplications", w)
+
+	var created models.WebProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	path := fmt.Sprintf("/api/WebApplications/%d", created.ID)
+
+	get := func(target, acceptLanguage string) models.WebProjects {
+		req := httptest.NewRequest("GET", target, nil)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var project models.WebProjects
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+		return project
+	}
+
+	missing := func() bool {
+		req := httptest.NewRequest("GET", "/api/admin/translations/missing?locale=en&type=web&per_page=100", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// Новые записи идут первыми
+		var report struct {
+			Items []models.MissingTranslation `json:"items"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		for _, item := range report.Items {
+			if item.ID == created.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Без перевода английский запрос получает основной язык
+	assert.Equal(t, project.Name, get(path+"?lang=en", "").Name)
+	assert.True(t, missing(), "project without translation should be reported")
+
+	req = httptest.NewRequest("PUT", path+"/translations/en", bytes.NewBufferString(`{"name": "Bakery landing page", "description": "English description of the bakery landing project."}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.Equal(t, "Bakery landing page", get(path+"?lang=en", "").Name)
+	assert.Equal(t, "Bakery landing page", get(path, "en-US,en;q=0.9").Name)
+	assert.Equal(t, project.Name, get(path, "").Name)
+	assert.False(t, missing(), "translated project should not be reported")
+
+	req = httptest.NewRequest("DELETE", path+"/translations/en", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, project.Name, get(path+"?lang=en", "").Name)
+}
+
+func TestProjectGallery(t *testing.T) {
+	router := setupTestRouter()
+	db, err := testutils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	media, err := repository.NewMediaRepository(db, testutils.NewRedisMock()).Create(context.Background(), models.Media{
+		Key:          fmt.Sprintf("test/gallery-%d.png", time.Now().UnixNano()),
+		URL:          "https://cdn.example.com/gallery.png",
+		OriginalName: "gallery.png",
+		MimeType:     "image/png",
+		Size:         1024,
+		Images:       models.Images{Original: models.ImageVariant{URL: "https://cdn.example.com/gallery.png", Width: 1600, Height: 900}},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	project := models.CreateWebProjectRequest{
+		Name:        "Кейс с галереей и видео",
+		Description: "Проект, у которого несколько скриншотов и видеодемонстрация работы.",
+		Img:         "https://example.com/gallery-cover.jpg",
+		Price:       1200.00,
+		TimeDevelop: 14,
+	}
+	body, _ := json.Marshal(project)
+	req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	publishCreated(t, router, "/api/WebApplications", w)
+
+	var created models.WebProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	path := fmt.Sprintf("/api/WebApplications/%d", created.ID)
+
+	add := func(payload string) models.ProjectMedia {
+		req := httptest.NewRequest("POST", path+"/media", bytes.NewBufferString(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var item models.ProjectMedia
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &item))
+		return item
+	}
+
+	image := add(fmt.Sprintf(`{"kind": "image", "media_id": %d, "caption": "Главная страница"}`, media.ID))
+	video := add(`{"kind": "video", "url": "https://vimeo.com/76979871", "caption": "Демо"}`)
+	file := add(`{"kind": "video", "url": "https://cdn.example.com/demo.mp4"}`)
+	assert.Equal(t, media.URL, image.URL)
+	if assert.NotNil(t, image.Images) {
+		assert.Equal(t, 1600, image.Images.Original.Width)
+	}
+	assert.Equal(t, []int{1, 2, 3}, []int{image.Position, video.Position, file.Position})
+
+	gallery := func() []models.ProjectMedia {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var project models.WebProjects
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+		return project.Gallery
+	}
+	ids := func(items []models.ProjectMedia) []int {
+		result := make([]int, len(items))
+		for i, item := range items {
+			result[i] = item.ID
+		}
+		return result
+	}
+	assert.Equal(t, []int{image.ID, video.ID, file.ID}, ids(gallery()))
+
+	// Порядок должен перечислять все элементы
+	req = httptest.NewRequest("PUT", path+"/media/order", bytes.NewBufferString(fmt.Sprintf(`{"ids": [%d, %d]}`, file.ID, image.ID)))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest("PUT", path+"/media/order", bytes.NewBufferString(fmt.Sprintf(`{"ids": [%d, %d, %d]}`, file.ID, image.ID, video.ID)))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []int{file.ID, image.ID, video.ID}, ids(gallery()))
+
+	req = httptest.NewRequest("DELETE", fmt.Sprintf("%s/media/%d", path, image.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	remaining := gallery()
+	assert.Equal(t, []int{file.ID, video.ID}, ids(remaining))
+	if assert.Len(t, remaining, 2) {
+		assert.Equal(t, 2, remaining[1].Position, "positions are renumbered after delete")
+	}
+}
+
+func TestProjectTypeFields(t *testing.T) {
+	router := setupTestRouter()
+
+	mobile := models.CreateMobileProjectRequest{
+		Name:          "Мобильное приложение магазина",
+		Description:   "Приложение опубликовано в обоих сторах и поддерживает iOS и Android.",
+		Img:           "https://example.com/mobile-stores.jpg",
+		Price:         3000.00,
+		TimeDevelop:   60,
+		AppStoreURL:   "https://apps.apple.com/ru/app/asmo-shop/id1234567890",
+		GooglePlayURL: "https://play.google.com/store/apps/details?id=ru.asmo.shop",
+		Platforms:     []string{"ios", "android"},
+	}
+	body, _ := json.Marshal(mobile)
+	req := httptest.NewRequest("POST", "/api/MobileApplications/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+		return
+	}
+
+	var created map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	path := fmt.Sprintf("/api/MobileApplications/%d", int(created["id"].(float64)))
+
+	// PATCH списка платформ не трогает ссылки на сторы
+	req = httptest.NewRequest("PATCH", path, bytes.NewBufferString(`{"platforms": ["android"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var patched models.MobileProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &patched))
+	assert.Equal(t, []string{"android"}, patched.Platforms)
+	assert.Equal(t, mobile.AppStoreURL, patched.AppStoreURL)
+	assert.Equal(t, mobile.GooglePlayURL, patched.GooglePlayURL)
+
+	req = httptest.NewRequest("PATCH", path, bytes.NewBufferString(`{"app_store_url": "https://example.com/app"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	bot := models.CreateBotsProjectRequest{
+		Name:        "Telegram-бот поддержки клиентов",
+		Description: "Бот отвечает на частые вопросы и передает сложные обращения операторам.",
+		Img:         "https://example.com/support-bot.jpg",
+		Price:       700.00,
+		TimeDevelop: 10,
+		Platform:    "telegram",
+		Handle:      "@AsmoSupportBot",
+	}
+	body, _ = json.Marshal(bot)
+	req = httptest.NewRequest("POST", "/api/Bots/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+		return
+	}
+
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	path = fmt.Sprintf("/api/Bots/%d", int(created["id"].(float64)))
+
+	req = httptest.NewRequest("PATCH", path, bytes.NewBufferString(`{"price": 750}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var botProject models.BotsProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &botProject))
+	assert.Equal(t, "telegram", botProject.Platform)
+	assert.Equal(t, "AsmoSupportBot", botProject.Handle)
+
+	// Имя без суффикса bot не подходит для Telegram
+	bot.Handle = "asmo_support"
+	body, _ = json.Marshal(bot)
+	req = httptest.NewRequest("POST", "/api/Bots/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestProjectTags(t *testing.T) {
+	router := setupTestRouter()
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano())
+
+	createTag := func(name, slug string) models.Tag {
+		body := fmt.Sprintf(`{"name": %q, "slug": %q, "kind": "technology"}`, name, slug)
+		req := httptest.NewRequest("POST", "/api/Tags/", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var tag models.Tag
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tag))
+		return tag
+	}
+	goTag := createTag("Go", "go-"+suffix)
+	reactTag := createTag("React", "react-"+suffix)
+
+	// Повторный slug
+	req := httptest.NewRequest("POST", "/api/Tags/", bytes.NewBufferString(fmt.Sprintf(`{"name": "Golang", "slug": %q}`, goTag.Slug)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	createProject := func(base string, project interface{}) string {
+		body, _ := json.Marshal(project)
+		req := httptest.NewRequest("POST", base+"/", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		publishCreated(t, router, base, w)
+
+		var created map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		return fmt.Sprintf("%s/%d", base, int(created["id"].(float64)))
+	}
+	webPath := createProject("/api/WebApplications", models.CreateWebProjectRequest{
+		Name:        "Web Project Tagged Go React",
+		Description: "This is a test description for filtering projects by technology tags.",
+		Img:         "https://example.com/tagged-web.jpg",
+		Price:       1500.00,
+		TimeDevelop: 30,
+	})
+	mobilePath := createProject("/api/MobileApplications", models.CreateMobileProjectRequest{
+		Name:        "Mobile Project Tagged With Go",
+		Description: "This is a test description for filtering projects by technology tags.",
+		Img:         "https://example.com/tagged-mobile.jpg",
+		Price:       2500.00,
+		TimeDevelop: 45,
+	})
+
+	setTags := func(path string, slugs ...string) int {
+		body, _ := json.Marshal(models.SetProjectTagsRequest{Tags: slugs})
+		req := httptest.NewRequest("PUT", path+"/tags", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	assert.Equal(t, http.StatusOK, setTags(webPath, strings.ToUpper(goTag.Slug), reactTag.Slug))
+	assert.Equal(t, http.StatusOK, setTags(mobilePath, goTag.Slug))
+	assert.Equal(t, http.StatusBadRequest, setTags(mobilePath, "missing-"+suffix))
+
+	list := func(path string) map[string]interface{} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	// Нужны все перечисленные теги
+	response := list("/api/WebApplications?tags=" + reactTag.Slug + "," + goTag.Slug)
+	assert.Equal(t, 1.0, response["total"])
+	response = list("/api/MobileApplications?tags=" + reactTag.Slug + "," + goTag.Slug)
+	assert.Equal(t, 0.0, response["total"])
+	response = list("/api/Projects?tags=" + goTag.Slug)
+	assert.Equal(t, 2.0, response["total"])
+
+	req = httptest.NewRequest("GET", webPath, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var project models.WebProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+	assert.Len(t, project.Tags, 2)
+
+	counts := map[string]float64{}
+	for _, item := range list("/api/Tags/cloud?kind=technology")["tags"].([]interface{}) {
+		tag := item.(map[string]interface{})
+		counts[tag["slug"].(string)] = tag["count"].(float64)
+	}
+	assert.Equal(t, 2.0, counts[goTag.Slug])
+	assert.Equal(t, 1.0, counts[reactTag.Slug])
+
+	// Удаление тега снимает его с проектов и сбрасывает выборки
+	req = httptest.NewRequest("DELETE", fmt.Sprintf("/api/Tags/%d", reactTag.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	response = list("/api/WebApplications?tags=" + reactTag.Slug)
+	assert.Equal(t, 0.0, response["total"])
+}
+
+func TestProjectTeam(t *testing.T) {
+	router := setupTestRouter()
+
+	create := func(base string, record interface{}) (string, int) {
+		body, _ := json.Marshal(record)
+		req := httptest.NewRequest("POST", base+"/", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		publishCreated(t, router, base, w)
+
+		var created map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		id := int(created["id"].(float64))
+		return fmt.Sprintf("%s/%d", base, id), id
+	}
+	projectPath, _ := create("/api/WebApplications", models.CreateWebProjectRequest{
+		Name:        "Web Project With Team Members",
+		Description: "This is a test description for assigning staff members to a project team.",
+		Img:         "https://example.com/team-web.jpg",
+		Price:       1200.00,
+		TimeDevelop: 20,
+	})
+	staffPath, staffID := create("/api/Staff", models.CreateStaffRequest{
+		Name:        "Team Member Backend Developer",
+		Description: "Staff member created to check project team assignments and profile projects.",
+		Img:         "https://example.com/team-staff.jpg",
+		Role:        "Backend Developer",
+	})
+
+	assign := func(staffID int, role string) int {
+		body, _ := json.Marshal(models.AssignTeamMemberRequest{Role: role})
+		req := httptest.NewRequest("PUT", fmt.Sprintf("%s/team/%d", projectPath, staffID), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	assert.Equal(t, http.StatusOK, assign(staffID, "frontend"))
+	// Повторное назначение меняет роль
+	assert.Equal(t, http.StatusOK, assign(staffID, "backend"))
+	assert.Equal(t, http.StatusBadRequest, assign(999999, "backend"))
+
+	req := httptest.NewRequest("GET", projectPath, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var project models.WebProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+	if assert.Len(t, project.Team, 1) {
+		assert.Equal(t, staffID, project.Team[0].StaffID)
+		assert.Equal(t, "backend", project.Team[0].Role)
+		assert.Equal(t, "Backend Developer", project.Team[0].Title)
+	}
+
+	req = httptest.NewRequest("GET", staffPath, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var member models.Staff
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &member))
+	if assert.Len(t, member.Projects, 1) {
+		assert.Equal(t, "web", member.Projects[0].Type)
+		assert.Equal(t, "backend", member.Projects[0].Role)
+	}
+
+	req = httptest.NewRequest("DELETE", fmt.Sprintf("%s/team/%d", projectPath, staffID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("DELETE", fmt.Sprintf("%s/team/%d", projectPath, staffID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest("GET", staffPath, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	member = models.Staff{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &member))
+	assert.Empty(t, member.Projects)
+}
+
+func TestStaffProfileAndOrder(t *testing.T) {
+	router := setupTestRouter()
+
+	db, err := testutils.SetupTestDB()
+	assert.NoError(t, err)
+
+	member := models.CreateStaffRequest{
+		Name:        "Profile Test Staff Member",
+		Description: "Staff member created to check skills, profile links and the team page order.",
+		Img:         "https://example.com/profile.jpg",
+		Role:        "Backend Developer",
+		Department:  "Backend",
+		Skills:      []models.Skill{{Name: "Go", Level: models.SkillExpert}},
+		Email:       "profile@example.com",
+		GitHubURL:   "https://github.com/profile-test",
+		TelegramURL: "https://t.me/profile_test",
+	}
+	body, _ := json.Marshal(member)
+	req := httptest.NewRequest("POST", "/api/Staff/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	publishCreated(t, router, "/api/Staff", w)
+
+	var created map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	id := int(created["id"].(float64))
+	path := fmt.Sprintf("/api/Staff/%d", id)
+
+	get := func() models.Staff {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var staff models.Staff
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &staff))
+		return staff
+	}
+
+	// Новый сотрудник встает в конец, email без show_email публично скрыт
+	staff := get()
+	assert.Equal(t, "Backend", staff.Department)
+	assert.Equal(t, []models.Skill{{Name: "Go", Level: models.SkillExpert}}, staff.Skills)
+	assert.Empty(t, staff.Email)
+	var maxOrder int
+	assert.NoError(t, db.QueryRow(`SELECT MAX(display_order) FROM staff WHERE deleted_at IS NULL`).Scan(&maxOrder))
+	assert.Equal(t, maxOrder, staff.DisplayOrder)
+
+	req = httptest.NewRequest("PATCH", path, bytes.NewBufferString(`{"show_email": true}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "profile@example.com", get().Email)
+
+	// Перемещаем сотрудника в начало
+	rows, err := db.Query(`SELECT id FROM staff WHERE deleted_at IS NULL AND id <> $1 ORDER BY display_order, id`, id)
+	assert.NoError(t, err)
+	ids := []int{id}
+	for rows.Next() {
+		var other int
+		assert.NoError(t, rows.Scan(&other))
+		ids = append(ids, other)
+	}
+	rows.Close()
+
+	reorder := func(ids []int) int {
+		body, _ := json.Marshal(models.ReorderStaffRequest{IDs: ids})
+		req := httptest.NewRequest("PUT", "/api/Staff/order", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	assert.Equal(t, http.StatusBadRequest, reorder([]int{id, id}))
+	assert.Equal(t, http.StatusOK, reorder(ids))
+	assert.Equal(t, 1, get().DisplayOrder)
+
+	req = httptest.NewRequest("GET", "/api/Staff/?per_page=1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var response struct {
+		Staff []models.Staff `json:"staff"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if assert.Len(t, response.Staff, 1) {
+		assert.Equal(t, id, response.Staff[0].ID)
+	}
+}
+
+func TestLeads(t *testing.T) {
+	router := setupTestRouter()
+
+	db, err := testutils.SetupTestDB()
+	assert.NoError(t, err)
+
+	send := func(method, path string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("POST", "/api/WebApplications/", models.CreateWebProjectRequest{
+		Name:        "Web Project For Lead Form",
+		Description: "This is a test description for a project page with an order form.",
+		Img:         "https://example.com/lead-web.jpg",
+		Price:       900.00,
+		TimeDevelop: 14,
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	publishCreated(t, router, "/api/WebApplications", w)
+	var project map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+	projectID := int(project["id"].(float64))
+
+	budget := 250000.0
+	lead := models.CreateLeadRequest{
+		Name:        "Lead Test Client",
+		Contact:     "client@example.com",
+		Message:     "We would like a similar shop with online payments.",
+		Budget:      &budget,
+		ProjectType: "web",
+		ProjectID:   &projectID,
+	}
+	w = send("POST", "/api/leads", lead)
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	id := int(created["id"].(float64))
+	path := fmt.Sprintf("/api/admin/leads/%d", id)
+
+	// Заявка со страницы несуществующего проекта
+	missing := 999999
+	lead.ProjectID = &missing
+	w = send("POST", "/api/leads", lead)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Project not found")
+
+	username := fmt.Sprintf("leads_%d", time.Now().UnixNano())
+	hash, err := auth.HashPassword("super-secret-password")
+	assert.NoError(t, err)
+	user, err := repository.NewAdminUsersRepository(db, testutils.NewRedisMock()).Create(context.Background(), username, hash, nil)
+	assert.NoError(t, err)
+
+	w = send("PUT", path+"/assignee", models.AssignLeadRequest{UserID: &missing})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = send("PUT", path+"/assignee", models.AssignLeadRequest{UserID: &user.ID})
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = send("PUT", path+"/status", models.LeadStatusRequest{Status: models.LeadContacted})
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = send("PUT", path+"/status", models.LeadStatusRequest{Status: "archived"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req := httptest.NewRequest("GET", path, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var stored models.Lead
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &stored))
+	assert.Equal(t, models.LeadContacted, stored.Status)
+	assert.Equal(t, username, stored.AssigneeName)
+	assert.Equal(t, budget, *stored.Budget)
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("/api/admin/leads?status=contacted&assigned_to=%d", user.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1.0, response["total"])
+
+	// Назначение и смена статуса попадают в журнал
+	req = httptest.NewRequest("GET", fmt.Sprintf("/api/admin/audit?resource_type=leads&resource_id=%d", id), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2.0, response["total"])
+}
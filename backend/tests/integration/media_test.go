@@ -0,0 +1,263 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ASMO-site-backend/internal/handlers"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+	"ASMO-site-backend/internal/storage"
+	testutils "ASMO-site-backend/tests/testutils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func pngFile(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func uploadRequest(t *testing.T, name string, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", name)
+	assert.NoError(t, err)
+	_, err = part.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest("POST", "/api/admin/media/", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestMediaLibrary(t *testing.T) {
+	router := setupTestRouter()
+	db, err := testutils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	dir := t.TempDir()
+	mediaHandler := handlers.NewMediaHandler(db, testutils.NewRedisMock(), storage.NewLocal(dir, "http://localhost:3000/media"), 1<<20)
+	router.GET("/api/admin/media/", mediaHandler.ListMedia)
+	router.POST("/api/admin/media/", mediaHandler.UploadMedia)
+	router.DELETE("/api/admin/media/:id", mediaHandler.DeleteMedia)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, uploadRequest(t, "cover.png", pngFile(t, 800, 400)))
+	if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+		return
+	}
+
+	var media models.Media
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &media))
+	assert.Equal(t, "image/png", media.MimeType)
+	assert.Equal(t, "cover.png", media.OriginalName)
+	assert.Equal(t, "http://localhost:3000/media/"+media.Key, media.URL)
+	_, err = os.Stat(filepath.Join(dir, filepath.FromSlash(media.Key)))
+	assert.NoError(t, err, "file should be written to the media directory")
+
+	// 800px: копии thumbnail и card, hero шире оригинала и не строится
+	assert.Equal(t, models.ImageVariant{URL: media.URL, Width: 800, Height: 400}, media.Images.Original)
+	if assert.NotNil(t, media.Images.Thumbnail) && assert.NotNil(t, media.Images.Card) {
+		assert.Equal(t, 160, media.Images.Thumbnail.Height)
+		assert.Equal(t, media.Images.Thumbnail.URL+" 320w, "+media.Images.Card.URL+" 640w, "+media.URL+" 800w", media.Images.Srcset)
+		assert.True(t, strings.HasSuffix(media.Images.Card.WebP, "_card.webp"), media.Images.Card.WebP)
+		// Оригинал в PNG, поэтому в srcset_webp только копии
+		assert.Equal(t, media.Images.Thumbnail.WebP+" 320w, "+media.Images.Card.WebP+" 640w", media.Images.SrcsetWebP)
+		_, err = os.Stat(filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(media.Images.Card.WebP, "http://localhost:3000/media/"))))
+		assert.NoError(t, err, "WebP variant should be written next to the original")
+	}
+	assert.Nil(t, media.Images.Hero)
+	assert.Len(t, media.VariantKeys, 0, "variant keys are not exposed")
+
+	// Текст с расширением картинки не проходит проверку содержимого
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, uploadRequest(t, "fake.png", []byte("definitely not an image")))
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+	// Проект ссылается на файл медиатеки вместо внешнего URL
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":         "Проект с картинкой из медиатеки",
+		"description":  "Описание проекта, у которого обложка загружена через медиатеку.",
+		"media_id":     media.ID,
+		"price":        500,
+		"time_develop": 5,
+	})
+	req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+		return
+	}
+
+	var project models.WebProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+	assert.Equal(t, media.URL, project.Img)
+	if assert.NotNil(t, project.MediaID) {
+		assert.Equal(t, media.ID, *project.MediaID)
+	}
+	if assert.NotNil(t, project.Images) {
+		assert.Equal(t, media.Images.Srcset, project.Images.Srcset)
+	}
+
+	// Пока файл стоит обложкой проекта, удалить его нельзя
+	req = httptest.NewRequest("DELETE", fmt.Sprintf("/api/admin/media/%d", media.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), fmt.Sprintf(`{"type":"web_projects","id":%d}`, project.ID))
+
+	// Внешний URL отвязывает картинку от медиатеки
+	body, _ = json.Marshal(map[string]interface{}{"img": "https://example.com/external.jpg"})
+	req = httptest.NewRequest("PATCH", fmt.Sprintf("/api/WebApplications/%d", project.ID), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var external models.WebProjects
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &external))
+	assert.Nil(t, external.MediaID)
+	assert.Nil(t, external.Images)
+
+	body, _ = json.Marshal(map[string]interface{}{"media_id": 999999})
+	req = httptest.NewRequest("PATCH", fmt.Sprintf("/api/WebApplications/%d", project.ID), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest("DELETE", fmt.Sprintf("/api/admin/media/%d", media.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	entries, _ := filepath.Glob(filepath.Join(dir, "*", "*", "*"))
+	assert.Empty(t, entries, "file and its variants should be removed with the record")
+}
+
+func TestExternalImageImport(t *testing.T) {
+	db, err := testutils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	redisMock := testutils.NewRedisMock()
+	mediaHandler := handlers.NewMediaHandler(db, redisMock, storage.NewLocal(t.TempDir(), "http://localhost:3000/media"), 1<<20)
+	webHandler := handlers.NewWebProjectsHandlerWithRepository(
+		repository.NewWebProjectsRepository(db, redisMock).ImportExternalImages(mediaHandler.ImportImage),
+	)
+
+	router := gin.New()
+	router.POST("/api/admin/media/", mediaHandler.UploadMedia)
+	router.POST("/api/WebApplications/", webHandler.CreateWebProject)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, uploadRequest(t, "external.png", pngFile(t, 800, 400)))
+	if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+		return
+	}
+	var media models.Media
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &media))
+
+	create := func(img string) models.WebProjects {
+		body, _ := json.Marshal(map[string]interface{}{
+			"name":         fmt.Sprintf("Проект с внешней картинкой %d", time.Now().UnixNano()),
+			"description":  "Описание проекта, у которого обложка указана внешним адресом.",
+			"img":          img,
+			"price":        500,
+			"time_develop": 5,
+		})
+		req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var project models.WebProjects
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+		return project
+	}
+
+	// Адрес, который уже есть в медиатеке, привязывается без повторного скачивания
+	project := create(media.URL)
+	if assert.NotNil(t, project.MediaID) {
+		assert.Equal(t, media.ID, *project.MediaID)
+	}
+	if assert.NotNil(t, project.Images) {
+		assert.Equal(t, media.Images.Srcset, project.Images.Srcset)
+		assert.Equal(t, media.Images.SrcsetWebP, project.Images.SrcsetWebP)
+	}
+
+	// Картинку, которую скачать нельзя, запись сохраняет как внешнюю и без копий
+	project = create("http://127.0.0.1:1/cover.png")
+	assert.Nil(t, project.MediaID)
+	assert.Nil(t, project.Images)
+}
+
+// TestS3StorageMinIO запускается против MinIO из docker-compose.test.yml
+func TestS3StorageMinIO(t *testing.T) {
+	endpoint := os.Getenv("TEST_S3_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "http://localhost:9000"
+	}
+	health, err := http.Get(endpoint + "/minio/health/live")
+	if err != nil {
+		t.Skipf("MinIO is not available at %s: %v", endpoint, err)
+	}
+	health.Body.Close()
+
+	store := storage.NewS3(storage.S3Config{
+		Endpoint:  endpoint,
+		Region:    "us-east-1",
+		Bucket:    "test-media",
+		AccessKey: "minioadmin",
+		SecretKey: "minioadmin",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	data := pngFile(t, 4, 4)
+	key, err := storage.NewKey(".png")
+	assert.NoError(t, err)
+	if !assert.NoError(t, store.Put(ctx, key, data, "image/png")) {
+		return
+	}
+
+	// Бакет открыт на чтение, файл доступен по публичному URL
+	resp, err := http.Get(store.URL(key))
+	if !assert.NoError(t, err) {
+		return
+	}
+	downloaded, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, data, downloaded)
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+
+	assert.NoError(t, store.Delete(ctx, key))
+	resp, err = http.Get(store.URL(key))
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
@@ -0,0 +1,116 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/notify"
+	"ASMO-site-backend/internal/repository"
+	testutils "ASMO-site-backend/tests/testutils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPublishNotifications уведомление приходит и при ручной публикации, и по расписанию
+func TestPublishNotifications(t *testing.T) {
+	router := setupTestRouter()
+	db, err := testutils.SetupTestDB()
+	assert.NoError(t, err)
+
+	var published []string
+	repo := repository.NewWebProjectsRepository(db, testutils.NewRedisMock()).
+		OnPublish(func(ctx context.Context, p models.WebProjects) {
+			published = append(published, p.Name)
+		})
+
+	create := func(name string) int {
+		body, _ := json.Marshal(models.CreateWebProjectRequest{
+			Name:        name,
+			Description: "Project used to check publication notifications.",
+			Img:         "https://example.com/notify.jpg",
+			Price:       1000.00,
+			TimeDevelop: 10,
+		})
+		req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var created models.WebProjects
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		return created.ID
+	}
+	ctx := context.Background()
+
+	manual := create("Manually Published Notify Project")
+	_, err = repo.Transition(ctx, manual, models.StatusInReview, []string{models.StatusDraft}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, published, "only publication is announced")
+	_, err = repo.Transition(ctx, manual, models.StatusPublished, []string{models.StatusInReview}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Manually Published Notify Project"}, published)
+
+	scheduled := create("Scheduled Published Notify Project")
+	_, err = repo.Update(ctx, scheduled, repository.Fields{"publish_at": time.Now().Add(-time.Minute)})
+	assert.NoError(t, err)
+	_, err = repo.ApplySchedule(ctx, time.Now())
+	assert.NoError(t, err)
+	assert.Contains(t, published, "Scheduled Published Notify Project")
+}
+
+// TestSMTPSenderMailHog запускается против MailHog из docker-compose.test.yml
+func TestSMTPSenderMailHog(t *testing.T) {
+	host := os.Getenv("TEST_SMTP_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, "1025"), time.Second)
+	if err != nil {
+		t.Skipf("MailHog is not available at %s: %v", host, err)
+	}
+	conn.Close()
+
+	subject := "Проверка доставки " + time.Now().Format(time.RFC3339Nano)
+	sender := notify.NewSMTPSender(host, "1025", "", "", "ASMO <noreply@asmo.test>")
+	notifier := notify.New(sender, []string{"team@asmo.test"}, 0, time.Second, nil)
+	assert.NoError(t, notifier.Deliver(context.Background(), notify.Message{
+		To:      []string{"team@asmo.test"},
+		Subject: subject,
+		HTML:    "<p>Привет, команда</p>",
+	}))
+
+	resp, err := http.Get("http://" + net.JoinHostPort(host, "8025") + "/api/v2/search?kind=to&query=team@asmo.test")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Items []struct {
+			Content struct {
+				Headers map[string][]string `json:"Headers"`
+			} `json:"Content"`
+		} `json:"items"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+	var decoder mime.WordDecoder
+	found := false
+	for _, item := range result.Items {
+		for _, value := range item.Content.Headers["Subject"] {
+			decoded, _ := decoder.DecodeHeader(value)
+			found = found || decoded == subject
+		}
+	}
+	assert.True(t, found, "message should reach MailHog with the subject intact")
+}
@@ -0,0 +1,21 @@
+package testutils
+
+import (
+	"context"
+
+	"ASMO-site-backend/internal/antispam"
+)
+
+// FakeCaptcha локальная замена провайдера капчи: подтверждает только токен Token
+type FakeCaptcha struct {
+	Token string
+	// Calls сколько раз вызывалась проверка
+	Calls int
+}
+
+var _ antispam.CaptchaVerifier = (*FakeCaptcha)(nil)
+
+func (f *FakeCaptcha) Verify(ctx context.Context, token, ip string) (bool, error) {
+	f.Calls++
+	return token == f.Token, nil
+}
@@ -3,6 +3,8 @@ package testutils
 import (
 	"ASMO-site-backend/internal/cache"
 	"encoding/json"
+	"path"
+	"strings"
 	"sync"
 	"time"
 )
@@ -34,6 +36,23 @@ func (r *RedisMock) Set(key string, value interface{}, expiration time.Duration)
 	return nil
 }
 
+// SetNX в моке ключ живет до Delete, expiration не учитывается
+func (r *RedisMock) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.data[key]; exists {
+		return false, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	r.data[key] = data
+	return true, nil
+}
+
 func (r *RedisMock) Get(key string, dest interface{}) error {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
@@ -54,6 +73,42 @@ func (r *RedisMock) Delete(key string) error {
 	return nil
 }
 
+// Increment хранит счетчик как JSON-число; expiration в моке не учитывается
+func (r *RedisMock) Increment(key string, expiration time.Duration) (int64, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var count int64
+	if data, exists := r.data[key]; exists {
+		if err := json.Unmarshal(data, &count); err != nil {
+			return 0, err
+		}
+	}
+	count++
+
+	data, err := json.Marshal(count)
+	if err != nil {
+		return 0, err
+	}
+	r.data[key] = data
+	return count, nil
+}
+
+// DeletePattern поддерживает "*" в любом месте шаблона, как glob в Redis ("*:gallery", "web_projects:all*")
+func (r *RedisMock) DeletePattern(pattern string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	prefix, isPrefix := strings.CutSuffix(pattern, "*")
+	for key := range r.data {
+		matched, _ := path.Match(pattern, key)
+		if matched || key == pattern || (isPrefix && strings.HasPrefix(key, prefix)) {
+			delete(r.data, key)
+		}
+	}
+	return nil
+}
+
 func (r *RedisMock) Close() error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
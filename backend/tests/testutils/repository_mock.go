@@ -0,0 +1,282 @@
+package testutils
+
+import (
+	"context"
+
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+)
+
+// Моки ролей репозитория (repository.CRUD, Workflow, Revisions и т.д.) через подменяемые функции,
+// чтобы хэндлеры можно было тестировать без базы данных.
+// Незаданная функция ведет себя как пустое хранилище.
+
+// ProjectsMock реализует repository.Projects из моков ролей; функции задаются через
+// встроенные моки: repo.GetFunc = ... или ProjectsMock{CRUDMock: CRUDMock[T]{GetFunc: ...}}
+type ProjectsMock[T any] struct {
+	CRUDMock[T]
+	WorkflowMock[T]
+	RevisionsMock[T]
+	TranslationsMock
+	GalleryMock
+	TagsMock
+	TeamMock
+}
+
+// StaffMock реализует repository.StaffDirectory из моков ролей
+type StaffMock struct {
+	CRUDMock[models.Staff]
+	WorkflowMock[models.Staff]
+	RevisionsMock[models.Staff]
+	TranslationsMock
+	RosterMock
+}
+
+type CRUDMock[T any] struct {
+	ListFunc        func(ctx context.Context, query repository.ListQuery) (repository.Page[T], bool, error)
+	GetFunc         func(ctx context.Context, id int) (T, bool, error)
+	ResolveSlugFunc func(ctx context.Context, slug string) (int, string, error)
+	CreateFunc      func(ctx context.Context, fields repository.Fields) (T, error)
+	UpdateFunc      func(ctx context.Context, id int, fields repository.Fields) (T, error)
+	DeleteFunc      func(ctx context.Context, id int) error
+	RestoreFunc     func(ctx context.Context, id int) (T, error)
+
+	// Последние переданные поля и запрос, чтобы проверять маппинг запроса
+	LastFields repository.Fields
+	LastQuery  repository.ListQuery
+}
+
+func (r *CRUDMock[T]) List(ctx context.Context, query repository.ListQuery) (repository.Page[T], bool, error) {
+	r.LastQuery = query
+	if r.ListFunc != nil {
+		return r.ListFunc(ctx, query)
+	}
+	return repository.Page[T]{Items: []T{}, Page: 1, PerPage: 20}, false, nil
+}
+
+func (r *CRUDMock[T]) Get(ctx context.Context, id int) (T, bool, error) {
+	if r.GetFunc != nil {
+		return r.GetFunc(ctx, id)
+	}
+	var zero T
+	return zero, false, repository.ErrNotFound
+}
+
+func (r *CRUDMock[T]) ResolveSlug(ctx context.Context, slug string) (int, string, error) {
+	if r.ResolveSlugFunc != nil {
+		return r.ResolveSlugFunc(ctx, slug)
+	}
+	return 0, "", repository.ErrNotFound
+}
+
+func (r *CRUDMock[T]) Create(ctx context.Context, fields repository.Fields) (T, error) {
+	r.LastFields = fields
+	if r.CreateFunc != nil {
+		return r.CreateFunc(ctx, fields)
+	}
+	var zero T
+	return zero, nil
+}
+
+func (r *CRUDMock[T]) Update(ctx context.Context, id int, fields repository.Fields) (T, error) {
+	r.LastFields = fields
+	if r.UpdateFunc != nil {
+		return r.UpdateFunc(ctx, id, fields)
+	}
+	var zero T
+	return zero, repository.ErrNotFound
+}
+
+func (r *CRUDMock[T]) Delete(ctx context.Context, id int) error {
+	if r.DeleteFunc != nil {
+		return r.DeleteFunc(ctx, id)
+	}
+	return repository.ErrNotFound
+}
+
+func (r *CRUDMock[T]) Restore(ctx context.Context, id int) (T, error) {
+	if r.RestoreFunc != nil {
+		return r.RestoreFunc(ctx, id)
+	}
+	var zero T
+	return zero, repository.ErrNotFound
+}
+
+type WorkflowMock[T any] struct {
+	// TransitionFunc получает целевой статус, допустимые исходные и дополнительные поля
+	TransitionFunc func(ctx context.Context, id int, to string, from []string, fields repository.Fields) (T, error)
+
+	LastFields repository.Fields
+}
+
+func (r *WorkflowMock[T]) Transition(ctx context.Context, id int, to string, from []string, fields repository.Fields) (T, error) {
+	r.LastFields = fields
+	if r.TransitionFunc != nil {
+		return r.TransitionFunc(ctx, id, to, from, fields)
+	}
+	var zero T
+	return zero, repository.ErrNotFound
+}
+
+type RevisionsMock[T any] struct {
+	RevisionsFunc       func(ctx context.Context, id int) ([]models.Revision, error)
+	RevisionFunc        func(ctx context.Context, id, rev int) (models.Revision, error)
+	RestoreRevisionFunc func(ctx context.Context, id, rev int) (T, error)
+}
+
+func (r *RevisionsMock[T]) Revisions(ctx context.Context, id int) ([]models.Revision, error) {
+	if r.RevisionsFunc != nil {
+		return r.RevisionsFunc(ctx, id)
+	}
+	return []models.Revision{}, nil
+}
+
+func (r *RevisionsMock[T]) Revision(ctx context.Context, id, rev int) (models.Revision, error) {
+	if r.RevisionFunc != nil {
+		return r.RevisionFunc(ctx, id, rev)
+	}
+	return models.Revision{}, repository.ErrNotFound
+}
+
+func (r *RevisionsMock[T]) RestoreRevision(ctx context.Context, id, rev int) (T, error) {
+	if r.RestoreRevisionFunc != nil {
+		return r.RestoreRevisionFunc(ctx, id, rev)
+	}
+	var zero T
+	return zero, repository.ErrNotFound
+}
+
+type TranslationsMock struct {
+	TranslationsFunc      func(ctx context.Context, id int) ([]models.Translation, error)
+	SetTranslationFunc    func(ctx context.Context, id int, locale string, fields repository.Fields) (models.Translation, error)
+	DeleteTranslationFunc func(ctx context.Context, id int, locale string) error
+
+	LastFields repository.Fields
+}
+
+func (r *TranslationsMock) Translations(ctx context.Context, id int) ([]models.Translation, error) {
+	if r.TranslationsFunc != nil {
+		return r.TranslationsFunc(ctx, id)
+	}
+	return []models.Translation{}, nil
+}
+
+func (r *TranslationsMock) SetTranslation(ctx context.Context, id int, locale string, fields repository.Fields) (models.Translation, error) {
+	r.LastFields = fields
+	if r.SetTranslationFunc != nil {
+		return r.SetTranslationFunc(ctx, id, locale, fields)
+	}
+	return models.Translation{}, repository.ErrNotFound
+}
+
+func (r *TranslationsMock) DeleteTranslation(ctx context.Context, id int, locale string) error {
+	if r.DeleteTranslationFunc != nil {
+		return r.DeleteTranslationFunc(ctx, id, locale)
+	}
+	return repository.ErrNotFound
+}
+
+type GalleryMock struct {
+	GalleryFunc           func(ctx context.Context, id int) ([]models.ProjectMedia, error)
+	AddGalleryItemFunc    func(ctx context.Context, id int, item models.ProjectMedia) (models.ProjectMedia, error)
+	RemoveGalleryItemFunc func(ctx context.Context, id, itemID int) error
+	ReorderGalleryFunc    func(ctx context.Context, id int, itemIDs []int) ([]models.ProjectMedia, error)
+}
+
+func (r *GalleryMock) Gallery(ctx context.Context, id int) ([]models.ProjectMedia, error) {
+	if r.GalleryFunc != nil {
+		return r.GalleryFunc(ctx, id)
+	}
+	return []models.ProjectMedia{}, nil
+}
+
+func (r *GalleryMock) AddGalleryItem(ctx context.Context, id int, item models.ProjectMedia) (models.ProjectMedia, error) {
+	if r.AddGalleryItemFunc != nil {
+		return r.AddGalleryItemFunc(ctx, id, item)
+	}
+	return models.ProjectMedia{}, repository.ErrNotFound
+}
+
+func (r *GalleryMock) RemoveGalleryItem(ctx context.Context, id, itemID int) error {
+	if r.RemoveGalleryItemFunc != nil {
+		return r.RemoveGalleryItemFunc(ctx, id, itemID)
+	}
+	return repository.ErrNotFound
+}
+
+func (r *GalleryMock) ReorderGallery(ctx context.Context, id int, itemIDs []int) ([]models.ProjectMedia, error) {
+	if r.ReorderGalleryFunc != nil {
+		return r.ReorderGalleryFunc(ctx, id, itemIDs)
+	}
+	return nil, repository.ErrNotFound
+}
+
+type TagsMock struct {
+	TagsFunc    func(ctx context.Context, id int) ([]models.Tag, error)
+	SetTagsFunc func(ctx context.Context, id int, slugs []string) ([]models.Tag, error)
+}
+
+func (r *TagsMock) Tags(ctx context.Context, id int) ([]models.Tag, error) {
+	if r.TagsFunc != nil {
+		return r.TagsFunc(ctx, id)
+	}
+	return []models.Tag{}, nil
+}
+
+func (r *TagsMock) SetTags(ctx context.Context, id int, slugs []string) ([]models.Tag, error) {
+	if r.SetTagsFunc != nil {
+		return r.SetTagsFunc(ctx, id, slugs)
+	}
+	return nil, repository.ErrNotFound
+}
+
+type TeamMock struct {
+	TeamFunc               func(ctx context.Context, id int) ([]models.TeamMember, error)
+	AssignTeamMemberFunc   func(ctx context.Context, id, staffID int, role string) (models.TeamMember, error)
+	UnassignTeamMemberFunc func(ctx context.Context, id, staffID int) error
+}
+
+func (r *TeamMock) Team(ctx context.Context, id int) ([]models.TeamMember, error) {
+	if r.TeamFunc != nil {
+		return r.TeamFunc(ctx, id)
+	}
+	return []models.TeamMember{}, nil
+}
+
+func (r *TeamMock) AssignTeamMember(ctx context.Context, id, staffID int, role string) (models.TeamMember, error) {
+	if r.AssignTeamMemberFunc != nil {
+		return r.AssignTeamMemberFunc(ctx, id, staffID, role)
+	}
+	return models.TeamMember{}, repository.ErrNotFound
+}
+
+func (r *TeamMock) UnassignTeamMember(ctx context.Context, id, staffID int) error {
+	if r.UnassignTeamMemberFunc != nil {
+		return r.UnassignTeamMemberFunc(ctx, id, staffID)
+	}
+	return repository.ErrNotFound
+}
+
+type RosterMock struct {
+	MemberProjectsFunc func(ctx context.Context, id int) ([]models.StaffProject, error)
+	ReorderFunc        func(ctx context.Context, ids []int) error
+}
+
+func (r *RosterMock) MemberProjects(ctx context.Context, id int) ([]models.StaffProject, error) {
+	if r.MemberProjectsFunc != nil {
+		return r.MemberProjectsFunc(ctx, id)
+	}
+	return []models.StaffProject{}, nil
+}
+
+func (r *RosterMock) Reorder(ctx context.Context, ids []int) error {
+	if r.ReorderFunc != nil {
+		return r.ReorderFunc(ctx, ids)
+	}
+	return nil
+}
+
+var (
+	_ repository.Projects[models.WebProjects] = (*ProjectsMock[models.WebProjects])(nil)
+	_ repository.StaffDirectory               = (*StaffMock)(nil)
+)
@@ -0,0 +1,135 @@
+package testutils
+
+import (
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// SMTPMessage письмо, принятое SMTPServer
+type SMTPMessage struct {
+	From string
+	To   []string
+	Data string
+}
+
+// SMTPServer локальный SMTP-сервер для тестов вместо MailHog: принимает письма без TLS и авторизации
+type SMTPServer struct {
+	// FailFirst сколько первых писем отклонить временной ошибкой 451
+	FailFirst int
+	// Reject отклонять получателей постоянной ошибкой 550
+	Reject bool
+
+	listener net.Listener
+	mutex    sync.Mutex
+	messages []SMTPMessage
+	attempts int
+}
+
+func NewSMTPServer() (*SMTPServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SMTPServer{listener: listener}
+	go s.serve()
+	return s, nil
+}
+
+func (s *SMTPServer) Host() string {
+	host, _, _ := net.SplitHostPort(s.listener.Addr().String())
+	return host
+}
+
+func (s *SMTPServer) Port() string {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return port
+}
+
+func (s *SMTPServer) Messages() []SMTPMessage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]SMTPMessage(nil), s.messages...)
+}
+
+// Attempts сколько раз начинали отправку письма, включая отклоненные
+func (s *SMTPServer) Attempts() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.attempts
+}
+
+func (s *SMTPServer) Close() error {
+	return s.listener.Close()
+}
+
+func (s *SMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.session(textproto.NewConn(conn))
+	}
+}
+
+func (s *SMTPServer) session(conn *textproto.Conn) {
+	defer conn.Close()
+
+	var msg SMTPMessage
+	conn.PrintfLine("220 localhost fake SMTP")
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		command, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(command) {
+		case "EHLO", "HELO":
+			conn.PrintfLine("250 localhost")
+		case "MAIL":
+			s.mutex.Lock()
+			s.attempts++
+			s.mutex.Unlock()
+			msg = SMTPMessage{From: strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")}
+			conn.PrintfLine("250 OK")
+		case "RCPT":
+			if s.Reject {
+				conn.PrintfLine("550 Mailbox unavailable")
+				continue
+			}
+			msg.To = append(msg.To, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			conn.PrintfLine("250 OK")
+		case "DATA":
+			conn.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := conn.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.Data = string(data)
+
+			s.mutex.Lock()
+			failed := s.attempts <= s.FailFirst
+			if !failed {
+				s.messages = append(s.messages, msg)
+			}
+			s.mutex.Unlock()
+
+			if failed {
+				conn.PrintfLine("451 Try again later")
+			} else {
+				conn.PrintfLine("250 OK")
+			}
+		case "RSET", "NOOP":
+			conn.PrintfLine("250 OK")
+		case "QUIT":
+			conn.PrintfLine("221 Bye")
+			return
+		default:
+			conn.PrintfLine("502 Command not implemented")
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"ASMO-site-backend/internal/antispam"
+	"ASMO-site-backend/internal/handlers"
+	"ASMO-site-backend/internal/validation"
+	testutils "ASMO-site-backend/tests/testutils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAntispamChecks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Nil Guard Allows Everything", func(t *testing.T) {
+		var guard *antispam.Guard
+		assert.NoError(t, guard.Check(ctx, antispam.Submission{Honeypot: "bot"}))
+	})
+
+	t.Run("Honeypot", func(t *testing.T) {
+		assert.NoError(t, antispam.Honeypot{}.Check(ctx, antispam.Submission{}))
+		assert.ErrorIs(t, antispam.Honeypot{}.Check(ctx, antispam.Submission{Honeypot: "http://spam.example"}), antispam.ErrSpam)
+	})
+
+	t.Run("Form Token", func(t *testing.T) {
+		tokens := antispam.NewFormTokens("secret", testutils.NewRedisMock(), 3*time.Second, time.Hour)
+		check := func(token string) error {
+			return tokens.Check(ctx, antispam.Submission{FormToken: token})
+		}
+
+		assert.NoError(t, check(tokens.IssueAt(time.Now().Add(-10*time.Second))))
+		assert.ErrorIs(t, check(tokens.Issue()), antispam.ErrTooFast)
+		assert.ErrorIs(t, check(tokens.IssueAt(time.Now().Add(-2*time.Hour))), antispam.ErrFormToken)
+		assert.ErrorIs(t, check(""), antispam.ErrFormToken)
+
+		// Подделанная метка времени и токен с чужим секретом
+		other := antispam.NewFormTokens("other", testutils.NewRedisMock(), 3*time.Second, time.Hour)
+		assert.ErrorIs(t, check(other.IssueAt(time.Now().Add(-10*time.Second))), antispam.ErrFormToken)
+		valid := tokens.IssueAt(time.Now().Add(-10 * time.Second))
+		assert.ErrorIs(t, check("1"+valid), antispam.ErrFormToken)
+	})
+
+	t.Run("Form Token Single Use", func(t *testing.T) {
+		tokens := antispam.NewFormTokens("secret", testutils.NewRedisMock(), 3*time.Second, time.Hour)
+		singleUse := tokens.SingleUse()
+
+		token := antispam.Submission{FormToken: tokens.IssueAt(time.Now().Add(-10 * time.Second))}
+		assert.NoError(t, singleUse.Check(ctx, token))
+		assert.ErrorIs(t, singleUse.Check(ctx, token), antispam.ErrFormToken)
+
+		// Каждый выданный токен гасится отдельно
+		other := antispam.Submission{FormToken: tokens.IssueAt(time.Now().Add(-10 * time.Second))}
+		assert.NoError(t, singleUse.Check(ctx, other))
+		assert.ErrorIs(t, singleUse.Check(ctx, antispam.Submission{FormToken: "forged"}), antispam.ErrFormToken)
+	})
+
+	t.Run("Throttle Per IP And Email", func(t *testing.T) {
+		throttle := antispam.NewThrottle(testutils.NewRedisMock(), "leads", 3, 2, time.Hour)
+
+		first := antispam.Submission{IP: "10.0.0.1", Email: "Client@Example.com"}
+		assert.NoError(t, throttle.Check(ctx, first))
+		assert.NoError(t, throttle.Check(ctx, antispam.Submission{IP: "10.0.0.2", Email: "client@example.com "}))
+
+		// Третья заявка на тот же email упирается в лимит, хоть и с нового IP
+		err := throttle.Check(ctx, antispam.Submission{IP: "10.0.0.3", Email: "CLIENT@example.com"})
+		var throttled *antispam.ThrottledError
+		if assert.ErrorAs(t, err, &throttled) {
+			assert.Greater(t, throttled.RetryAfter, time.Duration(0))
+			assert.LessOrEqual(t, throttled.RetryAfter, time.Hour)
+		}
+
+		// IP 10.0.0.1 уже отправил 1 заявку, лимит 3
+		assert.NoError(t, throttle.Check(ctx, antispam.Submission{IP: "10.0.0.1", Email: "a@example.com"}))
+		assert.NoError(t, throttle.Check(ctx, antispam.Submission{IP: "10.0.0.1", Email: "b@example.com"}))
+		assert.ErrorAs(t, throttle.Check(ctx, antispam.Submission{IP: "10.0.0.1", Email: "c@example.com"}), &throttled)
+	})
+
+	t.Run("Throttle Sub-Second Window", func(t *testing.T) {
+		throttle := antispam.NewThrottle(testutils.NewRedisMock(), "leads", 1, 0, 500*time.Millisecond)
+		assert.NotPanics(t, func() {
+			assert.NoError(t, throttle.Check(ctx, antispam.Submission{IP: "10.0.0.9"}))
+		})
+
+		zero := antispam.NewThrottle(testutils.NewRedisMock(), "leads", 1, 0, 0)
+		assert.NotPanics(t, func() {
+			assert.NoError(t, zero.Check(ctx, antispam.Submission{IP: "10.0.0.9"}))
+		})
+	})
+
+	t.Run("Content Heuristics", func(t *testing.T) {
+		content := antispam.NewContent(2, []string{" Casino ", ""})
+		check := func(text ...string) error {
+			return content.Check(ctx, antispam.Submission{Text: text})
+		}
+
+		assert.NoError(t, check("Ivan", "Нужен сайт как https://example.com и www.example.org"))
+		assert.ErrorIs(t, check("Ivan", "http://a.example http://b.example [url=c]c[/url]"), antispam.ErrSpam)
+		assert.ErrorIs(t, check("Best CASINO bonus", "Need a mobile app for iOS"), antispam.ErrSpam)
+	})
+
+	t.Run("Captcha", func(t *testing.T) {
+		fake := &testutils.FakeCaptcha{Token: "passed"}
+		captcha := antispam.NewCaptcha(fake)
+
+		assert.NoError(t, captcha.Check(ctx, antispam.Submission{CaptchaToken: "passed"}))
+		assert.ErrorIs(t, captcha.Check(ctx, antispam.Submission{CaptchaToken: "forged"}), antispam.ErrCaptcha)
+		assert.ErrorIs(t, captcha.Check(ctx, antispam.Submission{}), antispam.ErrCaptcha)
+		assert.Equal(t, 2, fake.Calls, "empty token must not reach the provider")
+	})
+
+	t.Run("Site Verify", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "secret", r.FormValue("secret"))
+			assert.Equal(t, "10.0.0.1", r.FormValue("remoteip"))
+			json.NewEncoder(w).Encode(map[string]bool{"success": r.FormValue("response") == "passed"})
+		}))
+		defer server.Close()
+
+		verifier := antispam.NewSiteVerify(server.URL, "secret")
+		ok, err := verifier.Verify(ctx, "passed", "10.0.0.1")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = verifier.Verify(ctx, "forged", "10.0.0.1")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestLeadFormAntispam(t *testing.T) {
+	validation.Init()
+	gin.SetMode(gin.TestMode)
+
+	tokens := antispam.NewFormTokens("secret", testutils.NewRedisMock(), 3*time.Second, time.Hour)
+	guard := antispam.New(
+		antispam.Honeypot{},
+		tokens,
+		antispam.NewThrottle(testutils.NewRedisMock(), "leads", 100, 1, time.Hour),
+		antispam.NewCaptcha(&testutils.FakeCaptcha{Token: "passed"}),
+		tokens.SingleUse(),
+	)
+
+	// Отклоненные заявки не доходят до базы, поэтому хэндлеру хватает nil *sql.DB
+	router := gin.New()
+	router.GET("/api/forms/token", handlers.NewFormsHandler(tokens).GetFormToken)
+	router.POST("/api/leads", handlers.NewLeadsHandler(nil, guard, nil).CreateLead)
+
+	submit := func(fields map[string]interface{}) *httptest.ResponseRecorder {
+		body := map[string]interface{}{
+			"name":          "Ivan Petrov",
+			"contact":       "ivan@example.com",
+			"message":       "Нужен интернет-магазин с каталогом",
+			"form_token":    tokens.IssueAt(time.Now().Add(-time.Minute)),
+			"captcha_token": "passed",
+		}
+		for key, value := range fields {
+			body[key] = value
+		}
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/leads", bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Form Token Endpoint", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/forms/token", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		var response struct {
+			Token          string `json:"token"`
+			MinFillSeconds int    `json:"min_fill_seconds"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.NotEmpty(t, response.Token)
+		assert.Equal(t, 3, response.MinFillSeconds)
+	})
+
+	t.Run("Rejections", func(t *testing.T) {
+		w := submit(map[string]interface{}{"website": "http://spam.example"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Submission rejected")
+
+		w = submit(map[string]interface{}{"form_token": tokens.Issue()})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Form submitted too fast")
+
+		w = submit(map[string]interface{}{"form_token": "forged"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Form expired")
+
+		// Валидация запроса идет раньше антиспама
+		w = submit(map[string]interface{}{"contact": "not a contact"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Validation failed")
+	})
+
+	t.Run("Throttled And Captcha", func(t *testing.T) {
+		w := submit(map[string]interface{}{"contact": "once@example.com", "captcha_token": "forged"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Captcha verification failed")
+
+		w = submit(map[string]interface{}{"contact": "ONCE@example.com"})
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	})
+}
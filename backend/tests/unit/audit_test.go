@@ -0,0 +1,55 @@
+package unit
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditDiff(t *testing.T) {
+	before := models.WebProjects{ID: 1, Name: "Old name", Price: 100, UpdateAt: time.Now().Add(-time.Hour)}
+	after := before
+	after.Price = 150
+	after.UpdateAt = time.Now()
+
+	t.Run("Update Keeps Only Changed Fields", func(t *testing.T) {
+		changedBefore, changedAfter, err := repository.AuditDiff(before, after)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"price": float64(100)}, changedBefore)
+		assert.Equal(t, map[string]interface{}{"price": float64(150)}, changedAfter)
+	})
+
+	t.Run("Create Has No Before", func(t *testing.T) {
+		changedBefore, changedAfter, err := repository.AuditDiff(nil, after)
+		assert.NoError(t, err)
+		assert.Nil(t, changedBefore)
+		assert.Equal(t, "Old name", changedAfter["name"])
+		assert.NotContains(t, changedAfter, "update_at")
+	})
+
+	t.Run("Delete Has No After", func(t *testing.T) {
+		changedBefore, changedAfter, err := repository.AuditDiff(before, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, changedAfter)
+		assert.Equal(t, float64(1), changedBefore["id"])
+	})
+}
+
+func TestAuditQueryBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/admin/audit?resource_type=staff&resource_id=7&from=2024-05-01T00:00:00Z", nil)
+
+	var query models.ListAuditQuery
+	assert.NoError(t, c.ShouldBindQuery(&query))
+	assert.Equal(t, "staff", query.ResourceType)
+	assert.Equal(t, 7, *query.ResourceID)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), query.From.UTC())
+	assert.Nil(t, query.To)
+}
@@ -0,0 +1,291 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"ASMO-site-backend/internal/auth"
+	"ASMO-site-backend/internal/handlers"
+	"ASMO-site-backend/internal/middleware"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+	"ASMO-site-backend/tests/testutils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenManager(t *testing.T) {
+	tokens := auth.NewTokenManager("unit-test-secret-unit-test-secret", time.Minute)
+
+	t.Run("Issue And Parse", func(t *testing.T) {
+		token, expiresAt, err := tokens.IssueAccessToken(5, "admin")
+		assert.NoError(t, err)
+		assert.True(t, expiresAt.After(time.Now()))
+
+		claims, err := tokens.ParseAccessToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, claims.UserID())
+		assert.Equal(t, "admin", claims.Username)
+	})
+
+	t.Run("Wrong Secret", func(t *testing.T) {
+		other := auth.NewTokenManager("another-secret-another-secret-123", time.Minute)
+		token, _, err := other.IssueAccessToken(5, "admin")
+		assert.NoError(t, err)
+
+		_, err = tokens.ParseAccessToken(token)
+		assert.Equal(t, auth.ErrInvalidToken, err)
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		expired := auth.NewTokenManager("unit-test-secret-unit-test-secret", -time.Minute)
+		token, _, err := expired.IssueAccessToken(5, "admin")
+		assert.NoError(t, err)
+
+		_, err = tokens.ParseAccessToken(token)
+		assert.Equal(t, auth.ErrInvalidToken, err)
+	})
+
+	t.Run("Refresh Token Hash", func(t *testing.T) {
+		token, hash, err := auth.NewRefreshToken()
+		assert.NoError(t, err)
+		assert.NotEqual(t, token, hash)
+		assert.Equal(t, hash, auth.HashToken(token))
+	})
+}
+
+func TestPasswordHashing(t *testing.T) {
+	hash, err := auth.HashPassword("correct horse battery")
+	assert.NoError(t, err)
+	assert.True(t, auth.CheckPassword(hash, "correct horse battery"))
+	assert.False(t, auth.CheckPassword(hash, "wrong password"))
+}
+
+func TestRequireAuthForWrites(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokens := auth.NewTokenManager("unit-test-secret-unit-test-secret", time.Minute)
+
+	router := gin.New()
+	group := router.Group("/api/WebApplications", middleware.RequireAuthForWrites(tokens))
+	group.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+	group.POST("/", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"user": c.GetInt(middleware.ContextUserID)})
+	})
+
+	t.Run("Public Read", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/WebApplications/", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Write Without Token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/api/WebApplications/", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Write With Invalid Token", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/WebApplications/", nil)
+		req.Header.Set("Authorization", "Bearer not-a-token")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Write With Token", func(t *testing.T) {
+		token, _, _ := tokens.IssueAccessToken(3, "editor")
+		req := httptest.NewRequest("POST", "/api/WebApplications/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"user":3`)
+	})
+}
+
+type staticPermissions map[int][]string
+
+func (s staticPermissions) Permissions(ctx context.Context, userID int) ([]string, error) {
+	return s[userID], nil
+}
+
+func TestRequirePermissionForWrites(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokens := auth.NewTokenManager("unit-test-secret-unit-test-secret", time.Minute)
+	checker := staticPermissions{
+		1: {auth.PermissionManageProjects},
+		2: {auth.PermissionManageStaff},
+	}
+
+	router := gin.New()
+	group := router.Group("/api/Bots",
+		middleware.RequireAuthForWrites(tokens),
+		middleware.RequirePermissionForWrites(checker, auth.PermissionManageProjects),
+	)
+	group.GET("/", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"permissions": c.GetStringSlice(middleware.ContextPermissions)})
+	})
+	group.DELETE("/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	deleteAs := func(userID int) *httptest.ResponseRecorder {
+		token, _, _ := tokens.IssueAccessToken(userID, "user")
+		req := httptest.NewRequest("DELETE", "/api/Bots/1", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Public Read", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/Bots/", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"permissions": null}`, w.Body.String())
+	})
+
+	t.Run("Read Loads Caller Permissions", func(t *testing.T) {
+		token, _, _ := tokens.IssueAccessToken(2, "hr")
+		req := httptest.NewRequest("GET", "/api/Bots/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"permissions": ["staff.manage"]}`, w.Body.String())
+	})
+
+	t.Run("Editor Can Delete", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, deleteAs(1).Code)
+	})
+
+	t.Run("HR Is Forbidden", func(t *testing.T) {
+		w := deleteAs(2)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.JSONEq(t, `{"error": "Insufficient permissions"}`, w.Body.String())
+	})
+
+	t.Run("User Without Roles Is Forbidden", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, deleteAs(3).Code)
+	})
+}
+
+func TestRequireAnyPermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokens := auth.NewTokenManager("unit-test-secret-unit-test-secret", time.Minute)
+	checker := staticPermissions{
+		1: {auth.PermissionManageProjects},
+		2: {auth.PermissionManageStaff},
+		3: {auth.PermissionManageLeads},
+	}
+
+	router := gin.New()
+	admin := router.Group("/api/admin",
+		middleware.RequireAuth(tokens),
+		middleware.RequireAnyPermission(checker, auth.PermissionManageProjects, auth.PermissionManageStaff),
+	)
+	admin.GET("/report", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"permissions": c.GetStringSlice(middleware.ContextPermissions)})
+	})
+	// Недоступный type отклоняется до запроса к базе
+	admin.GET("/trash", handlers.NewTrashHandler(nil).GetTrash)
+	admin.GET("/translations/missing", handlers.NewTranslationsHandler(nil).GetMissingTranslations)
+
+	getAs := func(userID int, path string) *httptest.ResponseRecorder {
+		token, _, _ := tokens.IssueAccessToken(userID, "user")
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Any Listed Permission Is Enough", func(t *testing.T) {
+		w := getAs(2, "/api/admin/report")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"permissions": ["staff.manage"]}`, w.Body.String())
+		assert.Equal(t, http.StatusOK, getAs(1, "/api/admin/report").Code)
+	})
+
+	t.Run("Other Permissions Are Forbidden", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, getAs(3, "/api/admin/report").Code)
+		assert.Equal(t, http.StatusForbidden, getAs(4, "/api/admin/trash").Code)
+	})
+
+	t.Run("Trash Type Outside Caller Permissions", func(t *testing.T) {
+		w := getAs(2, "/api/admin/trash?type=web")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.JSONEq(t, `{"error": "Insufficient permissions"}`, w.Body.String())
+		assert.Equal(t, http.StatusForbidden, getAs(1, "/api/admin/trash?type=staff").Code)
+	})
+
+	t.Run("Translation Report Type Outside Caller Permissions", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, getAs(2, "/api/admin/translations/missing?type=bot").Code)
+		assert.Equal(t, http.StatusForbidden, getAs(3, "/api/admin/translations/missing").Code)
+	})
+}
+
+type staticAPIKeys map[string]models.APIKey
+
+func (s staticAPIKeys) FindActiveByHash(ctx context.Context, hash string) (models.APIKey, error) {
+	key, ok := s[hash]
+	if !ok {
+		return models.APIKey{}, repository.ErrNotFound
+	}
+	return key, nil
+}
+
+func (s staticAPIKeys) TouchLastUsed(ctx context.Context, id int) error {
+	return nil
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokens := auth.NewTokenManager("unit-test-secret-unit-test-secret", time.Minute)
+	projectsKey, _, projectsHash, _ := auth.NewAPIKey()
+	staffKey, _, staffHash, _ := auth.NewAPIKey()
+	store := staticAPIKeys{
+		projectsHash: {ID: 1, Scopes: []string{auth.PermissionManageProjects}, RateLimit: 2},
+		staffHash:    {ID: 2, Scopes: []string{auth.PermissionManageStaff}, RateLimit: 100},
+	}
+
+	router := gin.New()
+	router.Use(middleware.APIKeyAuth(store, testutils.NewRedisMock()))
+	group := router.Group("/api/Bots",
+		middleware.RequireAuthForWrites(tokens),
+		middleware.RequirePermissionForWrites(staticPermissions{}, auth.PermissionManageProjects),
+	)
+	group.DELETE("/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"key": c.GetInt(middleware.ContextAPIKeyID)})
+	})
+
+	deleteWith := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("DELETE", "/api/Bots/1", nil)
+		req.Header.Set(middleware.APIKeyHeader, key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Unknown Key", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, deleteWith("asmo_unknown").Code)
+	})
+
+	t.Run("Key Without Scope Is Forbidden", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, deleteWith(staffKey).Code)
+	})
+
+	t.Run("Scoped Key And Rate Limit", func(t *testing.T) {
+		w := deleteWith(projectsKey)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"key":1`)
+
+		assert.Equal(t, http.StatusOK, deleteWith(projectsKey).Code)
+
+		w = deleteWith(projectsKey)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	})
+}
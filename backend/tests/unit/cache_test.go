@@ -79,4 +79,23 @@ func TestRedisMockWithSlice(t *testing.T) {
 	assert.Len(t, retrieved, 2)
 	assert.Equal(t, "Project 1", retrieved[0].Name)
 	assert.Equal(t, "Project 2", retrieved[1].Name)
-}
\ No newline at end of file
+}
+
+func TestRedisMockDeletePattern(t *testing.T) {
+	mock := testutils.NewRedisMock()
+	defer mock.Close()
+
+	assert.NoError(t, mock.Set("web_projects:all", []int{1}, time.Minute))
+	assert.NoError(t, mock.Set("web_projects:all?page=2&per_page=10", []int{2}, time.Minute))
+	assert.NoError(t, mock.Set("web_project:1", 1, time.Minute))
+
+	err := mock.DeletePattern("web_projects:all*")
+	assert.NoError(t, err)
+
+	var list []int
+	assert.Equal(t, testutils.ErrNotFound, mock.Get("web_projects:all", &list))
+	assert.Equal(t, testutils.ErrNotFound, mock.Get("web_projects:all?page=2&per_page=10", &list))
+
+	var id int
+	assert.NoError(t, mock.Get("web_project:1", &id))
+}
@@ -0,0 +1,61 @@
+package unit
+
+import (
+	"testing"
+
+	"ASMO-site-backend/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormLimitsConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := config.Load()
+		assert.Equal(t, 5, cfg.FormRateLimitIP)
+		assert.Equal(t, 3, cfg.FormRateLimitEmail)
+		assert.Equal(t, 2, cfg.FormMaxLinks)
+	})
+
+	t.Run("Zero Disables Limits", func(t *testing.T) {
+		t.Setenv("FORM_RATE_LIMIT_IP", "0")
+		t.Setenv("FORM_RATE_LIMIT_EMAIL", "0")
+		t.Setenv("FORM_MAX_LINKS", "0")
+
+		cfg := config.Load()
+		assert.Equal(t, 0, cfg.FormRateLimitIP)
+		assert.Equal(t, 0, cfg.FormRateLimitEmail)
+		assert.Equal(t, 0, cfg.FormMaxLinks)
+	})
+
+	t.Run("Negative Falls Back To Default", func(t *testing.T) {
+		t.Setenv("FORM_RATE_LIMIT_IP", "-1")
+		assert.Equal(t, 5, config.Load().FormRateLimitIP)
+	})
+}
+
+func TestFormSecretConfig(t *testing.T) {
+	t.Run("Derived From JWT Secret Outside Production", func(t *testing.T) {
+		t.Setenv("JWT_SECRET", "unit-test-jwt-secret-unit-test-jwt-secret")
+		cfg := config.Load()
+		assert.Len(t, cfg.FormSecret, 64)
+		assert.NotContains(t, cfg.FormSecret, cfg.JWTSecret)
+	})
+
+	t.Run("Explicit Secret", func(t *testing.T) {
+		t.Setenv("FORM_SECRET", "unit-test-form-secret-unit-test-form-secret")
+		assert.Equal(t, "unit-test-form-secret-unit-test-form-secret", config.Load().FormSecret)
+	})
+
+	t.Run("Required In Production", func(t *testing.T) {
+		t.Setenv("ENVIRONMENT", "production")
+		t.Setenv("JWT_SECRET", "unit-test-jwt-secret-unit-test-jwt-secret")
+		assert.Empty(t, config.Load().FormSecret)
+	})
+}
+
+func TestNotifyRetriesConfig(t *testing.T) {
+	assert.Equal(t, 3, config.Load().NotifyRetries)
+
+	t.Setenv("NOTIFY_RETRIES", "0")
+	assert.Equal(t, 0, config.Load().NotifyRetries)
+}
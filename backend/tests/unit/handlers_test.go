@@ -0,0 +1,718 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ASMO-site-backend/internal/auth"
+	"ASMO-site-backend/internal/handlers"
+	"ASMO-site-backend/internal/middleware"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+	testutils "ASMO-site-backend/tests/testutils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupWebRouter(repo *testutils.ProjectsMock[models.WebProjects]) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewWebProjectsHandlerWithRepository(repo)
+
+	router := gin.New()
+	web := router.Group("/api/WebApplications")
+	{
+		web.GET("/:id", handler.GetWebProject)
+		web.GET("/", handler.GetWebProjects)
+		web.POST("/", handler.CreateWebProject)
+		web.PATCH("/:id", handler.PatchWebProject)
+		web.DELETE("/:id", handler.DeleteWebProject)
+		web.POST("/:id/restore", handler.RestoreWebProject)
+	}
+	return router
+}
+
+func TestWebProjectsHandlerWithMockRepository(t *testing.T) {
+	t.Run("Not Found", func(t *testing.T) {
+		router := setupWebRouter(&testutils.ProjectsMock[models.WebProjects]{})
+
+		req := httptest.NewRequest("GET", "/api/WebApplications/42", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Web project not found")
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		router := setupWebRouter(&testutils.ProjectsMock[models.WebProjects]{})
+
+		req := httptest.NewRequest("GET", "/api/WebApplications/abc_def", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Create Maps Request Fields", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			CRUDMock: testutils.CRUDMock[models.WebProjects]{
+				CreateFunc: func(ctx context.Context, fields repository.Fields) (models.WebProjects, error) {
+					return models.WebProjects{ID: 7}, nil
+				},
+			},
+		}
+		router := setupWebRouter(repo)
+
+		body, _ := json.Marshal(models.CreateWebProjectRequest{
+			Name:        "Valid Web Project Name Here",
+			Description: "This is a valid description that meets the minimum length requirement.",
+			Img:         "https://example.com/image.jpg",
+			Price:       1500.00,
+			TimeDevelop: 30,
+		})
+		req := httptest.NewRequest("POST", "/api/WebApplications/", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "Valid Web Project Name Here", repo.CRUDMock.LastFields["name"])
+		assert.Equal(t, 30, repo.CRUDMock.LastFields["time_develop"])
+
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, float64(7), response["id"])
+	})
+
+	t.Run("Patch Sends Only Given Fields", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			CRUDMock: testutils.CRUDMock[models.WebProjects]{
+				UpdateFunc: func(ctx context.Context, id int, fields repository.Fields) (models.WebProjects, error) {
+					return models.WebProjects{ID: id, Price: fields["price"].(float64)}, nil
+				},
+			},
+		}
+		router := setupWebRouter(repo)
+
+		req := httptest.NewRequest("PATCH", "/api/WebApplications/3", bytes.NewBufferString(`{"price": 0}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, repository.Fields{"price": 0.0}, repo.CRUDMock.LastFields)
+	})
+
+	t.Run("List Passes Filters", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{}
+		router := setupWebRouter(repo)
+
+		req := httptest.NewRequest("GET", "/api/WebApplications/?page=2&sort=price&price_max=5000", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 2, repo.LastQuery.Page)
+		assert.Equal(t, "price", repo.LastQuery.Sort)
+		// Публичный список дополнительно ограничен опубликованными проектами
+		assert.Len(t, repo.LastQuery.Filters, 2)
+		assert.Equal(t, "price_max", repo.LastQuery.Filters[0].Name)
+		assert.Equal(t, "status", repo.LastQuery.Filters[1].Name)
+	})
+
+	t.Run("Delete Missing Project", func(t *testing.T) {
+		router := setupWebRouter(&testutils.ProjectsMock[models.WebProjects]{})
+
+		req := httptest.NewRequest("DELETE", "/api/WebApplications/5", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+	t.Run("Restore From Trash", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			CRUDMock: testutils.CRUDMock[models.WebProjects]{
+				RestoreFunc: func(ctx context.Context, id int) (models.WebProjects, error) {
+					return models.WebProjects{ID: id, Name: "Restored Web Project"}, nil
+				},
+			},
+		}
+		router := setupWebRouter(repo)
+
+		req := httptest.NewRequest("POST", "/api/WebApplications/9/restore", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Restored Web Project")
+	})
+
+	t.Run("Restore Missing Project", func(t *testing.T) {
+		router := setupWebRouter(&testutils.ProjectsMock[models.WebProjects]{})
+
+		req := httptest.NewRequest("POST", "/api/WebApplications/9/restore", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Web project not found in trash")
+	})
+}
+
+func TestWebProjectsWorkflow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	draft := func(ctx context.Context, id int) (models.WebProjects, bool, error) {
+		return models.WebProjects{ID: id, Name: "Draft Web Project", Status: models.StatusDraft}, false, nil
+	}
+
+	// asAdmin имитирует RequireAuthForWrites и RequirePermissionForWrites для администратора с projects.manage
+	setup := func(repo *testutils.ProjectsMock[models.WebProjects], asAdmin bool) *gin.Engine {
+		handler := handlers.NewWebProjectsHandlerWithRepository(repo)
+		router := gin.New()
+		if asAdmin {
+			router.Use(func(c *gin.Context) {
+				c.Set(middleware.ContextUserID, 7)
+				c.Set(middleware.ContextPermissions, []string{auth.PermissionManageProjects})
+				c.Next()
+			})
+		}
+		router.GET("/api/WebApplications/:id", handler.GetWebProject)
+		router.GET("/api/WebApplications/", handler.GetWebProjects)
+		router.POST("/api/WebApplications/:id/publish", handler.TransitionWebProject("publish"))
+		return router
+	}
+
+	get := func(router *gin.Engine, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	t.Run("Draft Hidden From Public", func(t *testing.T) {
+		router := setup(&testutils.ProjectsMock[models.WebProjects]{CRUDMock: testutils.CRUDMock[models.WebProjects]{GetFunc: draft}}, false)
+		assert.Equal(t, http.StatusNotFound, get(router, "/api/WebApplications/3").Code)
+	})
+
+	t.Run("Admin Sees Draft", func(t *testing.T) {
+		router := setup(&testutils.ProjectsMock[models.WebProjects]{CRUDMock: testutils.CRUDMock[models.WebProjects]{GetFunc: draft}}, true)
+		assert.Equal(t, http.StatusOK, get(router, "/api/WebApplications/3").Code)
+	})
+
+	t.Run("Admin Without Projects Permission Sees Only Published", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{CRUDMock: testutils.CRUDMock[models.WebProjects]{GetFunc: draft}}
+		handler := handlers.NewWebProjectsHandlerWithRepository(repo)
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set(middleware.ContextUserID, 8)
+			c.Set(middleware.ContextPermissions, []string{auth.PermissionManageStaff})
+			c.Next()
+		})
+		router.GET("/api/WebApplications/:id", handler.GetWebProject)
+		router.GET("/api/WebApplications/", handler.GetWebProjects)
+
+		assert.Equal(t, http.StatusNotFound, get(router, "/api/WebApplications/3").Code)
+		assert.Equal(t, http.StatusOK, get(router, "/api/WebApplications/?status=draft").Code)
+		assert.Equal(t, []repository.Filter{{Name: "status", Condition: "status = $?", Value: models.StatusPublished}}, repo.LastQuery.Filters)
+	})
+
+	t.Run("Public List Ignores Status Param", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{}
+		router := setup(repo, false)
+		assert.Equal(t, http.StatusOK, get(router, "/api/WebApplications/?status=draft").Code)
+		assert.Equal(t, []repository.Filter{{Name: "status", Condition: "status = $?", Value: models.StatusPublished}}, repo.LastQuery.Filters)
+	})
+
+	t.Run("Admin List Without Status Sees All", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{}
+		router := setup(repo, true)
+		assert.Equal(t, http.StatusOK, get(router, "/api/WebApplications/").Code)
+		assert.Empty(t, repo.LastQuery.Filters)
+	})
+
+	t.Run("Publish Records Approver", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			WorkflowMock: testutils.WorkflowMock[models.WebProjects]{
+				TransitionFunc: func(ctx context.Context, id int, to string, from []string, fields repository.Fields) (models.WebProjects, error) {
+					assert.Equal(t, models.StatusPublished, to)
+					assert.Equal(t, []string{models.StatusInReview}, from)
+					return models.WebProjects{ID: id, Status: to}, nil
+				},
+			},
+		}
+		router := setup(repo, true)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/api/WebApplications/3/publish", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 7, repo.WorkflowMock.LastFields["approved_by"])
+		assert.Contains(t, repo.WorkflowMock.LastFields, "approved_at")
+	})
+
+	t.Run("Invalid Transition", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			WorkflowMock: testutils.WorkflowMock[models.WebProjects]{
+				TransitionFunc: func(ctx context.Context, id int, to string, from []string, fields repository.Fields) (models.WebProjects, error) {
+					return models.WebProjects{}, repository.ErrInvalidTransition
+				},
+			},
+		}
+		router := setup(repo, true)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/api/WebApplications/3/publish", nil))
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "Status transition not allowed")
+	})
+}
+
+func TestWebProjectsRevisions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := func(repo *testutils.ProjectsMock[models.WebProjects]) *gin.Engine {
+		handler := handlers.NewWebProjectsHandlerWithRepository(repo)
+		router := gin.New()
+		router.GET("/api/WebApplications/:id/revisions/", handler.GetWebProjectRevisions)
+		router.GET("/api/WebApplications/:id/revisions/:rev", handler.GetWebProjectRevision)
+		router.POST("/api/WebApplications/:id/revisions/:rev/restore", handler.RestoreWebProjectRevision)
+		return router
+	}
+
+	t.Run("Diff Against Current", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			RevisionsMock: testutils.RevisionsMock[models.WebProjects]{
+				RevisionFunc: func(ctx context.Context, id, rev int) (models.Revision, error) {
+					return models.Revision{Revision: rev, Snapshot: json.RawMessage(`{"id":3,"name":"Old Web Project Name","price":100}`)}, nil
+				},
+			},
+			CRUDMock: testutils.CRUDMock[models.WebProjects]{
+				GetFunc: func(ctx context.Context, id int) (models.WebProjects, bool, error) {
+					return models.WebProjects{ID: id, Name: "New Web Project Name", Price: 100}, false, nil
+				},
+			},
+		}
+		router := setup(repo)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/WebApplications/3/revisions/2", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Diff map[string]map[string]interface{} `json:"diff"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "Old Web Project Name", response.Diff["name"]["revision"])
+		assert.Equal(t, "New Web Project Name", response.Diff["name"]["current"])
+		assert.NotContains(t, response.Diff, "price")
+	})
+
+	t.Run("Revision Not Found", func(t *testing.T) {
+		router := setup(&testutils.ProjectsMock[models.WebProjects]{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/WebApplications/3/revisions/9", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid Revision", func(t *testing.T) {
+		router := setup(&testutils.ProjectsMock[models.WebProjects]{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/api/WebApplications/3/revisions/0/restore", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			RevisionsMock: testutils.RevisionsMock[models.WebProjects]{
+				RestoreRevisionFunc: func(ctx context.Context, id, rev int) (models.WebProjects, error) {
+					assert.Equal(t, 3, id)
+					assert.Equal(t, 2, rev)
+					return models.WebProjects{ID: id, Name: "Old Web Project Name"}, nil
+				},
+			},
+		}
+		router := setup(repo)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/api/WebApplications/3/revisions/2/restore", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Old Web Project Name")
+	})
+}
+
+func TestWebProjectsGallery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := func(repo *testutils.ProjectsMock[models.WebProjects]) *gin.Engine {
+		handler := handlers.NewWebProjectsHandlerWithRepository(repo)
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			// Админ видит черновики, как после RequireAuthForWrites и RequirePermissionForWrites
+			c.Set(middleware.ContextUserID, 1)
+			c.Set(middleware.ContextPermissions, []string{auth.PermissionManageProjects})
+			c.Next()
+		})
+		router.GET("/api/WebApplications/:id", handler.GetWebProject)
+		router.POST("/api/WebApplications/:id/media", handler.AddWebProjectMedia)
+		router.PUT("/api/WebApplications/:id/media/order", handler.ReorderWebProjectMedia)
+		return router
+	}
+
+	t.Run("Get Embeds Gallery", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			CRUDMock: testutils.CRUDMock[models.WebProjects]{
+				GetFunc: func(ctx context.Context, id int) (models.WebProjects, bool, error) {
+					return models.WebProjects{ID: id, Status: models.StatusPublished}, false, nil
+				},
+			},
+			GalleryMock: testutils.GalleryMock{
+				GalleryFunc: func(ctx context.Context, id int) ([]models.ProjectMedia, error) {
+					return []models.ProjectMedia{{ID: 1, Kind: models.GalleryVideo, Position: 1}}, nil
+				},
+			},
+		}
+
+		w := httptest.NewRecorder()
+		setup(repo).ServeHTTP(w, httptest.NewRequest("GET", "/api/WebApplications/3", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var project models.WebProjects
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+		assert.Len(t, project.Gallery, 1)
+	})
+
+	t.Run("Add Video Resolves Embed URL", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			GalleryMock: testutils.GalleryMock{
+				AddGalleryItemFunc: func(ctx context.Context, id int, item models.ProjectMedia) (models.ProjectMedia, error) {
+					assert.Equal(t, 3, id)
+					item.ID = 5
+					return item, nil
+				},
+			},
+		}
+
+		req := httptest.NewRequest("POST", "/api/WebApplications/3/media", bytes.NewBufferString(`{"kind": "video", "url": "https://youtu.be/dQw4w9WgXcQ", "caption": "Демо"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setup(repo).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var item models.ProjectMedia
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &item))
+		assert.Equal(t, "youtube", item.Provider)
+		assert.Equal(t, "https://www.youtube.com/embed/dQw4w9WgXcQ", item.EmbedURL)
+	})
+
+	t.Run("Add Rejects Unknown Video Host", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/WebApplications/3/media", bytes.NewBufferString(`{"kind": "video", "url": "https://example.com/watch"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setup(&testutils.ProjectsMock[models.WebProjects]{}).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Unsupported video URL")
+	})
+
+	t.Run("Add Image Requires Media ID", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/WebApplications/3/media", bytes.NewBufferString(`{"kind": "image"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setup(&testutils.ProjectsMock[models.WebProjects]{}).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "This field is required")
+	})
+
+	t.Run("Reorder Rejects Incomplete Order", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			GalleryMock: testutils.GalleryMock{
+				ReorderGalleryFunc: func(ctx context.Context, id int, itemIDs []int) ([]models.ProjectMedia, error) {
+					return nil, repository.ErrInvalidGalleryOrder
+				},
+			},
+		}
+
+		req := httptest.NewRequest("PUT", "/api/WebApplications/3/media/order", bytes.NewBufferString(`{"ids": [2, 1]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setup(repo).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestWebProjectsTags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := func(repo *testutils.ProjectsMock[models.WebProjects]) *gin.Engine {
+		handler := handlers.NewWebProjectsHandlerWithRepository(repo)
+		router := gin.New()
+		router.GET("/api/WebApplications", handler.GetWebProjects)
+		router.GET("/api/WebApplications/:id", handler.GetWebProject)
+		router.PUT("/api/WebApplications/:id/tags", handler.SetWebProjectTags)
+		return router
+	}
+
+	t.Run("List Normalizes Tags Filter", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{}
+
+		w := httptest.NewRecorder()
+		setup(repo).ServeHTTP(w, httptest.NewRequest("GET", "/api/WebApplications?tags=React,%20go,,react", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"go", "react"}, repo.LastQuery.Tags)
+	})
+
+	t.Run("Get Embeds Tags", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			CRUDMock: testutils.CRUDMock[models.WebProjects]{
+				GetFunc: func(ctx context.Context, id int) (models.WebProjects, bool, error) {
+					return models.WebProjects{ID: id, Status: models.StatusPublished}, false, nil
+				},
+			},
+			TagsMock: testutils.TagsMock{
+				TagsFunc: func(ctx context.Context, id int) ([]models.Tag, error) {
+					return []models.Tag{{ID: 1, Name: "Go", Slug: "go", Kind: models.TagKindTechnology}}, nil
+				},
+			},
+		}
+
+		w := httptest.NewRecorder()
+		setup(repo).ServeHTTP(w, httptest.NewRequest("GET", "/api/WebApplications/3", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var project models.WebProjects
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+		if assert.Len(t, project.Tags, 1) {
+			assert.Equal(t, "go", project.Tags[0].Slug)
+		}
+	})
+
+	t.Run("Set Passes Normalized Slugs", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			TagsMock: testutils.TagsMock{
+				SetTagsFunc: func(ctx context.Context, id int, slugs []string) ([]models.Tag, error) {
+					assert.Equal(t, 3, id)
+					assert.Equal(t, []string{"go", "react"}, slugs)
+					return []models.Tag{{Slug: "go"}, {Slug: "react"}}, nil
+				},
+			},
+		}
+
+		req := httptest.NewRequest("PUT", "/api/WebApplications/3/tags", bytes.NewBufferString(`{"tags": ["react", "Go", "go"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setup(repo).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"count":2`)
+	})
+
+	t.Run("Set Unknown Tag", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			TagsMock: testutils.TagsMock{
+				SetTagsFunc: func(ctx context.Context, id int, slugs []string) ([]models.Tag, error) {
+					return nil, repository.ErrTagNotFound
+				},
+			},
+		}
+
+		req := httptest.NewRequest("PUT", "/api/WebApplications/3/tags", bytes.NewBufferString(`{"tags": ["cobol"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setup(repo).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Tag not found")
+	})
+}
+
+func TestWebProjectsTeam(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := func(repo *testutils.ProjectsMock[models.WebProjects]) *gin.Engine {
+		handler := handlers.NewWebProjectsHandlerWithRepository(repo)
+		router := gin.New()
+		router.GET("/api/WebApplications/:id", handler.GetWebProject)
+		router.PUT("/api/WebApplications/:id/team/:staff_id", handler.AssignWebProjectMember)
+		router.DELETE("/api/WebApplications/:id/team/:staff_id", handler.UnassignWebProjectMember)
+		return router
+	}
+	assign := func(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PUT", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Get Hides Unpublished Members", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			CRUDMock: testutils.CRUDMock[models.WebProjects]{
+				GetFunc: func(ctx context.Context, id int) (models.WebProjects, bool, error) {
+					return models.WebProjects{ID: id, Status: models.StatusPublished}, false, nil
+				},
+			},
+			TeamMock: testutils.TeamMock{
+				TeamFunc: func(ctx context.Context, id int) ([]models.TeamMember, error) {
+					return []models.TeamMember{
+						{StaffID: 1, Name: "Published Member", Role: "backend", Status: models.StatusPublished},
+						{StaffID: 2, Name: "Draft Member", Role: "qa", Status: models.StatusDraft},
+					}, nil
+				},
+			},
+		}
+
+		w := httptest.NewRecorder()
+		setup(repo).ServeHTTP(w, httptest.NewRequest("GET", "/api/WebApplications/3", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var project models.WebProjects
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+		if assert.Len(t, project.Team, 1) {
+			assert.Equal(t, 1, project.Team[0].StaffID)
+			assert.Equal(t, "backend", project.Team[0].Role)
+		}
+	})
+
+	t.Run("Assign Passes Role", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			TeamMock: testutils.TeamMock{
+				AssignTeamMemberFunc: func(ctx context.Context, id, staffID int, role string) (models.TeamMember, error) {
+					assert.Equal(t, 3, id)
+					assert.Equal(t, 7, staffID)
+					return models.TeamMember{StaffID: staffID, Role: role}, nil
+				},
+			},
+		}
+
+		w := assign(setup(repo), "/api/WebApplications/3/team/7", `{"role": "designer"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"role":"designer"`)
+	})
+
+	t.Run("Assign Invalid Role", func(t *testing.T) {
+		w := assign(setup(&testutils.ProjectsMock[models.WebProjects]{}), "/api/WebApplications/3/team/7", `{"role": "astronaut"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Validation failed")
+	})
+
+	t.Run("Assign Unknown Staff", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			TeamMock: testutils.TeamMock{
+				AssignTeamMemberFunc: func(ctx context.Context, id, staffID int, role string) (models.TeamMember, error) {
+					return models.TeamMember{}, repository.ErrStaffNotFound
+				},
+			},
+		}
+
+		w := assign(setup(repo), "/api/WebApplications/3/team/99", `{"role": "qa"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Staff member not found")
+	})
+
+	t.Run("Unassign Missing Member", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		setup(&testutils.ProjectsMock[models.WebProjects]{}).ServeHTTP(w, httptest.NewRequest("DELETE", "/api/WebApplications/3/team/7", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Team member not found")
+	})
+}
+
+func TestStaffProfiles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := func(repo *testutils.StaffMock, asAdmin bool) *gin.Engine {
+		handler := handlers.NewStaffHandlerWithRepository(repo)
+		router := gin.New()
+		if asAdmin {
+			router.Use(func(c *gin.Context) {
+				c.Set(middleware.ContextUserID, 7)
+				c.Set(middleware.ContextPermissions, []string{auth.PermissionManageStaff})
+				c.Next()
+			})
+		}
+		router.GET("/api/Staff/", handler.GetStaff)
+		router.GET("/api/Staff/:id", handler.GetStaffMember)
+		router.POST("/api/Staff/", handler.CreateStaff)
+		router.PUT("/api/Staff/order", handler.ReorderStaff)
+		return router
+	}
+	private := func(ctx context.Context, id int) (models.Staff, bool, error) {
+		return models.Staff{ID: id, Email: "private@asmo.ru", Status: models.StatusPublished}, false, nil
+	}
+
+	t.Run("List Defaults To Display Order", func(t *testing.T) {
+		repo := &testutils.StaffMock{}
+
+		w := httptest.NewRecorder()
+		setup(repo, false).ServeHTTP(w, httptest.NewRequest("GET", "/api/Staff/?department=Backend", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "display_order", repo.LastQuery.Sort)
+		assert.Equal(t, "asc", repo.LastQuery.Order)
+		assert.Equal(t, "department", repo.LastQuery.Filters[0].Name)
+	})
+
+	t.Run("Hidden Email", func(t *testing.T) {
+		repo := &testutils.StaffMock{CRUDMock: testutils.CRUDMock[models.Staff]{GetFunc: private}}
+
+		w := httptest.NewRecorder()
+		setup(repo, false).ServeHTTP(w, httptest.NewRequest("GET", "/api/Staff/3", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "private@asmo.ru")
+
+		w = httptest.NewRecorder()
+		setup(repo, true).ServeHTTP(w, httptest.NewRequest("GET", "/api/Staff/3", nil))
+		assert.Contains(t, w.Body.String(), "private@asmo.ru")
+
+		// API-ключ без scope staff.manage видит только публичные контакты
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set(middleware.ContextAPIKeyID, 2)
+			c.Set(middleware.ContextPermissions, []string{auth.PermissionManageProjects})
+			c.Next()
+		})
+		router.GET("/api/Staff/:id", handlers.NewStaffHandlerWithRepository(repo).GetStaffMember)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/Staff/3", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "private@asmo.ru")
+	})
+
+	t.Run("Create Appends By Default", func(t *testing.T) {
+		repo := &testutils.StaffMock{}
+		body := `{"name": "Staff Member With Skills", "description": "Valid description that meets requirements",
+			"img": "https://example.com/staff.jpg", "role": "Developer"}`
+		req := httptest.NewRequest("POST", "/api/Staff/", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setup(repo, true).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.NotContains(t, repo.CRUDMock.LastFields, "display_order")
+		assert.Equal(t, []models.Skill{}, repo.CRUDMock.LastFields["skills"])
+	})
+
+	t.Run("Reorder", func(t *testing.T) {
+		repo := &testutils.StaffMock{
+			RosterMock: testutils.RosterMock{
+				ReorderFunc: func(ctx context.Context, ids []int) error {
+					if len(ids) != 3 {
+						return repository.ErrInvalidOrder
+					}
+					return nil
+				},
+			},
+		}
+
+		reorder := func(body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("PUT", "/api/Staff/order", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setup(repo, true).ServeHTTP(w, req)
+			return w
+		}
+		assert.Equal(t, http.StatusOK, reorder(`{"ids": [3, 1, 2]}`).Code)
+		assert.Equal(t, http.StatusBadRequest, reorder(`{"ids": [3, 1]}`).Code)
+		assert.Equal(t, http.StatusBadRequest, reorder(`{"ids": []}`).Code)
+	})
+}
@@ -0,0 +1,89 @@
+package unit
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"ASMO-site-backend/internal/imaging"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/webp"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestGenerateImageVariants(t *testing.T) {
+	t.Run("All Variants", func(t *testing.T) {
+		width, height, resized, err := imaging.Generate(encodePNG(t, image.NewRGBA(image.Rect(0, 0, 2000, 1000))))
+		assert.NoError(t, err)
+		assert.Equal(t, 2000, width)
+		assert.Equal(t, 1000, height)
+
+		if assert.Len(t, resized, 3) {
+			for i, expected := range []struct {
+				name          string
+				width, height int
+			}{{"thumbnail", 320, 160}, {"card", 640, 320}, {"hero", 1280, 640}} {
+				assert.Equal(t, expected.name, resized[i].Name)
+				assert.Equal(t, expected.height, resized[i].Height)
+
+				decoded, err := jpeg.Decode(bytes.NewReader(resized[i].JPEG))
+				assert.NoError(t, err)
+				assert.Equal(t, expected.width, decoded.Bounds().Dx())
+				assert.Equal(t, expected.height, decoded.Bounds().Dy())
+
+				decoded, err = webp.Decode(bytes.NewReader(resized[i].WebP))
+				assert.NoError(t, err)
+				assert.Equal(t, expected.width, decoded.Bounds().Dx())
+				assert.Equal(t, expected.height, decoded.Bounds().Dy())
+			}
+		}
+	})
+
+	t.Run("No Upscaling", func(t *testing.T) {
+		_, _, resized, err := imaging.Generate(encodePNG(t, image.NewRGBA(image.Rect(0, 0, 500, 500))))
+		assert.NoError(t, err)
+		if assert.Len(t, resized, 1) {
+			assert.Equal(t, "thumbnail", resized[0].Name)
+		}
+	})
+
+	t.Run("Transparent Background Becomes White", func(t *testing.T) {
+		_, _, resized, err := imaging.Generate(encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 400, 400))))
+		assert.NoError(t, err)
+		if assert.Len(t, resized, 1) {
+			decoded, err := jpeg.Decode(bytes.NewReader(resized[0].JPEG))
+			assert.NoError(t, err)
+			r, g, b, _ := decoded.At(10, 10).RGBA()
+			assert.Greater(t, r, uint32(0xf000))
+			assert.Greater(t, g, uint32(0xf000))
+			assert.Greater(t, b, uint32(0xf000))
+
+			// WebP сохраняет прозрачность
+			decoded, err = webp.Decode(bytes.NewReader(resized[0].WebP))
+			assert.NoError(t, err)
+			_, _, _, a := decoded.At(10, 10).RGBA()
+			assert.Zero(t, a)
+		}
+	})
+
+	t.Run("Invalid Image", func(t *testing.T) {
+		_, _, _, err := imaging.Generate([]byte("\x89PNG\r\n\x1a\nbroken"))
+		assert.ErrorIs(t, err, imaging.ErrInvalidImage)
+	})
+
+	t.Run("Too Many Pixels", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 10000, 5000))
+		img.Set(0, 0, color.White)
+		_, _, _, err := imaging.Generate(encodePNG(t, img))
+		assert.ErrorIs(t, err, imaging.ErrTooLarge)
+	})
+}
@@ -0,0 +1,128 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ASMO-site-backend/internal/handlers"
+	"ASMO-site-backend/internal/middleware"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/repository"
+	testutils "ASMO-site-backend/tests/testutils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.Locale([]string{"ru", "en"}, "ru"))
+	router.GET("/", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"locale":       c.GetString(middleware.ContextLocale),
+			"translate":    middleware.TranslationLocale(c),
+			"translatable": middleware.TranslatableLocales(c),
+		})
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptLanguage string
+		expected       string
+	}{
+		{"Default", "/", "", "ru"},
+		{"Query Param", "/?lang=en", "", "en"},
+		{"Query Param Wins Over Header", "/?lang=ru", "en", "ru"},
+		{"Unsupported Query Param Falls Back To Header", "/?lang=de", "en", "en"},
+		{"Region Subtag", "/", "en-US,en;q=0.9", "en"},
+		{"Quality Order", "/", "de;q=1.0, ru;q=0.5, en;q=0.8", "en"},
+		{"Nothing Supported", "/", "de, fr", "ru"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expected, w.Header().Get("Content-Language"))
+			assert.Contains(t, w.Body.String(), `"locale":"`+tt.expected+`"`)
+		})
+	}
+
+	t.Run("Default Locale Is Not Translated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		assert.Contains(t, w.Body.String(), `"translate":""`)
+		assert.Contains(t, w.Body.String(), `"translatable":["en"]`)
+	})
+}
+
+func TestWebProjectTranslations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := func(repo *testutils.ProjectsMock[models.WebProjects]) *gin.Engine {
+		handler := handlers.NewWebProjectsHandlerWithRepository(repo)
+		router := gin.New()
+		router.Use(middleware.Locale([]string{"ru", "en"}, "ru"))
+		router.PUT("/api/WebApplications/:id/translations/:locale", handler.SetWebProjectTranslation)
+		router.DELETE("/api/WebApplications/:id/translations/:locale", handler.DeleteWebProjectTranslation)
+		return router
+	}
+
+	body := `{"name": "Coffee shop landing", "description": "Landing page for a coffee shop with online menu."}`
+
+	t.Run("Set Translation", func(t *testing.T) {
+		repo := &testutils.ProjectsMock[models.WebProjects]{
+			TranslationsMock: testutils.TranslationsMock{
+				SetTranslationFunc: func(ctx context.Context, id int, locale string, fields repository.Fields) (models.Translation, error) {
+					assert.Equal(t, 3, id)
+					assert.Equal(t, "en", locale)
+					return models.Translation{Locale: locale, Name: fields["name"].(string)}, nil
+				},
+			},
+		}
+		router := setup(repo)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("PUT", "/api/WebApplications/3/translations/en", bytes.NewBufferString(body)))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Coffee shop landing", repo.TranslationsMock.LastFields["name"])
+	})
+
+	t.Run("Default Locale Rejected", func(t *testing.T) {
+		router := setup(&testutils.ProjectsMock[models.WebProjects]{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("PUT", "/api/WebApplications/3/translations/ru", bytes.NewBufferString(body)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Unsupported locale")
+	})
+
+	t.Run("Unknown Locale Rejected", func(t *testing.T) {
+		router := setup(&testutils.ProjectsMock[models.WebProjects]{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("PUT", "/api/WebApplications/3/translations/de", bytes.NewBufferString(body)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Delete Missing Translation", func(t *testing.T) {
+		router := setup(&testutils.ProjectsMock[models.WebProjects]{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/WebApplications/3/translations/en", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Web project translation not found")
+	})
+}
@@ -0,0 +1,178 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ASMO-site-backend/internal/handlers"
+	"ASMO-site-backend/internal/storage"
+	testutils "ASMO-site-backend/tests/testutils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	store := storage.NewLocal(dir, "http://localhost:3000/media/")
+
+	key, err := storage.NewKey(".png")
+	assert.NoError(t, err)
+	assert.Regexp(t, `^\d{4}/\d{2}/[0-9a-f]{32}\.png$`, key)
+	assert.Equal(t, "http://localhost:3000/media/"+key, store.URL(key))
+
+	assert.NoError(t, store.Put(context.Background(), key, []byte("data"), "image/png"))
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(key)))
+	assert.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+
+	assert.NoError(t, store.Delete(context.Background(), key))
+	_, err = os.Stat(filepath.Join(dir, filepath.FromSlash(key)))
+	assert.True(t, os.IsNotExist(err))
+
+	// Повторное удаление не ошибка
+	assert.NoError(t, store.Delete(context.Background(), key))
+}
+
+func TestS3StorageSignsRequests(t *testing.T) {
+	var method, path, authorization, contentType, contentHash string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		authorization = r.Header.Get("Authorization")
+		contentType = r.Header.Get("Content-Type")
+		contentHash = r.Header.Get("X-Amz-Content-Sha256")
+		body, _ = io.ReadAll(r.Body)
+		if strings.HasSuffix(r.URL.Path, "/missing-bucket.png") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := storage.NewS3(storage.S3Config{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    "media",
+		AccessKey: "access",
+		SecretKey: "secret",
+		PublicURL: "https://cdn.example.com/media/",
+	})
+
+	payload := []byte("image bytes")
+	assert.NoError(t, store.Put(context.Background(), "2024/05/cover.png", payload, "image/png"))
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/media/2024/05/cover.png", path)
+	assert.Equal(t, payload, body)
+	assert.Equal(t, "image/png", contentType)
+	sum := sha256.Sum256(payload)
+	assert.Equal(t, hex.EncodeToString(sum[:]), contentHash)
+	assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=access/"), authorization)
+	assert.Contains(t, authorization, "/us-east-1/s3/aws4_request")
+	assert.Contains(t, authorization, "SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date")
+
+	assert.Equal(t, "https://cdn.example.com/media/2024/05/cover.png", store.URL("2024/05/cover.png"))
+
+	assert.NoError(t, store.Delete(context.Background(), "2024/05/cover.png"))
+	assert.Equal(t, http.MethodDelete, method)
+
+	assert.Error(t, store.Put(context.Background(), "missing-bucket.png", payload, "image/png"))
+}
+
+func uploadMedia(router *gin.Engine, name string, data []byte) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", name)
+	part.Write(data)
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/admin/media/", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// Проверки размера и типа срабатывают до записи в хранилище и БД
+func TestMediaUploadRejections(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	handler := handlers.NewMediaHandler(nil, testutils.NewRedisMock(), storage.NewLocal(dir, "http://localhost/media"), 1024)
+
+	router := gin.New()
+	router.POST("/api/admin/media/", handler.UploadMedia)
+
+	t.Run("Too Large", func(t *testing.T) {
+		w := uploadMedia(router, "big.png", bytes.Repeat([]byte{0x89}, 2048))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), `"max_size":1024`)
+	})
+
+	t.Run("Unsupported Type", func(t *testing.T) {
+		w := uploadMedia(router, "script.png", []byte("#!/bin/sh\necho not an image\n"))
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+		assert.Contains(t, w.Body.String(), "image/webp")
+	})
+
+	t.Run("Import Requires HTTP URL", func(t *testing.T) {
+		router := gin.New()
+		router.POST("/api/admin/media/import", handler.ImportMedia)
+
+		req := httptest.NewRequest("POST", "/api/admin/media/import", strings.NewReader(`{"url": "ftp://example.com/cover.png"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Import Refuses Internal Addresses", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "0.0.0.0:0")
+		if !assert.NoError(t, err) {
+			return
+		}
+		hits := 0
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits++
+		}))
+		server.Listener = listener
+		server.Start()
+		defer server.Close()
+		port := listener.Addr().(*net.TCPAddr).Port
+
+		router := gin.New()
+		router.POST("/api/admin/media/import", handler.ImportMedia)
+
+		for _, host := range []string{"127.0.0.1", "0.0.0.0", "100.64.0.1"} {
+			body := fmt.Sprintf(`{"url": "http://%s:%d/cover.png"}`, host, port)
+			req := httptest.NewRequest("POST", "/api/admin/media/import", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code, host)
+		}
+		assert.Zero(t, hits, "internal addresses must not be dialed")
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/admin/media/", strings.NewReader(""))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries, "rejected uploads must not reach storage")
+}
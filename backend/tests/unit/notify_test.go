@@ -0,0 +1,216 @@
+package unit
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/notify"
+	"ASMO-site-backend/pkg/logger"
+	testutils "ASMO-site-backend/tests/testutils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingSender запоминает письма вместо отправки
+type recordingSender struct {
+	mutex    sync.Mutex
+	messages []notify.Message
+}
+
+func (s *recordingSender) Send(ctx context.Context, msg notify.Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func startSMTPServer(t *testing.T) *testutils.SMTPServer {
+	server, err := testutils.NewSMTPServer()
+	if err != nil {
+		t.Fatalf("failed to start SMTP server: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+	return server
+}
+
+func TestSMTPSender(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("test", logger.ERROR)
+	msg := notify.Message{
+		To:      []string{"team@asmo.test", "sales@asmo.test"},
+		Subject: "Новая заявка: Иван",
+		HTML:    "<p>Нужен интернет-магазин</p>",
+	}
+
+	t.Run("Delivers Encoded Message", func(t *testing.T) {
+		server := startSMTPServer(t)
+		sender := notify.NewSMTPSender(server.Host(), server.Port(), "", "", "ASMO <noreply@asmo.test>")
+		assert.NoError(t, sender.Send(ctx, msg))
+
+		messages := server.Messages()
+		if !assert.Len(t, messages, 1) {
+			return
+		}
+		assert.Equal(t, "noreply@asmo.test", messages[0].From)
+		assert.Equal(t, msg.To, messages[0].To)
+
+		parsed, err := mail.ReadMessage(strings.NewReader(messages[0].Data))
+		if !assert.NoError(t, err) {
+			return
+		}
+		var decoder mime.WordDecoder
+		subject, err := decoder.DecodeHeader(parsed.Header.Get("Subject"))
+		assert.NoError(t, err)
+		assert.Equal(t, msg.Subject, subject)
+		assert.Equal(t, "text/html; charset=UTF-8", parsed.Header.Get("Content-Type"))
+		assert.Contains(t, parsed.Header.Get("Message-ID"), "@asmo.test>")
+
+		body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+		assert.NoError(t, err)
+		assert.Equal(t, msg.HTML, strings.TrimSpace(string(body)))
+	})
+
+	t.Run("Retries Temporary Failures", func(t *testing.T) {
+		server := startSMTPServer(t)
+		server.FailFirst = 2
+		sender := notify.NewSMTPSender(server.Host(), server.Port(), "", "", "noreply@asmo.test")
+
+		notifier := notify.New(sender, msg.To, 3, time.Millisecond, log)
+		assert.NoError(t, notifier.Deliver(ctx, msg))
+		assert.Equal(t, 3, server.Attempts())
+		assert.Len(t, server.Messages(), 1)
+	})
+
+	t.Run("Gives Up After Retries", func(t *testing.T) {
+		server := startSMTPServer(t)
+		server.FailFirst = 10
+		sender := notify.NewSMTPSender(server.Host(), server.Port(), "", "", "noreply@asmo.test")
+
+		notifier := notify.New(sender, msg.To, 2, time.Millisecond, log)
+		assert.Error(t, notifier.Deliver(ctx, msg))
+		assert.Equal(t, 3, server.Attempts())
+	})
+
+	t.Run("Permanent Rejection Is Not Retried", func(t *testing.T) {
+		server := startSMTPServer(t)
+		server.Reject = true
+		sender := notify.NewSMTPSender(server.Host(), server.Port(), "", "", "noreply@asmo.test")
+
+		notifier := notify.New(sender, msg.To, 3, time.Millisecond, log)
+		assert.Error(t, notifier.Deliver(ctx, msg))
+		assert.Equal(t, 1, server.Attempts())
+	})
+}
+
+func TestNotificationTemplates(t *testing.T) {
+	log := logger.New("test", logger.ERROR)
+
+	t.Run("Lead Created", func(t *testing.T) {
+		sender := &recordingSender{}
+		notifier := notify.New(sender, []string{"team@asmo.test"}, 0, time.Millisecond, log)
+
+		budget := 1500000.0
+		projectID := 7
+		notifier.LeadCreated(models.Lead{
+			ID:          42,
+			Name:        "Иван <b>Петров</b>",
+			Contact:     "ivan@example.com",
+			Message:     "Нужен магазин <script>alert(1)</script>",
+			Budget:      &budget,
+			ProjectType: "mobile",
+			ProjectID:   &projectID,
+			CreatedAt:   time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC),
+		})
+		notifier.Wait()
+
+		if !assert.Len(t, sender.messages, 1) {
+			return
+		}
+		msg := sender.messages[0]
+		assert.Equal(t, []string{"team@asmo.test"}, msg.To)
+		assert.Equal(t, "Новая заявка: Иван <b>Петров</b>", msg.Subject)
+		assert.Contains(t, msg.HTML, "Новая заявка №42")
+		assert.Contains(t, msg.HTML, "Иван &lt;b&gt;Петров&lt;/b&gt;")
+		assert.NotContains(t, msg.HTML, "<script>")
+		assert.Contains(t, msg.HTML, "1 500 000")
+		assert.Contains(t, msg.HTML, "Мобильное приложение")
+		assert.Contains(t, msg.HTML, "№7")
+		assert.Contains(t, msg.HTML, "05.03.2026 14:30")
+	})
+
+	t.Run("Lead Without Optional Fields", func(t *testing.T) {
+		sender := &recordingSender{}
+		notifier := notify.New(sender, []string{"team@asmo.test"}, 0, time.Millisecond, log)
+
+		notifier.LeadCreated(models.Lead{ID: 1, Name: "Анна", Contact: "@anna", Message: "Нужен бот для записи"})
+		notifier.Wait()
+
+		if assert.Len(t, sender.messages, 1) {
+			assert.NotContains(t, sender.messages[0].HTML, "Бюджет")
+			assert.NotContains(t, sender.messages[0].HTML, "Тип проекта")
+		}
+	})
+
+	t.Run("Project Published", func(t *testing.T) {
+		sender := &recordingSender{}
+		notifier := notify.New(sender, []string{"team@asmo.test"}, 0, time.Millisecond, log)
+
+		notifier.ProjectPublished(notify.Project{Type: "web", ID: 3, Name: "Интернет-магазин", Slug: "internet-magazin", Price: 250000})
+		notifier.Wait()
+
+		if assert.Len(t, sender.messages, 1) {
+			assert.Equal(t, "Проект опубликован: Интернет-магазин", sender.messages[0].Subject)
+			assert.Contains(t, sender.messages[0].HTML, "Веб-приложение")
+			assert.Contains(t, sender.messages[0].HTML, "internet-magazin")
+			assert.Contains(t, sender.messages[0].HTML, "250 000")
+		}
+	})
+
+	t.Run("Shutdown Waits For Queued Messages", func(t *testing.T) {
+		server := startSMTPServer(t)
+		server.FailFirst = 1
+		sender := notify.NewSMTPSender(server.Host(), server.Port(), "", "", "noreply@asmo.test")
+		notifier := notify.New(sender, []string{"team@asmo.test"}, 1, 20*time.Millisecond, log)
+
+		notifier.LeadCreated(models.Lead{ID: 1, Name: "Анна", Contact: "@anna", Message: "Нужен бот для записи"})
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, notifier.Shutdown(ctx))
+		assert.Len(t, server.Messages(), 1, "retried message is delivered before shutdown returns")
+
+		var disabled *notify.Notifier
+		assert.NoError(t, disabled.Shutdown(ctx))
+	})
+
+	t.Run("Shutdown Gives Up After Timeout", func(t *testing.T) {
+		server := startSMTPServer(t)
+		server.FailFirst = 10
+		sender := notify.NewSMTPSender(server.Host(), server.Port(), "", "", "noreply@asmo.test")
+		notifier := notify.New(sender, []string{"team@asmo.test"}, 1, time.Second, log)
+
+		notifier.LeadCreated(models.Lead{ID: 1, Name: "Анна", Contact: "@anna", Message: "Нужен бот для записи"})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, notifier.Shutdown(ctx), context.DeadlineExceeded)
+		notifier.Wait()
+	})
+
+	t.Run("Disabled Notifier", func(t *testing.T) {
+		var notifier *notify.Notifier
+		notifier.LeadCreated(models.Lead{Name: "Анна"})
+		notifier.ProjectPublished(notify.Project{Name: "Проект"})
+		notifier.Wait()
+
+		sender := &recordingSender{}
+		notify.New(sender, nil, 0, time.Millisecond, log).LeadCreated(models.Lead{Name: "Анна"})
+		assert.Empty(t, sender.messages, "no recipients - nothing to send")
+	})
+}
@@ -0,0 +1,42 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ASMO-site-backend/internal/jobs"
+	"ASMO-site-backend/pkg/logger"
+	"ASMO-site-backend/tests/testutils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingSchedule struct {
+	calls int
+}
+
+func (s *countingSchedule) ApplySchedule(ctx context.Context, now time.Time) (int, error) {
+	s.calls++
+	return 0, nil
+}
+
+func TestPublishSchedulerLeaderElection(t *testing.T) {
+	lock := testutils.NewRedisMock()
+	first := &countingSchedule{}
+	second := &countingSchedule{}
+	log := logger.New("test", logger.ERROR)
+
+	replicaA := jobs.NewPublishScheduler(map[string]jobs.Scheduled{"web_projects": first}, lock, time.Minute, log)
+	replicaB := jobs.NewPublishScheduler(map[string]jobs.Scheduled{"web_projects": second}, lock, time.Minute, log)
+
+	assert.True(t, replicaA.RunOnce(context.Background(), time.Now()))
+	assert.False(t, replicaB.RunOnce(context.Background(), time.Now()))
+	assert.Equal(t, 1, first.calls)
+	assert.Equal(t, 0, second.calls)
+
+	// Блокировка истекла - тик достается любой реплике
+	lock.Delete("scheduler:publish:lock")
+	assert.True(t, replicaB.RunOnce(context.Background(), time.Now()))
+	assert.Equal(t, 1, second.calls)
+}
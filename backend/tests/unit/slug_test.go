@@ -0,0 +1,89 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ASMO-site-backend/internal/handlers"
+	"ASMO-site-backend/internal/models"
+	"ASMO-site-backend/internal/slug"
+	testutils "ASMO-site-backend/tests/testutils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugMake(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Latin", "My Web Project 2024", "my-web-project-2024"},
+		{"Cyrillic", "Интернет-магазин для Щукинской пиццерии", "internet-magazin-dlya-shchukinskoy-pitstserii"},
+		{"Hard And Soft Signs", "Подъезд и мебель", "podezd-i-mebel"},
+		{"Yo And Punctuation", "  Ёлка: CRM (бета)!  ", "elka-crm-beta"},
+		{"Numeric", "2024", "item-2024"},
+		{"Empty", "!!!", "item"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, slug.Make(tt.input))
+		})
+	}
+
+	t.Run("Length Limit", func(t *testing.T) {
+		s := slug.Make(strings.Repeat("проект ", 40))
+		assert.LessOrEqual(t, len(s), slug.MaxLength)
+		assert.True(t, slug.Valid(s))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		assert.True(t, slug.Valid("web-project-2"))
+		assert.False(t, slug.Valid("web_project"))
+		assert.False(t, slug.Valid("-web"))
+		assert.False(t, slug.Valid("Web"))
+	})
+}
+
+func TestGetBySlug(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := &testutils.ProjectsMock[models.WebProjects]{
+		CRUDMock: testutils.CRUDMock[models.WebProjects]{
+			ResolveSlugFunc: func(ctx context.Context, s string) (int, string, error) {
+				return 5, "new-web-project", nil
+			},
+			GetFunc: func(ctx context.Context, id int) (models.WebProjects, bool, error) {
+				return models.WebProjects{ID: id, Slug: "new-web-project", Status: models.StatusPublished}, false, nil
+			},
+		},
+	}
+	handler := handlers.NewWebProjectsHandlerWithRepository(repo)
+	router := gin.New()
+	router.GET("/api/WebApplications/:id", handler.GetWebProject)
+
+	t.Run("Current Slug", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/WebApplications/new-web-project", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":5`)
+	})
+
+	t.Run("Old Slug Redirects", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/WebApplications/old-web-project?lang=ru", nil))
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, "/api/WebApplications/new-web-project?lang=ru", w.Header().Get("Location"))
+	})
+
+	t.Run("Numeric ID Skips Slug Lookup", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/WebApplications/5", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
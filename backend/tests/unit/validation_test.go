@@ -105,4 +105,304 @@ func TestValidation(t *testing.T) {
 		assert.NotEmpty(t, errs)
 		assert.Equal(t, "name", errs[0].Field)
 	})
-}
\ No newline at end of file
+}
+func TestPatchValidation(t *testing.T) {
+	validation.Init()
+
+	t.Run("Empty Patch Is Valid", func(t *testing.T) {
+		errs := validation.ValidateStruct(models.PatchWebProjectRequest{})
+		assert.Empty(t, errs)
+	})
+
+	t.Run("Zero Price Is Allowed", func(t *testing.T) {
+		price := 0.0
+		errs := validation.ValidateStruct(models.PatchWebProjectRequest{Price: &price})
+		assert.Empty(t, errs)
+	})
+
+	t.Run("Invalid Patch - Short Name", func(t *testing.T) {
+		name := "Short"
+		errs := validation.ValidateStruct(models.PatchStaffRequest{Name: &name})
+		assert.NotEmpty(t, errs)
+		assert.Equal(t, "name", errs[0].Field)
+	})
+
+	t.Run("Invalid Patch - Invalid URL", func(t *testing.T) {
+		img := "invalid-url"
+		errs := validation.ValidateStruct(models.PatchMobileProjectRequest{Img: &img})
+		assert.NotEmpty(t, errs)
+		assert.Equal(t, "img", errs[0].Field)
+	})
+}
+
+func TestProjectTypeFieldsValidation(t *testing.T) {
+	validation.Init()
+
+	mobile := func(appStore, googlePlay string, platforms ...string) models.CreateMobileProjectRequest {
+		return models.CreateMobileProjectRequest{
+			Name:          "Valid Mobile Project Name",
+			Description:   "Valid description that meets requirements",
+			Img:           "https://example.com/image.jpg",
+			Price:         1500.00,
+			TimeDevelop:   30,
+			AppStoreURL:   appStore,
+			GooglePlayURL: googlePlay,
+			Platforms:     platforms,
+		}
+	}
+
+	bot := func(platform, handle string) models.CreateBotsProjectRequest {
+		return models.CreateBotsProjectRequest{
+			Name:        "Valid Bot Project Name Here",
+			Description: "Valid description that meets requirements",
+			Img:         "https://example.com/image.jpg",
+			Price:       1500.00,
+			TimeDevelop: 30,
+			Platform:    platform,
+			Handle:      handle,
+		}
+	}
+
+	t.Run("Valid Store URLs", func(t *testing.T) {
+		errs := validation.ValidateStruct(mobile(
+			"https://apps.apple.com/ru/app/asmo-shop/id1234567890",
+			"https://play.google.com/store/apps/details?id=ru.asmo.shop&hl=ru",
+			"ios", "android",
+		))
+		assert.Empty(t, errs)
+	})
+
+	t.Run("App Store URL Without Region And Name", func(t *testing.T) {
+		errs := validation.ValidateStruct(mobile("https://apps.apple.com/app/id1234567890", ""))
+		assert.Empty(t, errs)
+	})
+
+	t.Run("Invalid App Store URL", func(t *testing.T) {
+		for _, url := range []string{
+			"https://play.google.com/store/apps/details?id=ru.asmo.shop",
+			"http://apps.apple.com/ru/app/asmo-shop/id1234567890",
+			"https://apps.apple.com/ru/app/asmo-shop",
+		} {
+			errs := validation.ValidateStruct(mobile(url, ""))
+			if assert.Len(t, errs, 1, url) {
+				assert.Equal(t, "appstoreurl", errs[0].Field)
+				assert.Equal(t, "Invalid App Store URL", errs[0].Message)
+			}
+		}
+	})
+
+	t.Run("Invalid Google Play URL", func(t *testing.T) {
+		for _, url := range []string{
+			"https://apps.apple.com/app/id1234567890",
+			"https://play.google.com/store/apps/details?id=shop",
+			"https://play.google.com/store/apps/details",
+		} {
+			errs := validation.ValidateStruct(mobile("", url))
+			if assert.Len(t, errs, 1, url) {
+				assert.Equal(t, "googleplayurl", errs[0].Field)
+			}
+		}
+	})
+
+	t.Run("Unknown Or Duplicate Platforms", func(t *testing.T) {
+		errs := validation.ValidateStruct(mobile("", "", "ios", "windows"))
+		assert.NotEmpty(t, errs)
+
+		errs = validation.ValidateStruct(mobile("", "", "ios", "ios"))
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "Values must be unique", errs[0].Message)
+		}
+	})
+
+	t.Run("Bot Handles By Platform", func(t *testing.T) {
+		valid := [][2]string{
+			{"telegram", "asmo_support_bot"},
+			{"telegram", "@AsmoBot"},
+			{"discord", "asmo.helper"},
+			{"discord", "AsmoHelper#1234"},
+			{"vk", "asmo_studio"},
+			{"", "asmo"},
+		}
+		for _, tc := range valid {
+			assert.Empty(t, validation.ValidateStruct(bot(tc[0], tc[1])), tc)
+		}
+
+		invalid := [][2]string{
+			{"telegram", "asmo_support"},
+			{"telegram", "1asmobot"},
+			{"discord", "a"},
+			{"vk", "asmo"},
+			{"vk", "asmo studio"},
+		}
+		for _, tc := range invalid {
+			errs := validation.ValidateStruct(bot(tc[0], tc[1]))
+			if assert.Len(t, errs, 1, tc) {
+				assert.Equal(t, "handle", errs[0].Field)
+				assert.Equal(t, "Invalid bot handle for this platform", errs[0].Message)
+			}
+		}
+	})
+
+	t.Run("Platform Requires Handle", func(t *testing.T) {
+		errs := validation.ValidateStruct(bot("telegram", ""))
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "handle", errs[0].Field)
+			assert.Equal(t, "This field is required", errs[0].Message)
+		}
+
+		errs = validation.ValidateStruct(bot("icq", "asmobot"))
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "platform", errs[0].Field)
+		}
+	})
+
+	t.Run("Patch Handle Without Platform", func(t *testing.T) {
+		handle := "@asmo_helper"
+		assert.Empty(t, validation.ValidateStruct(models.PatchBotsProjectRequest{Handle: &handle}))
+
+		platform := "telegram"
+		assert.NotEmpty(t, validation.ValidateStruct(models.PatchBotsProjectRequest{Platform: &platform, Handle: &handle}))
+	})
+
+	t.Run("Web Stack And Live URL", func(t *testing.T) {
+		project := models.CreateWebProjectRequest{
+			Name:        "Valid Web Project Name Here",
+			Description: "Valid description that meets requirements",
+			Img:         "https://example.com/image.jpg",
+			Price:       1500.00,
+			TimeDevelop: 30,
+			LiveURL:     "https://asmo.example.com",
+			Stack:       []string{"Go", "PostgreSQL", "React"},
+		}
+		assert.Empty(t, validation.ValidateStruct(project))
+
+		project.Stack = []string{"Go", ""}
+		errs := validation.ValidateStruct(project)
+		assert.NotEmpty(t, errs)
+
+		project.Stack = nil
+		project.LiveURL = "asmo.example.com"
+		errs = validation.ValidateStruct(project)
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "liveurl", errs[0].Field)
+		}
+	})
+}
+
+func TestStaffProfileValidation(t *testing.T) {
+	validation.Init()
+
+	member := func(edit func(*models.CreateStaffRequest)) models.CreateStaffRequest {
+		req := models.CreateStaffRequest{
+			Name:        "Valid Staff Member Full Name",
+			Description: "Valid description that meets requirements",
+			Img:         "https://example.com/staff.jpg",
+			Role:        "Developer",
+		}
+		edit(&req)
+		return req
+	}
+
+	t.Run("Valid Profile", func(t *testing.T) {
+		errs := validation.ValidateStruct(member(func(r *models.CreateStaffRequest) {
+			r.Department = "Backend"
+			r.Skills = []models.Skill{{Name: "Go", Level: models.SkillExpert}, {Name: "PostgreSQL", Level: models.SkillAdvanced}}
+			r.Email = "dev@asmo.ru"
+			r.ShowEmail = true
+			r.GitHubURL = "https://github.com/asmo-dev"
+			r.LinkedInURL = "https://ru.linkedin.com/in/asmo-dev/"
+			r.TelegramURL = "https://t.me/asmo_dev"
+		}))
+		assert.Empty(t, errs)
+	})
+
+	t.Run("Invalid Links By Platform", func(t *testing.T) {
+		invalid := []func(*models.CreateStaffRequest){
+			func(r *models.CreateStaffRequest) { r.GitHubURL = "https://gitlab.com/asmo-dev" },
+			func(r *models.CreateStaffRequest) { r.GitHubURL = "https://github.com/asmo--dev" },
+			func(r *models.CreateStaffRequest) { r.LinkedInURL = "https://linkedin.com/feed/" },
+			func(r *models.CreateStaffRequest) { r.TelegramURL = "https://t.me/abc" },
+			func(r *models.CreateStaffRequest) { r.TelegramURL = "@asmo_dev" },
+		}
+		for i, edit := range invalid {
+			errs := validation.ValidateStruct(member(edit))
+			if assert.Len(t, errs, 1, i) {
+				assert.Equal(t, "Invalid profile link for this platform", errs[0].Message)
+			}
+		}
+	})
+
+	t.Run("Skills", func(t *testing.T) {
+		errs := validation.ValidateStruct(member(func(r *models.CreateStaffRequest) {
+			r.Skills = []models.Skill{{Name: "Go", Level: "guru"}}
+		}))
+		assert.NotEmpty(t, errs)
+
+		errs = validation.ValidateStruct(member(func(r *models.CreateStaffRequest) {
+			r.Skills = []models.Skill{{Name: "Go", Level: models.SkillBeginner}, {Name: "Go", Level: models.SkillExpert}}
+		}))
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "Values must be unique", errs[0].Message)
+		}
+	})
+
+	t.Run("Public Email Requires Address", func(t *testing.T) {
+		errs := validation.ValidateStruct(member(func(r *models.CreateStaffRequest) { r.ShowEmail = true }))
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "email", errs[0].Field)
+		}
+
+		errs = validation.ValidateStruct(member(func(r *models.CreateStaffRequest) { r.Email = "not-an-email" }))
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "Invalid email address", errs[0].Message)
+		}
+	})
+}
+
+func TestLeadValidation(t *testing.T) {
+	validation.Init()
+
+	lead := func(contact string) models.CreateLeadRequest {
+		return models.CreateLeadRequest{
+			Name:    "Иван",
+			Contact: contact,
+			Message: "Нужен интернет-магазин с каталогом и оплатой",
+		}
+	}
+
+	t.Run("Contacts", func(t *testing.T) {
+		for _, contact := range []string{"ivan@example.com", "+7 (999) 123-45-67", "89991234567", "@ivan_petrov"} {
+			assert.Empty(t, validation.ValidateStruct(lead(contact)), contact)
+		}
+
+		for _, contact := range []string{"ivan", "123-45", "@abc", "ivan@example", "+7 999 123 45 67 89 01 23"} {
+			errs := validation.ValidateStruct(lead(contact))
+			if assert.Len(t, errs, 1, contact) {
+				assert.Equal(t, "contact", errs[0].Field)
+			}
+		}
+	})
+
+	t.Run("Project Reference", func(t *testing.T) {
+		id := 5
+		req := lead("ivan@example.com")
+		req.ProjectID = &id
+		errs := validation.ValidateStruct(req)
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "projecttype", errs[0].Field)
+		}
+
+		req.ProjectType = "web"
+		assert.Empty(t, validation.ValidateStruct(req))
+
+		req.ProjectType = "desktop"
+		assert.Len(t, validation.ValidateStruct(req), 1)
+	})
+
+	t.Run("Negative Budget", func(t *testing.T) {
+		budget := -1.0
+		req := lead("ivan@example.com")
+		req.Budget = &budget
+		assert.Len(t, validation.ValidateStruct(req), 1)
+	})
+}
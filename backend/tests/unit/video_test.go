@@ -0,0 +1,46 @@
+package unit
+
+import (
+	"testing"
+
+	"ASMO-site-backend/internal/video"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVideoURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		provider string
+		embed    string
+	}{
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42", video.ProviderYouTube, "https://www.youtube.com/embed/dQw4w9WgXcQ"},
+		{"https://youtu.be/dQw4w9WgXcQ", video.ProviderYouTube, "https://www.youtube.com/embed/dQw4w9WgXcQ"},
+		{"https://youtube.com/shorts/dQw4w9WgXcQ", video.ProviderYouTube, "https://www.youtube.com/embed/dQw4w9WgXcQ"},
+		{"https://vimeo.com/76979871", video.ProviderVimeo, "https://player.vimeo.com/video/76979871"},
+		{"https://player.vimeo.com/video/76979871", video.ProviderVimeo, "https://player.vimeo.com/video/76979871"},
+		{"https://cdn.example.com/demo/preview.MP4", video.ProviderFile, "https://cdn.example.com/demo/preview.MP4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			embed, err := video.Parse(tt.url)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.provider, embed.Provider)
+			assert.Equal(t, tt.embed, embed.EmbedURL)
+		})
+	}
+
+	for _, url := range []string{
+		"https://www.youtube.com/watch?v=short",
+		"https://vimeo.com/channels/staffpicks",
+		"https://example.com/page.html",
+		"javascript:alert(1)",
+		"ftp://cdn.example.com/demo.mp4",
+	} {
+		t.Run("Reject "+url, func(t *testing.T) {
+			_, err := video.Parse(url)
+			assert.ErrorIs(t, err, video.ErrUnsupported)
+		})
+	}
+}